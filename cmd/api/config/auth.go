@@ -0,0 +1,89 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/auth"
+	errorhandling "github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/error_handling"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/logging"
+	"github.com/mercadolibre/fury_go-core/pkg/log"
+	"github.com/mercadolibre/fury_go-core/pkg/web"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	apiKeyHeader        = "X-API-Key"
+	bearerPrefix        = "Bearer "
+)
+
+// AuthConfig configures how bearer tokens are verified: with HMACSecret for
+// HS* tokens and with the keys in the JWKSPath file for RS* tokens.
+type AuthConfig struct {
+	HMACSecret string
+	JWKSPath   string
+	Issuer     string
+	Audience   string
+}
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, credentials auth.Credentials) (auth.Principal, error)
+}
+
+// Authenticate requires an API key or a bearer token and puts the resulting
+// auth.Principal in the request context.
+func Authenticate(authenticator Authenticator) HandlerMiddleware {
+	return func(handler web.Handler) web.Handler {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			ctx := r.Context()
+			principal, err := authenticator.Authenticate(ctx, credentials(r))
+			if err != nil {
+				var internalErr *domain.InternalError
+				if errors.As(err, &internalErr) {
+					logging.Error(ctx, "fail to authenticate request", log.Err(err))
+					return errorhandling.NewInternalServerAPIError("fail to authenticate request", nil)
+				}
+				logging.Warn(ctx, "request not authenticated", log.Err(err))
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				return errorhandling.NewUnauthorizedAPIError("invalid or missing credentials")
+			}
+			return handler(w, r.WithContext(auth.NewContext(ctx, principal)))
+		}
+	}
+}
+
+// RequireScopes rejects callers missing any of scopes. It must run after
+// Authenticate.
+func RequireScopes(scopes ...string) HandlerMiddleware {
+	return func(handler web.Handler) web.Handler {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			principal, ok := auth.FromContext(r.Context())
+			if !ok {
+				return errorhandling.NewUnauthorizedAPIError("invalid or missing credentials")
+			}
+			for _, scope := range scopes {
+				if !principal.HasScope(scope) {
+					logging.Warn(r.Context(), "request not authorized",
+						log.String("subject", principal.Subject),
+						log.String("required_scope", scope),
+					)
+					return errorhandling.NewForbiddenAPIError(fmt.Sprintf("missing required scope %s", scope))
+				}
+			}
+			return handler(w, r)
+		}
+	}
+}
+
+func credentials(r *http.Request) auth.Credentials {
+	var credentials auth.Credentials
+	if header := r.Header.Get(authorizationHeader); strings.HasPrefix(header, bearerPrefix) {
+		credentials.BearerToken = strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+	}
+	credentials.APIKey = r.Header.Get(apiKeyHeader)
+	return credentials
+}
@@ -5,10 +5,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
 
 	furyConfig "github.com/mercadolibre/fury_go-toolkit-config/v2/pkg/config"
 	"go.uber.org/zap/zapcore"
@@ -28,6 +30,31 @@ const (
 	fileExtension     = "yaml"
 	LimitSearchRows   = 100
 
+	defaultShutdownTimeoutSeconds  = 30
+	defaultShutdownPreDrainSeconds = 5
+	defaultCacheTTLSeconds         = 60
+
+	defaultIdempotencyExpirationHours      = 24
+	defaultIdempotencyPurgeIntervalMinutes = 60
+	defaultIdempotencyPurgeBatchSize       = 1000
+	defaultIdempotencyLeaseSeconds         = 60
+	defaultIdempotencyMaxBodyKiloBytes     = 1024
+
+	defaultScheduledPricesIntervalSeconds   = 60
+	defaultScheduledPricesBatchSize         = 100
+	defaultScheduledPricesMaxAttempts       = 5
+	defaultScheduledPricesRetryDelaySeconds = 300
+
+	defaultThumbnailsIntervalSeconds = 30
+	defaultThumbnailsBatchSize       = 20
+	defaultThumbnailsMaxAttempts     = 3
+
+	defaultUploadsMaxSizeKiloBytes = 5120
+	defaultUploadsMaxDimension     = 4096
+
+	defaultPriceFormat   = money.FormatNumber
+	defaultPriceCurrency = "BRL"
+
 	fileNotFoundError = "no such file or directory"
 )
 
@@ -40,11 +67,26 @@ var (
 )
 
 type Environment struct {
-	LogLevel       zapcore.Level `mapstructure:"logLevel"`
-	ScopeContainer string
-	MySQLConfig    domain.MySQL `mapstructure:"mysqlconfig"`
+	LogLevel         zapcore.Level `mapstructure:"logLevel"`
+	ScopeContainer   string
+	MySQLConfig      domain.MySQL          `mapstructure:"mysqlconfig"`
+	ConnectionConfig ConnectionConfig      `mapstructure:"connectionconfig"`
+	Health           HealthConfig          `mapstructure:"health"`
+	Shutdown         ShutdownConfig        `mapstructure:"shutdown"`
+	Cache            CacheConfig           `mapstructure:"cache"`
+	Auth             AuthConfig            `mapstructure:"auth"`
+	RateLimit        RateLimitConfig       `mapstructure:"ratelimit"`
+	Idempotency      IdempotencyConfig     `mapstructure:"idempotency"`
+	Pricing          PricingConfig         `mapstructure:"pricing"`
+	ExchangeRates    ExchangeRatesConfig   `mapstructure:"exchangerates"`
+	ScheduledPrices  ScheduledPricesConfig `mapstructure:"scheduledprices"`
+	Uploads          UploadsConfig         `mapstructure:"uploads"`
+	Thumbnails       ThumbnailsConfig      `mapstructure:"thumbnails"`
 }
 
+// ConnectionConfig tunes how lib/mysql runs each call: TimeoutSeconds is the
+// deadline of a whole read or transaction, and transient failures are retried
+// up to MaxRetries times, doubling RetryDelayMilliSeconds between attempts.
 type ConnectionConfig struct {
 	TimeoutSeconds         time.Duration
 	DisableTimeout         bool
@@ -53,6 +95,90 @@ type ConnectionConfig struct {
 	RetryDelayMilliSeconds time.Duration
 }
 
+// HealthConfig configures the readiness checks. The migrations check is
+// skipped when MigrationsPath is empty.
+type HealthConfig struct {
+	MigrationsPath          string
+	PingTimeoutMilliSeconds time.Duration
+}
+
+// ShutdownConfig bounds how long the service waits for in-flight requests and
+// background workers before closing its connections. PreDrainSeconds is the
+// part of that budget spent serving with readiness already failing, so the load
+// balancer stops routing before the server stops accepting connections.
+type ShutdownConfig struct {
+	TimeoutSeconds  time.Duration
+	PreDrainSeconds time.Duration
+}
+
+// CacheConfig configures the read-through cache in front of the product and
+// category services.
+type CacheConfig struct {
+	Enabled            bool
+	MaxEntries         int
+	ProductTTLSeconds  time.Duration
+	CategoryTTLSeconds time.Duration
+}
+
+// IdempotencyConfig configures how long Idempotency-Key responses are kept and
+// how often expired ones are purged. A key is reserved for LeaseSeconds while
+// its first request runs, so a key left behind by a crash is freed by then;
+// it must be longer than the slowest request.
+type IdempotencyConfig struct {
+	ExpirationHours      time.Duration
+	PurgeIntervalMinutes time.Duration
+	PurgeBatchSize       int
+	LeaseSeconds         time.Duration
+	MaxBodyKiloBytes     int64
+}
+
+// PricingConfig configures how prices are written in JSON and the currency
+// of prices sent without one. JSONFormat is one of the money.Format values;
+// "number" keeps the payload of clients written when prices were floats.
+type PricingConfig struct {
+	JSONFormat      string
+	DefaultCurrency string
+}
+
+// ExchangeRatesConfig points to a CSV file of base,quote,rate records loaded
+// into the exchange rates table on start. Nothing is loaded when Path is
+// empty.
+type ExchangeRatesConfig struct {
+	Path string
+}
+
+// ScheduledPricesConfig configures the worker that applies scheduled price
+// changes: every IntervalSeconds it applies up to BatchSize due changes. A
+// change that fails is retried RetryDelaySeconds later, up to MaxAttempts
+// times.
+type ScheduledPricesConfig struct {
+	IntervalSeconds   time.Duration
+	BatchSize         int
+	MaxAttempts       int
+	RetryDelaySeconds time.Duration
+}
+
+// UploadsConfig configures image uploads: files are written to Path and
+// served under BaseURL. Images over MaxSizeKiloBytes, or wider or taller than
+// MaxWidth and MaxHeight pixels, are rejected.
+type UploadsConfig struct {
+	Path             string
+	BaseURL          string
+	MaxSizeKiloBytes int64
+	MaxWidth         int
+	MaxHeight        int
+}
+
+// ThumbnailsConfig configures the worker that generates the renditions of
+// uploaded images: every IntervalSeconds it handles up to BatchSize pending
+// images. An image that fails is retried on the next run, up to MaxAttempts
+// times.
+type ThumbnailsConfig struct {
+	IntervalSeconds time.Duration
+	BatchSize       int
+	MaxAttempts     int
+}
+
 func InitConfig() Environment {
 	instance := Environment{}
 
@@ -90,6 +216,19 @@ func handlerEnvViper(environment *Environment) {
 	loadEnvironmentFile()
 	loadFuryConfig()
 	loadEnvironment(environment)
+
+	environment.Health.MigrationsPath = resolvePath(dir, environment.Health.MigrationsPath)
+	environment.Auth.JWKSPath = resolvePath(dir, environment.Auth.JWKSPath)
+	environment.ExchangeRates.Path = resolvePath(dir, environment.ExchangeRates.Path)
+	environment.Uploads.Path = resolvePath(dir, environment.Uploads.Path)
+}
+
+// resolvePath makes configured paths relative to the project root.
+func resolvePath(dir string, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
 }
 
 func loadEnvironment(environment *Environment) {
@@ -147,3 +286,152 @@ func handlerFormatScope() string {
 func (e *Environment) IsProduction() bool {
 	return e.ScopeContainer == envProduction
 }
+
+func (s ShutdownConfig) Timeout() time.Duration {
+	if s.TimeoutSeconds <= 0 {
+		return defaultShutdownTimeoutSeconds * time.Second
+	}
+	return s.TimeoutSeconds * time.Second
+}
+
+func (s ShutdownConfig) PreDrainDelay() time.Duration {
+	if s.PreDrainSeconds <= 0 {
+		return defaultShutdownPreDrainSeconds * time.Second
+	}
+	return s.PreDrainSeconds * time.Second
+}
+
+func (c CacheConfig) ProductTTL() time.Duration {
+	if c.ProductTTLSeconds <= 0 {
+		return defaultCacheTTLSeconds * time.Second
+	}
+	return c.ProductTTLSeconds * time.Second
+}
+
+func (c CacheConfig) CategoryTTL() time.Duration {
+	if c.CategoryTTLSeconds <= 0 {
+		return defaultCacheTTLSeconds * time.Second
+	}
+	return c.CategoryTTLSeconds * time.Second
+}
+
+func (c IdempotencyConfig) Expiration() time.Duration {
+	if c.ExpirationHours <= 0 {
+		return defaultIdempotencyExpirationHours * time.Hour
+	}
+	return c.ExpirationHours * time.Hour
+}
+
+func (c IdempotencyConfig) Lease() time.Duration {
+	if c.LeaseSeconds <= 0 {
+		return defaultIdempotencyLeaseSeconds * time.Second
+	}
+	return c.LeaseSeconds * time.Second
+}
+
+// MaxBodySize is the largest body of an idempotent request, in bytes.
+func (c IdempotencyConfig) MaxBodySize() int64 {
+	if c.MaxBodyKiloBytes <= 0 {
+		return defaultIdempotencyMaxBodyKiloBytes << 10
+	}
+	return c.MaxBodyKiloBytes << 10
+}
+
+func (c IdempotencyConfig) PurgeInterval() time.Duration {
+	if c.PurgeIntervalMinutes <= 0 {
+		return defaultIdempotencyPurgeIntervalMinutes * time.Minute
+	}
+	return c.PurgeIntervalMinutes * time.Minute
+}
+
+func (c IdempotencyConfig) PurgeLimit() int {
+	if c.PurgeBatchSize <= 0 {
+		return defaultIdempotencyPurgeBatchSize
+	}
+	return c.PurgeBatchSize
+}
+
+func (c PricingConfig) Format() money.Format {
+	if format := money.Format(c.JSONFormat); format.Valid() {
+		return format
+	}
+	return defaultPriceFormat
+}
+
+func (c PricingConfig) Currency() string {
+	if currency, ok := money.LookupCurrency(c.DefaultCurrency); ok {
+		return currency.Code
+	}
+	return defaultPriceCurrency
+}
+
+func (c ScheduledPricesConfig) Interval() time.Duration {
+	if c.IntervalSeconds <= 0 {
+		return defaultScheduledPricesIntervalSeconds * time.Second
+	}
+	return c.IntervalSeconds * time.Second
+}
+
+func (c ScheduledPricesConfig) Limit() int {
+	if c.BatchSize <= 0 {
+		return defaultScheduledPricesBatchSize
+	}
+	return c.BatchSize
+}
+
+func (c ScheduledPricesConfig) Attempts() int {
+	if c.MaxAttempts <= 0 {
+		return defaultScheduledPricesMaxAttempts
+	}
+	return c.MaxAttempts
+}
+
+func (c ScheduledPricesConfig) RetryDelay() time.Duration {
+	if c.RetryDelaySeconds <= 0 {
+		return defaultScheduledPricesRetryDelaySeconds * time.Second
+	}
+	return c.RetryDelaySeconds * time.Second
+}
+
+// MaxSize is the largest upload accepted, in bytes.
+func (c UploadsConfig) MaxSize() int64 {
+	if c.MaxSizeKiloBytes <= 0 {
+		return defaultUploadsMaxSizeKiloBytes << 10
+	}
+	return c.MaxSizeKiloBytes << 10
+}
+
+func (c UploadsConfig) Width() int {
+	if c.MaxWidth <= 0 {
+		return defaultUploadsMaxDimension
+	}
+	return c.MaxWidth
+}
+
+func (c UploadsConfig) Height() int {
+	if c.MaxHeight <= 0 {
+		return defaultUploadsMaxDimension
+	}
+	return c.MaxHeight
+}
+
+func (c ThumbnailsConfig) Interval() time.Duration {
+	if c.IntervalSeconds <= 0 {
+		return defaultThumbnailsIntervalSeconds * time.Second
+	}
+	return c.IntervalSeconds * time.Second
+}
+
+func (c ThumbnailsConfig) Limit() int {
+	if c.BatchSize <= 0 {
+		return defaultThumbnailsBatchSize
+	}
+	return c.BatchSize
+}
+
+func (c ThumbnailsConfig) Attempts() int {
+	if c.MaxAttempts <= 0 {
+		return defaultThumbnailsMaxAttempts
+	}
+	return c.MaxAttempts
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	errorhandling "github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/error_handling"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/i18n"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/logging"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/requestid"
+	"github.com/mercadolibre/fury_go-core/pkg/log"
+	"github.com/mercadolibre/fury_go-core/pkg/web"
+)
+
+const internalServerErrorMessage = "internal server error"
+
+// ErrorResponse is the single place where handler errors become responses:
+// every error is logged once, in English, at the level of its kind and
+// rendered in the language asked for in Accept-Language as an
+// errorhandling.ApiError with the request id, or as an RFC 7807 problem
+// document when the client prefers application/problem+json.
+func ErrorResponse() HandlerMiddleware {
+	return func(handler web.Handler) web.Handler {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			err := handler(w, r)
+			if err == nil {
+				return nil
+			}
+
+			ctx := r.Context()
+			apiErr, level := ToAPIError(err)
+			logHandlerError(ctx, level, apiErr, err)
+			apiErr = errorhandling.WithRequestID(apiErr, requestid.FromContext(ctx))
+
+			translator := i18n.Negotiate(r.Header.Get("Accept-Language"))
+			translate := func(message string) string {
+				return i18n.Translate(translator, message)
+			}
+			apiErr = errorhandling.Localize(apiErr, translate)
+			w.Header().Add("Vary", "Accept, Accept-Language")
+			w.Header().Set("Content-Language", i18n.LanguageTag(translator))
+			if errorhandling.AcceptsProblem(r.Header.Get("Accept")) {
+				problem := errorhandling.NewProblem(apiErr, r.URL.Path)
+				problem.Title = translate(problem.Title)
+				return writeProblem(w, problem)
+			}
+			return web.EncodeJSON(w, apiErr, apiErr.Status())
+		}
+	}
+}
+
+func writeProblem(w http.ResponseWriter, problem errorhandling.Problem) error {
+	w.Header().Set("Content-Type", errorhandling.ProblemContentType)
+	w.WriteHeader(problem.Status)
+	return json.NewEncoder(w).Encode(problem)
+}
+
+// ToAPIError maps err to the payload sent to clients and the level it is
+// logged at. The outermost known error in the chain wins. Internal errors only
+// expose their own message, never the wrapped cause, and unknown errors are
+// reported as a plain internal server error.
+func ToAPIError(err error) (errorhandling.ApiError, log.Level) {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if apiErr, level, ok := toAPIError(e); ok {
+			return apiErr, level
+		}
+	}
+	return errorhandling.NewInternalServerAPIError(internalServerErrorMessage, nil), log.ErrorLevel
+}
+
+func toAPIError(err error) (errorhandling.ApiError, log.Level, bool) {
+	if apiErr, level, ok := errorhandling.Handle(err); ok {
+		return apiErr, level, true
+	}
+
+	switch e := err.(type) {
+	case validator.ValidationErrors:
+		return errorhandling.NewValidationErrorsAPIError(e), log.WarnLevel, true
+	case errorhandling.ApiError:
+		return e, levelForStatus(e.Status()), true
+	case *domain.NotFoundError:
+		return errorhandling.NewNotFoundAPIError(e.Message), log.WarnLevel, true
+	case *domain.BadRequest:
+		return errorhandling.NewBadRequestAPIError(e.Message), log.WarnLevel, true
+	case *domain.ConflictError:
+		return errorhandling.NewAPIError(e.Message, "conflict_error", http.StatusConflict, errorhandling.CauseList{}), log.WarnLevel, true
+	case *domain.InternalError:
+		return errorhandling.NewInternalServerAPIError(e.Message, nil), log.ErrorLevel, true
+	case *domain.AppError:
+		return errorhandling.NewInternalServerAPIError(e.Message, nil), log.ErrorLevel, true
+	}
+	return nil, log.ErrorLevel, false
+}
+
+func levelForStatus(status int) log.Level {
+	if status >= http.StatusInternalServerError {
+		return log.ErrorLevel
+	}
+	return log.WarnLevel
+}
+
+func logHandlerError(ctx context.Context, level log.Level, apiErr errorhandling.ApiError, err error) {
+	fields := []log.Field{
+		log.Int("status", apiErr.Status()),
+		log.String("error_code", apiErr.Code()),
+		log.Err(err),
+	}
+	switch level {
+	case log.ErrorLevel:
+		logging.Error(ctx, apiErr.Message(), fields...)
+	case log.WarnLevel:
+		logging.Warn(ctx, apiErr.Message(), fields...)
+	case log.InfoLevel:
+		logging.Info(ctx, apiErr.Message(), fields...)
+	default:
+		logging.Debug(ctx, apiErr.Message(), fields...)
+	}
+}
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	errorhandling "github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/error_handling"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/requestid"
+	"github.com/mercadolibre/fury_go-core/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorResponse_RendersApiError(t *testing.T) {
+	handler := ErrorResponse()(func(w http.ResponseWriter, r *http.Request) error {
+		return errorhandling.NewBadRequestAPIError("invalid json body")
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/product", nil)
+	r = r.WithContext(requestid.NewContext(r.Context(), "abc"))
+	w := httptest.NewRecorder()
+	err := handler(w, r)
+
+	var body map[string]interface{}
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "invalid json body", body["message"])
+	assert.Equal(t, "bad_request", body["error"])
+	assert.Equal(t, float64(http.StatusBadRequest), body["status"])
+	assert.Equal(t, []interface{}{}, body["cause"])
+	assert.Equal(t, "abc", body["request_id"])
+}
+
+func TestErrorResponse_HidesInternalCause(t *testing.T) {
+	sqlErr := errors.New("Error 1146: Table 'catalog.produtcs' doesn't exist")
+	handler := ErrorResponse()(func(w http.ResponseWriter, r *http.Request) error {
+		return domain.NewInternalError("fail to get product", sqlErr)
+	})
+
+	w := httptest.NewRecorder()
+	err := handler(w, httptest.NewRequest(http.MethodGet, "/product/1", nil))
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "produtcs")
+	assert.Contains(t, w.Body.String(), "fail to get product")
+}
+
+func TestToAPIError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		message string
+		level   log.Level
+	}{
+		{
+			name:    "api error",
+			err:     errorhandling.NewForbiddenAPIError("missing required scope catalog:write"),
+			status:  http.StatusForbidden,
+			code:    "forbidden",
+			message: "missing required scope catalog:write",
+			level:   log.WarnLevel,
+		},
+		{
+			name:    "not found",
+			err:     domain.NewNotFoundError("product with ID 1 not found", nil),
+			status:  http.StatusNotFound,
+			code:    "not_found",
+			message: "product with ID 1 not found",
+			level:   log.WarnLevel,
+		},
+		{
+			name:    "bad request",
+			err:     domain.NewBadRequest("invalid id", nil),
+			status:  http.StatusBadRequest,
+			code:    "bad_request",
+			message: "invalid id",
+			level:   log.WarnLevel,
+		},
+		{
+			name:    "conflict",
+			err:     domain.NewConflictError("idempotency key already in use", nil),
+			status:  http.StatusConflict,
+			code:    "conflict_error",
+			message: "idempotency key already in use",
+			level:   log.WarnLevel,
+		},
+		{
+			name:    "request error",
+			err:     errorhandling.NewRequestError("limit parameter value is not an integer. limit = a"),
+			status:  http.StatusBadRequest,
+			code:    "bad_request",
+			message: "limit parameter value is not an integer. limit = a",
+			level:   log.WarnLevel,
+		},
+		{
+			name:    "sql error",
+			err:     errorhandling.NewSQLError(errors.New("Error 1054: Unknown column"), "fail to get product", "FindByID"),
+			status:  http.StatusInternalServerError,
+			code:    "internal_server_error",
+			message: "fail to get product",
+			level:   log.ErrorLevel,
+		},
+		{
+			name:    "wrapped",
+			err:     fmt.Errorf("get product: %w", domain.NewNotFoundError("product with ID 1 not found", nil)),
+			status:  http.StatusNotFound,
+			code:    "not_found",
+			message: "product with ID 1 not found",
+			level:   log.WarnLevel,
+		},
+		{
+			name:    "internal error wrapping a not found",
+			err:     domain.NewInternalError("fail to load product", domain.NewNotFoundError("category with id 3 not found", nil)),
+			status:  http.StatusInternalServerError,
+			code:    "internal_server_error",
+			message: "fail to load product",
+			level:   log.ErrorLevel,
+		},
+		{
+			name:    "unknown",
+			err:     errors.New("dial tcp 10.0.0.1:3306: connect: connection refused"),
+			status:  http.StatusInternalServerError,
+			code:    "internal_server_error",
+			message: "internal server error",
+			level:   log.ErrorLevel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr, level := ToAPIError(tt.err)
+
+			assert.Equal(t, tt.status, apiErr.Status())
+			assert.Equal(t, tt.code, apiErr.Code())
+			assert.Equal(t, tt.message, apiErr.Message())
+			assert.Empty(t, apiErr.Cause())
+			assert.Equal(t, tt.level, level)
+		})
+	}
+}
+
+func TestErrorResponse_RendersProblem(t *testing.T) {
+	handler := ErrorResponse()(func(w http.ResponseWriter, r *http.Request) error {
+		return domain.NewNotFoundError("product with ID 7 not found", nil)
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/product/7", nil)
+	r.Header.Set("Accept", "application/problem+json")
+	r = r.WithContext(requestid.NewContext(r.Context(), "abc"))
+	w := httptest.NewRecorder()
+	err := handler(w, r)
+
+	var problem errorhandling.Problem
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, errorhandling.Problem{
+		Type:      "/problems/not_found",
+		Title:     "Not Found",
+		Status:    http.StatusNotFound,
+		Detail:    "product with ID 7 not found",
+		Instance:  "/product/7",
+		RequestID: "abc",
+	}, problem)
+}
+
+func TestErrorResponse_RendersValidationErrors(t *testing.T) {
+	handler := ErrorResponse()(func(w http.ResponseWriter, r *http.Request) error {
+		product := dto.ProductDTO{Title: "TV", Description: "4K", Price: money.NewDecimal(-1, 0), Image: "tv.jpg"}
+		return product.Validate()
+	})
+
+	w := httptest.NewRecorder()
+	err := handler(w, httptest.NewRequest(http.MethodPost, "/product", nil))
+
+	var body struct {
+		Error string                     `json:"error"`
+		Cause []errorhandling.FieldError `json:"cause"`
+	}
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "validation_error", body.Error)
+	assert.Equal(t, []errorhandling.FieldError{
+		{Field: "price", Rule: "gte", Message: "price must be greater than or equal to 0"},
+		{Field: "category", Rule: "required", Message: "category is required"},
+	}, body.Cause)
+}
+
+func TestErrorResponse_LocalizesFromAcceptLanguage(t *testing.T) {
+	handler := ErrorResponse()(func(w http.ResponseWriter, r *http.Request) error {
+		product := dto.ProductDTO{Title: "TV", Description: "4K", Price: money.NewDecimal(-1, 0), Image: "tv.jpg", Category: "tv"}
+		return product.Validate()
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/product", nil)
+	r.Header.Set("Accept-Language", "pt-BR,pt;q=0.9,en;q=0.8")
+	w := httptest.NewRecorder()
+	err := handler(w, r)
+
+	var body struct {
+		Message string                     `json:"message"`
+		Error   string                     `json:"error"`
+		Cause   []errorhandling.FieldError `json:"cause"`
+	}
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, "pt-BR", w.Header().Get("Content-Language"))
+	assert.Equal(t, "Accept, Accept-Language", w.Header().Get("Vary"))
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "corpo da requisição inválido", body.Message)
+	assert.Equal(t, "validation_error", body.Error)
+	assert.Equal(t, []errorhandling.FieldError{
+		{Field: "price", Rule: "gte", Message: "price deve ser maior ou igual a 0"},
+	}, body.Cause)
+}
+
+func TestErrorResponse_LocalizesProblem(t *testing.T) {
+	handler := ErrorResponse()(func(w http.ResponseWriter, r *http.Request) error {
+		return domain.NewNotFoundError("product with ID 7 not found", nil)
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/product/7", nil)
+	r.Header.Set("Accept", "application/problem+json")
+	r.Header.Set("Accept-Language", "es-MX")
+	w := httptest.NewRecorder()
+	err := handler(w, r)
+
+	var problem errorhandling.Problem
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, "es", w.Header().Get("Content-Language"))
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "No encontrado", problem.Title)
+	assert.Equal(t, "producto con ID 7 no encontrado", problem.Detail)
+	assert.Equal(t, "/problems/not_found", problem.Type)
+}
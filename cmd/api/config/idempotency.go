@@ -0,0 +1,186 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/auth"
+	errorhandling "github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/error_handling"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/logging"
+	"github.com/mercadolibre/fury_go-core/pkg/log"
+	"github.com/mercadolibre/fury_go-core/pkg/web"
+)
+
+// replayedHeaders are the response headers stored with an idempotent response
+// and restored when it is replayed.
+var replayedHeaders = []string{"Content-Type", "Content-Language", "Location"}
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	anonymousIdempotencyScope = "anonymous"
+)
+
+type IdempotencyStore interface {
+	Begin(ctx context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error)
+	Complete(ctx context.Context, key domain.IdempotencyKey) error
+	Release(ctx context.Context, key domain.IdempotencyKey) error
+}
+
+// Idempotency replays the stored response of requests retried with the same
+// Idempotency-Key header. Keys are scoped by caller and route, and reusing one
+// with a different body is rejected with 422. Handler errors are rendered
+// here so that 4xx error responses are stored and replayed like any other
+// response below 500; 5xx responses release the key, so failed requests can be
+// retried with it, and so does a panic. Bodies larger than
+// idempotency.maxbodykilobytes are rejected with 413. Requests without the
+// header are not affected.
+func Idempotency(store IdempotencyStore, idempotencyConfig IdempotencyConfig) HandlerMiddleware {
+	return func(handler web.Handler) web.Handler {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			keyValue := r.Header.Get(idempotencyKeyHeader)
+			if keyValue == "" {
+				return handler(w, r)
+			}
+			if len(keyValue) > maxIdempotencyKeyLength {
+				return errorhandling.NewBadRequestAPIError("Idempotency-Key must have at most 255 characters")
+			}
+
+			ctx := r.Context()
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, idempotencyConfig.MaxBodySize()))
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					return errorhandling.NewAPIError(
+						fmt.Sprintf("request body must be at most %d KB", idempotencyConfig.MaxBodySize()>>10),
+						"request_entity_too_large",
+						http.StatusRequestEntityTooLarge,
+						errorhandling.CauseList{},
+					)
+				}
+				return errorhandling.NewBadRequestAPIError("fail to read request body")
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			key := domain.IdempotencyKey{
+				Scope:       idempotencyScope(r),
+				Key:         keyValue,
+				RequestHash: requestHash(body),
+			}
+			stored, found, err := store.Begin(ctx, key)
+			if err != nil {
+				var conflictErr *domain.ConflictError
+				if errors.As(err, &conflictErr) {
+					return idempotencyKeyInProgressError()
+				}
+				logging.Error(ctx, "fail to reserve idempotency key", log.Err(err))
+				return errorhandling.NewInternalServerAPIError("fail to reserve idempotency key", nil)
+			}
+			if found {
+				return replay(w, key, stored)
+			}
+
+			defer func() {
+				if p := recover(); p != nil {
+					release(ctx, store, key)
+					panic(p)
+				}
+			}()
+
+			recorder := &bodyRecorder{ResponseRecorder: NewResponseRecorder(w)}
+			err = ErrorResponse()(handler)(recorder, r)
+			if err != nil || recorder.Status() >= http.StatusInternalServerError {
+				release(ctx, store, key)
+				return err
+			}
+
+			key.StatusCode = recorder.Status()
+			key.ResponseBody = recorder.body.Bytes()
+			key.ResponseHeaders = map[string]string{}
+			for _, name := range replayedHeaders {
+				if value := recorder.Header().Get(name); value != "" {
+					key.ResponseHeaders[name] = value
+				}
+			}
+			if err := store.Complete(ctx, key); err != nil {
+				logging.Error(ctx, "fail to store idempotent response", log.Err(err))
+			}
+			return nil
+		}
+	}
+}
+
+// release frees key so the request can be retried with it. A key that fails
+// to be released is freed anyway once its lease expires.
+func release(ctx context.Context, store IdempotencyStore, key domain.IdempotencyKey) {
+	if err := store.Release(ctx, key); err != nil {
+		logging.Warn(ctx, "fail to release idempotency key", log.Err(err))
+	}
+}
+
+func replay(w http.ResponseWriter, key domain.IdempotencyKey, stored domain.IdempotencyKey) error {
+	if stored.RequestHash != key.RequestHash {
+		return errorhandling.NewAPIError(
+			"Idempotency-Key was already used with a different request body",
+			"idempotency_key_reused",
+			http.StatusUnprocessableEntity,
+			errorhandling.CauseList{},
+		)
+	}
+	if !stored.Completed() {
+		return idempotencyKeyInProgressError()
+	}
+	for name, value := range stored.ResponseHeaders {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(stored.StatusCode)
+	_, err := w.Write(stored.ResponseBody)
+	return err
+}
+
+func idempotencyKeyInProgressError() error {
+	return errorhandling.NewAPIError(
+		"a request with the same Idempotency-Key is still being processed",
+		"idempotency_key_in_progress",
+		http.StatusConflict,
+		errorhandling.CauseList{},
+	)
+}
+
+func idempotencyScope(r *http.Request) string {
+	subject := anonymousIdempotencyScope
+	if principal, ok := auth.FromContext(r.Context()); ok {
+		subject = principal.Subject
+	}
+	return subject + " " + r.Method + " " + r.URL.Path
+}
+
+// requestHash ignores insignificant whitespace of JSON bodies.
+func requestHash(body []byte) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, body); err == nil {
+		body = compacted.Bytes()
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+type bodyRecorder struct {
+	*ResponseRecorder
+	body bytes.Buffer
+}
+
+func (b *bodyRecorder) Write(p []byte) (int, error) {
+	b.body.Write(p)
+	return b.ResponseRecorder.Write(p)
+}
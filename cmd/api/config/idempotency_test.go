@@ -0,0 +1,266 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/auth"
+	errorhandling "github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/error_handling"
+	"github.com/stretchr/testify/assert"
+)
+
+type idempotencyStoreMock struct {
+	stored    domain.IdempotencyKey
+	found     bool
+	err       error
+	begun     domain.IdempotencyKey
+	completed *domain.IdempotencyKey
+	released  bool
+}
+
+func (s *idempotencyStoreMock) Begin(_ context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error) {
+	s.begun = key
+	return s.stored, s.found, s.err
+}
+
+func (s *idempotencyStoreMock) Complete(_ context.Context, key domain.IdempotencyKey) error {
+	s.completed = &key
+	return nil
+}
+
+func (s *idempotencyStoreMock) Release(_ context.Context, _ domain.IdempotencyKey) error {
+	s.released = true
+	return nil
+}
+
+func newIdempotentRequest(body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/product", strings.NewReader(body))
+	r.Header.Set("Idempotency-Key", "3f1c2a9e")
+	return r.WithContext(auth.NewContext(r.Context(), auth.Principal{Subject: "ck_0123abcd"}))
+}
+
+func TestIdempotency_StoresFirstResponse(t *testing.T) {
+	store := &idempotencyStoreMock{}
+	var received string
+	handler := Idempotency(store, IdempotencyConfig{})(func(w http.ResponseWriter, r *http.Request) error {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.WriteHeader(http.StatusCreated)
+		_, err := w.Write([]byte(`{"id":1}`))
+		return err
+	})
+
+	w := httptest.NewRecorder()
+	err := handler(w, newIdempotentRequest(`{"title": "TV"}`))
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, `{"title": "TV"}`, received)
+	assert.Equal(t, "ck_0123abcd POST /product", store.begun.Scope)
+	assert.Equal(t, "3f1c2a9e", store.begun.Key)
+	assert.Equal(t, requestHash([]byte(`{"title":"TV"}`)), store.begun.RequestHash)
+	if assert.NotNil(t, store.completed) {
+		assert.Equal(t, http.StatusCreated, store.completed.StatusCode)
+		assert.Equal(t, `{"id":1}`, string(store.completed.ResponseBody))
+	}
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	store := &idempotencyStoreMock{
+		found: true,
+		stored: domain.IdempotencyKey{
+			RequestHash:  requestHash([]byte(`{"title":"TV"}`)),
+			StatusCode:   http.StatusCreated,
+			ResponseBody: []byte(`{"id":1}`),
+		},
+	}
+	called := false
+	handler := Idempotency(store, IdempotencyConfig{})(func(w http.ResponseWriter, r *http.Request) error {
+		called = true
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	err := handler(w, newIdempotentRequest(`{"title":"TV"}`))
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.False(t, called)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `{"id":1}`, w.Body.String())
+	assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotency_Rejections(t *testing.T) {
+	tests := []struct {
+		name   string
+		store  *idempotencyStoreMock
+		status int
+	}{
+		{
+			name:   "different body",
+			store:  &idempotencyStoreMock{found: true, stored: domain.IdempotencyKey{RequestHash: "other", StatusCode: http.StatusCreated}},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "still in progress",
+			store:  &idempotencyStoreMock{found: true, stored: domain.IdempotencyKey{RequestHash: requestHash([]byte(`{"title":"TV"}`))}},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "reserved concurrently",
+			store:  &idempotencyStoreMock{err: domain.NewConflictError("idempotency key already in use", nil)},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "store failure",
+			store:  &idempotencyStoreMock{err: domain.NewInternalError("fail to create idempotency key", errors.New("timeout"))},
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := Idempotency(tt.store, IdempotencyConfig{})(func(w http.ResponseWriter, r *http.Request) error {
+				called = true
+				return nil
+			})
+
+			err := handler(httptest.NewRecorder(), newIdempotentRequest(`{"title":"TV"}`))
+
+			var apiErr errorhandling.ApiError
+			assert.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.status, apiErr.Status())
+			assert.False(t, called)
+		})
+	}
+}
+
+func TestIdempotency_ReleasesKeyOnError(t *testing.T) {
+	store := &idempotencyStoreMock{}
+	handler := Idempotency(store, IdempotencyConfig{})(func(w http.ResponseWriter, r *http.Request) error {
+		return errorhandling.NewInternalServerAPIError("fail to create product", nil)
+	})
+
+	w := httptest.NewRecorder()
+	err := handler(w, newIdempotentRequest(`{"title":"TV"}`))
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.True(t, store.released)
+	assert.Nil(t, store.completed)
+}
+
+func TestIdempotency_StoresClientErrorResponse(t *testing.T) {
+	store := &idempotencyStoreMock{}
+	handler := Idempotency(store, IdempotencyConfig{})(func(w http.ResponseWriter, r *http.Request) error {
+		return domain.NewNotFoundError("category with ID 9 not found", nil)
+	})
+
+	r := newIdempotentRequest(`{"title":"TV","category_id":9}`)
+	r.Header.Set("Accept", errorhandling.ProblemContentType)
+	w := httptest.NewRecorder()
+	err := handler(w, r)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.False(t, store.released)
+	if assert.NotNil(t, store.completed) {
+		assert.Equal(t, http.StatusNotFound, store.completed.StatusCode)
+		assert.Equal(t, w.Body.String(), string(store.completed.ResponseBody))
+		assert.Equal(t, errorhandling.ProblemContentType, store.completed.ResponseHeaders["Content-Type"])
+	}
+
+	replayStore := &idempotencyStoreMock{found: true, stored: *store.completed}
+	called := false
+	replayHandler := Idempotency(replayStore, IdempotencyConfig{})(func(w http.ResponseWriter, r *http.Request) error {
+		called = true
+		return nil
+	})
+
+	replayed := httptest.NewRecorder()
+	err = replayHandler(replayed, newIdempotentRequest(`{"title":"TV","category_id":9}`))
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.False(t, called)
+	assert.Equal(t, http.StatusNotFound, replayed.Code)
+	assert.Equal(t, w.Body.String(), replayed.Body.String())
+	assert.Equal(t, errorhandling.ProblemContentType, replayed.Header().Get("Content-Type"))
+	assert.Equal(t, "true", replayed.Header().Get("Idempotent-Replayed"))
+}
+
+func TestIdempotency_StoresAndReplaysHeaders(t *testing.T) {
+	store := &idempotencyStoreMock{}
+	handler := Idempotency(store, IdempotencyConfig{})(func(w http.ResponseWriter, r *http.Request) error {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", "/product/1")
+		w.Header().Set("X-Request-ID", "0f8fad5b")
+		w.WriteHeader(http.StatusCreated)
+		return nil
+	})
+
+	err := handler(httptest.NewRecorder(), newIdempotentRequest(`{"title":"TV"}`))
+
+	assert.NoError(t, err, "Error should not be returned")
+	if assert.NotNil(t, store.completed) {
+		assert.Equal(t, map[string]string{"Content-Type": "application/json", "Location": "/product/1"}, store.completed.ResponseHeaders)
+	}
+
+	replayStore := &idempotencyStoreMock{found: true, stored: *store.completed}
+	replayed := httptest.NewRecorder()
+	err = Idempotency(replayStore, IdempotencyConfig{})(func(w http.ResponseWriter, r *http.Request) error {
+		return nil
+	})(replayed, newIdempotentRequest(`{"title":"TV"}`))
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, "application/json", replayed.Header().Get("Content-Type"))
+	assert.Equal(t, "/product/1", replayed.Header().Get("Location"))
+	assert.Empty(t, replayed.Header().Get("X-Request-ID"))
+}
+
+func TestIdempotency_ReleasesKeyOnPanic(t *testing.T) {
+	store := &idempotencyStoreMock{}
+	handler := Idempotency(store, IdempotencyConfig{})(func(w http.ResponseWriter, r *http.Request) error {
+		panic("nil map")
+	})
+
+	assert.PanicsWithValue(t, "nil map", func() {
+		_ = handler(httptest.NewRecorder(), newIdempotentRequest(`{"title":"TV"}`))
+	})
+	assert.True(t, store.released)
+	assert.Nil(t, store.completed)
+}
+
+func TestIdempotency_BodyTooLarge(t *testing.T) {
+	store := &idempotencyStoreMock{}
+	called := false
+	handler := Idempotency(store, IdempotencyConfig{MaxBodyKiloBytes: 1})(func(w http.ResponseWriter, r *http.Request) error {
+		called = true
+		return nil
+	})
+
+	err := handler(httptest.NewRecorder(), newIdempotentRequest(`{"title":"`+strings.Repeat("a", 1024)+`"}`))
+
+	var apiErr errorhandling.ApiError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, apiErr.Status())
+	assert.Empty(t, store.begun.Key)
+	assert.False(t, called)
+}
+
+func TestIdempotency_WithoutHeader(t *testing.T) {
+	store := &idempotencyStoreMock{}
+	handler := Idempotency(store, IdempotencyConfig{})(func(w http.ResponseWriter, r *http.Request) error {
+		return nil
+	})
+
+	err := handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/product", strings.NewReader(`{}`)))
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Empty(t, store.begun.Key)
+}
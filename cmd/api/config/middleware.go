@@ -2,10 +2,24 @@ package config
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/logging"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/metrics"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/requestid"
+	"github.com/mercadolibre/fury_go-core/pkg/log"
 	"github.com/mercadolibre/fury_go-core/pkg/web"
 )
 
+const unmatchedRoute = "unmatched"
+
+var (
+	httpRequestsCounter = metrics.NewCounterVec("http_requests_total", "HTTP requests by route and status.", "method", "route", "status")
+	httpRequestDuration = metrics.NewHistogramVec("http_request_duration_seconds", "HTTP request latency by route and status.", metrics.DefaultBuckets, "method", "route", "status")
+)
+
 // JSONResponse adds the content-type json to every request.
 func JSONResponse() web.Middleware {
 	return func(handler http.HandlerFunc) http.HandlerFunc {
@@ -15,3 +29,118 @@ func JSONResponse() web.Middleware {
 		}
 	}
 }
+
+// HandlerMiddleware decorates a single route handler. Unlike web.Middleware it
+// sees the error returned by the handler.
+type HandlerMiddleware func(web.Handler) web.Handler
+
+// Chain applies middlewares to handler, the first one being the outermost.
+func Chain(handler web.Handler, middlewares ...HandlerMiddleware) web.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// RequestID reuses the X-Request-ID received from the caller or generates a
+// new one, and makes it available in the context and the response header.
+func RequestID() web.Middleware {
+	return func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			id := requestid.Resolve(r.Header.Get(requestid.Header))
+			w.Header().Set(requestid.Header, id)
+			handler(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+		}
+	}
+}
+
+// AccessLog emits one log entry per request once it has been served.
+func AccessLog() web.Middleware {
+	return func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := NewResponseRecorder(w)
+
+			handler(recorder, r)
+
+			logging.Info(r.Context(), "access",
+				log.String("method", r.Method),
+				log.String("route", RoutePattern(r)),
+				log.Int("status", recorder.Status()),
+				log.Duration("latency", time.Since(start)),
+				log.Int("bytes", recorder.BytesWritten()),
+			)
+		}
+	}
+}
+
+// Metrics records the count and latency of every request by route pattern,
+// so paths with ids do not create a series per id.
+func Metrics() web.Middleware {
+	return func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := NewResponseRecorder(w)
+
+			handler(recorder, r)
+
+			route := RoutePattern(r)
+			status := strconv.Itoa(recorder.Status())
+			httpRequestsCounter.Inc(r.Method, route, status)
+			httpRequestDuration.Observe(time.Since(start).Seconds(), r.Method, route, status)
+		}
+	}
+}
+
+// RoutePattern returns the pattern of the route that handled r. It is only
+// known after the router has matched the request.
+func RoutePattern(r *http.Request) string {
+	if routeContext := chi.RouteContext(r.Context()); routeContext != nil {
+		if pattern := routeContext.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return unmatchedRoute
+}
+
+// ResponseRecorder keeps the status code and the number of bytes written.
+type ResponseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func NewResponseRecorder(w http.ResponseWriter) *ResponseRecorder {
+	return &ResponseRecorder{ResponseWriter: w}
+}
+
+func (r *ResponseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *ResponseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+func (r *ResponseRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+func (r *ResponseRecorder) BytesWritten() int {
+	return r.bytes
+}
+
+func (r *ResponseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID_ReusesReceivedID(t *testing.T) {
+	var fromContext string
+	handler := RequestID()(func(w http.ResponseWriter, r *http.Request) {
+		fromContext = requestid.FromContext(r.Context())
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/products", nil)
+	r.Header.Set(requestid.Header, "abc-123")
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	assert.Equal(t, "abc-123", fromContext)
+	assert.Equal(t, "abc-123", w.Header().Get(requestid.Header))
+}
+
+func TestRequestID_GeneratesID(t *testing.T) {
+	var fromContext string
+	handler := RequestID()(func(w http.ResponseWriter, r *http.Request) {
+		fromContext = requestid.FromContext(r.Context())
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	assert.NotEmpty(t, fromContext)
+	assert.Equal(t, fromContext, w.Header().Get(requestid.Header))
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/auth"
+	errorhandling "github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/error_handling"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/logging"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/metrics"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/ratelimit"
+	"github.com/mercadolibre/fury_go-core/pkg/log"
+	"github.com/mercadolibre/fury_go-core/pkg/web"
+)
+
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+	retryAfterHeader         = "Retry-After"
+	forwardedForHeader       = "X-Forwarded-For"
+)
+
+var rateLimitedCounter = metrics.NewCounterVec("http_rate_limited_requests_total", "Requests rejected by the rate limiter.", "group")
+
+// RateLimitConfig configures one token bucket per client and route group.
+// Groups without a configuration are not limited. X-Forwarded-For is only
+// used to identify clients when TrustForwardedFor is set, i.e. when the
+// service runs behind a proxy that overwrites it.
+type RateLimitConfig struct {
+	Enabled           bool
+	TrustForwardedFor bool
+	Groups            map[string]RateLimitGroupConfig
+}
+
+type RateLimitGroupConfig struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+func (c RateLimitConfig) Limit(group string) ratelimit.Limit {
+	if !c.Enabled {
+		return ratelimit.Limit{}
+	}
+	groupConfig := c.Groups[group]
+	return ratelimit.Limit{Rate: groupConfig.RequestsPerSecond, Burst: groupConfig.Burst}
+}
+
+// RateLimit takes a token from the bucket of the client for group and rejects
+// the request with 429 when it is empty. Clients are identified by the
+// principal put in the context by Authenticate when it runs after it, and
+// otherwise by IP, which is how authenticated routes are also limited before
+// checking credentials. The request goes through when store fails.
+func RateLimit(store ratelimit.Store, group string, rateLimitConfig RateLimitConfig) HandlerMiddleware {
+	limit := rateLimitConfig.Limit(group)
+	return func(handler web.Handler) web.Handler {
+		if !limit.Enabled() {
+			return handler
+		}
+		return func(w http.ResponseWriter, r *http.Request) error {
+			ctx := r.Context()
+			result, err := store.Take(ctx, group+":"+clientKey(r, rateLimitConfig.TrustForwardedFor), limit)
+			if err != nil {
+				logging.Warn(ctx, "fail to check rate limit", log.String("group", group), log.Err(err))
+				return handler(w, r)
+			}
+
+			header := w.Header()
+			header.Set(rateLimitLimitHeader, strconv.Itoa(result.Limit))
+			header.Set(rateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+			header.Set(rateLimitResetHeader, ceilSeconds(result.Reset))
+			if !result.Allowed {
+				rateLimitedCounter.Inc(group)
+				header.Set(retryAfterHeader, ceilSeconds(result.RetryAfter))
+				return errorhandling.NewTooManyRequestsError("rate limit exceeded, retry later")
+			}
+			return handler(w, r)
+		}
+	}
+}
+
+// clientKey never trusts unverified credentials: a bucket per presented API
+// key would let callers bypass the limit, and fill the store, with random keys.
+func clientKey(r *http.Request, trustForwardedFor bool) string {
+	if principal, ok := auth.FromContext(r.Context()); ok {
+		return "principal:" + principal.Method + ":" + principal.Subject
+	}
+	if trustForwardedFor {
+		if forwarded := r.Header.Get(forwardedForHeader); forwarded != "" {
+			return "ip:" + strings.TrimSpace(strings.Split(forwarded, ",")[0])
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/auth"
+	errorhandling "github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/error_handling"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+type rateLimitStoreMock struct {
+	result ratelimit.Result
+	err    error
+	key    string
+	limit  ratelimit.Limit
+}
+
+func (s *rateLimitStoreMock) Take(_ context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	s.key = key
+	s.limit = limit
+	return s.result, s.err
+}
+
+var rateLimitConfig = RateLimitConfig{
+	Enabled: true,
+	Groups: map[string]RateLimitGroupConfig{
+		"write": {RequestsPerSecond: 5, Burst: 20},
+	},
+}
+
+func TestRateLimit_Allowed(t *testing.T) {
+	store := &rateLimitStoreMock{result: ratelimit.Result{Allowed: true, Limit: 20, Remaining: 19, Reset: 200 * time.Millisecond}}
+	called := false
+	handler := RateLimit(store, "write", rateLimitConfig)(func(w http.ResponseWriter, r *http.Request) error {
+		called = true
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/product", nil)
+	r = r.WithContext(auth.NewContext(r.Context(), auth.Principal{Subject: "ck_1a2b3c4d", Method: auth.MethodAPIKey}))
+	err := handler(w, r)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.True(t, called)
+	assert.Equal(t, "write:principal:api_key:ck_1a2b3c4d", store.key)
+	assert.Equal(t, ratelimit.Limit{Rate: 5, Burst: 20}, store.limit)
+	assert.Equal(t, "20", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "19", w.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Reset"))
+	assert.Empty(t, w.Header().Get("Retry-After"))
+}
+
+func TestRateLimit_Exceeded(t *testing.T) {
+	store := &rateLimitStoreMock{result: ratelimit.Result{Limit: 20, RetryAfter: 1500 * time.Millisecond, Reset: 4 * time.Second}}
+	called := false
+	handler := RateLimit(store, "write", rateLimitConfig)(func(w http.ResponseWriter, r *http.Request) error {
+		called = true
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	err := handler(w, httptest.NewRequest(http.MethodPost, "/product", nil))
+
+	var apiErr errorhandling.ApiError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.Status())
+	assert.False(t, called)
+	assert.Equal(t, "write:ip:192.0.2.1", store.key)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+}
+
+func TestRateLimit_StoreFailureLetsRequestThrough(t *testing.T) {
+	store := &rateLimitStoreMock{err: errors.New("connection refused")}
+	called := false
+	handler := RateLimit(store, "write", rateLimitConfig)(func(w http.ResponseWriter, r *http.Request) error {
+		called = true
+		return nil
+	})
+
+	err := handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/product", nil))
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.True(t, called)
+}
+
+func TestRateLimit_GroupWithoutLimit(t *testing.T) {
+	store := &rateLimitStoreMock{}
+	handler := RateLimit(store, "read", rateLimitConfig)(func(w http.ResponseWriter, r *http.Request) error {
+		return nil
+	})
+
+	err := handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Empty(t, store.key)
+}
+
+func TestRateLimit_UnverifiedAPIKeysShareBucket(t *testing.T) {
+	handler := RateLimit(ratelimit.NewMemoryStore(), "write", RateLimitConfig{
+		Enabled: true,
+		Groups:  map[string]RateLimitGroupConfig{"write": {RequestsPerSecond: 0.001, Burst: 1}},
+	})(func(w http.ResponseWriter, r *http.Request) error {
+		return nil
+	})
+
+	first := httptest.NewRequest(http.MethodPost, "/product", nil)
+	first.Header.Set("X-API-Key", "ck_random1")
+	second := httptest.NewRequest(http.MethodPost, "/product", nil)
+	second.Header.Set("X-API-Key", "ck_random2")
+
+	assert.NoError(t, handler(httptest.NewRecorder(), first), "Error should not be returned")
+	var apiErr errorhandling.ApiError
+	assert.ErrorAs(t, handler(httptest.NewRecorder(), second), &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.Status())
+}
+
+func TestRateLimit_ByIPBeforeAuthenticate(t *testing.T) {
+	authenticator := &authenticatorMock{err: auth.ErrInvalidToken}
+	handler := Chain(func(w http.ResponseWriter, r *http.Request) error {
+		return nil
+	}, RateLimit(ratelimit.NewMemoryStore(), "auth", RateLimitConfig{
+		Enabled: true,
+		Groups:  map[string]RateLimitGroupConfig{"auth": {RequestsPerSecond: 0.001, Burst: 1}},
+	}), Authenticate(authenticator))
+
+	for _, apiKey := range []string{"ck_random1", "ck_random2"} {
+		r := httptest.NewRequest(http.MethodPost, "/product", nil)
+		r.Header.Set("X-API-Key", apiKey)
+		_ = handler(httptest.NewRecorder(), r)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/product", nil)
+	r.Header.Set("X-API-Key", "ck_random3")
+	var apiErr errorhandling.ApiError
+	assert.ErrorAs(t, handler(httptest.NewRecorder(), r), &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.Status())
+	assert.Equal(t, 1, authenticator.calls)
+}
+
+func TestClientKey_ForwardedFor(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/products", nil)
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+
+	assert.Equal(t, "ip:192.0.2.1", clientKey(r, false))
+	assert.Equal(t, "ip:203.0.113.7", clientKey(r, true))
+}
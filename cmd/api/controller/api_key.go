@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/service"
+	"github.com/mercadolibre/fury_go-core/pkg/web"
+)
+
+type APIKeyController interface {
+	HandleCreateAPIKey(w http.ResponseWriter, r *http.Request) error
+	HandleGetAPIKeys(w http.ResponseWriter, r *http.Request) error
+	HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request) error
+}
+
+type apiKeyController struct {
+	apiKeyService service.APIKeyService
+}
+
+func NewAPIKeyController(apiKeyService service.APIKeyService) APIKeyController {
+	return &apiKeyController{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// HandleCreateAPIKey godoc
+// @Summary Create API key
+// @Description Create an API key. The key is only returned in this response.
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param apiKey body dto.APIKeyDTO true "api key"
+// @Success 201 {object} dto.APIKeyCreatedResponseDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:admin"]
+// @Router /admin/api-keys [post]
+func (a *apiKeyController) HandleCreateAPIKey(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var apiKeyDTO dto.APIKeyDTO
+	if err := json.NewDecoder(r.Body).Decode(&apiKeyDTO); err != nil {
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if err := apiKeyDTO.Validate(); err != nil {
+		return err
+	}
+
+	apiKey, err := a.apiKeyService.CreateAPIKey(ctx, apiKeyDTO)
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, apiKey, http.StatusCreated)
+}
+
+// HandleGetAPIKeys godoc
+// @Summary Get API keys
+// @Description Get API keys, without the keys themselves
+// @Tags admin
+// @Produce  json
+// @Success 200 {array} dto.APIKeyResponseDTO
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:admin"]
+// @Router /admin/api-keys [get]
+func (a *apiKeyController) HandleGetAPIKeys(w http.ResponseWriter, r *http.Request) error {
+	apiKeys, err := a.apiKeyService.GetAPIKeys(r.Context())
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, apiKeys, http.StatusOK)
+}
+
+// HandleRevokeAPIKey godoc
+// @Summary Revoke API key
+// @Description Revoke API key
+// @Tags admin
+// @Produce  json
+// @Param id path int true "api key id"
+// @Success 204
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:admin"]
+// @Router /admin/api-keys/{id} [delete]
+func (a *apiKeyController) HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	apiKeyID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid api key id", err)
+	}
+
+	if err = a.apiKeyService.RevokeAPIKey(ctx, int64(apiKeyID)); err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, nil, http.StatusNoContent)
+}
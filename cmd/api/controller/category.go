@@ -2,7 +2,6 @@ package controller
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
@@ -35,22 +34,33 @@ func NewCategoryController(categoryService service.CategoryService) CategoryCont
 // @Accept  json
 // @Produce  json
 // @Param category body string true "category"
+// @Param Idempotency-Key header string false "replays the response of a previous request with the same key"
 // @Success 201 {object} domain.Category
 // @Failure 400 {object} ErrorMessage
 // @Failure 400 {object} ErrorMessage
 // @Failure 404 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 409 {object} ErrorMessage
+// @Failure 413 {object} ErrorMessage
+// @Failure 422 {object} ErrorMessage
 // @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
 // @Router /category [post]
 func (c *categoryController) HandleCreateCategory(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
-	var categoryDomain domain.Category
-	if err := json.NewDecoder(r.Body).Decode(&categoryDomain); err != nil {
-		appErr := domain.NewInternalError("error unmarshalling request body", err)
-		return domain.ConvertToWebErr(appErr)
+	var categoryDTO dto.CategoryDTO
+	if err := json.NewDecoder(r.Body).Decode(&categoryDTO); err != nil {
+		return domain.NewBadRequest("invalid json body", err)
 	}
-	category, err := c.categoryService.CreateCategory(ctx, categoryDomain)
+	if err := categoryDTO.Validate(); err != nil {
+		return err
+	}
+	category, err := c.categoryService.CreateCategory(ctx, domain.Category{Name: categoryDTO.Name})
 	if err != nil {
-		return domain.ConvertToWebErr(err)
+		return err
 	}
 
 	return web.EncodeJSON(w, category, http.StatusCreated)
@@ -85,7 +95,7 @@ func (c *categoryController) HandleGetCategories(w http.ResponseWriter, r *http.
 
 	categories, err := c.categoryService.GetCategories(ctx, params)
 	if err != nil {
-		return domain.ConvertToWebErr(err)
+		return err
 	}
 
 	return web.EncodeJSON(w, categories, http.StatusOK)
@@ -103,7 +113,12 @@ func (c *categoryController) HandleGetCategories(w http.ResponseWriter, r *http.
 // @Failure 400 {object} ErrorMessage
 // @Failure 400 {object} ErrorMessage
 // @Failure 404 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
 // @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
 // @Router /category/{id} [put]
 func (c *categoryController) HandleUpdateCategory(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
@@ -111,17 +126,18 @@ func (c *categoryController) HandleUpdateCategory(w http.ResponseWriter, r *http
 
 	productID, err := web.ParamInt(r, "id")
 	if err != nil {
-		apiErr := domain.NewBadRequest(fmt.Sprintf("invalid product id: %d", productID), err)
-		return domain.ConvertToWebErr(apiErr)
+		return domain.NewBadRequest("invalid category id", err)
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&categoryDTO); err != nil {
-		appErr := domain.NewInternalError("error unmarshalling request body", err)
-		return domain.ConvertToWebErr(appErr)
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if err := categoryDTO.Validate(); err != nil {
+		return err
 	}
 	category, err := c.categoryService.UpdateCategory(ctx, categoryDTO, int64(productID))
 	if err != nil {
-		return domain.ConvertToWebErr(err)
+		return err
 	}
 
 	return web.EncodeJSON(w, category, http.StatusOK)
@@ -138,19 +154,23 @@ func (c *categoryController) HandleUpdateCategory(w http.ResponseWriter, r *http
 // @Failure 400 {object} ErrorMessage
 // @Failure 400 {object} ErrorMessage
 // @Failure 404 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
 // @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
 // @Router /category/{id} [delete]
 func (c *categoryController) HandleDeleteCategory(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	categoryID, err := web.ParamInt(r, "id")
 	if err != nil {
-		apiErr := domain.NewBadRequest(fmt.Sprintf("invalid category id: %d", categoryID), err)
-		return domain.ConvertToWebErr(apiErr)
+		return domain.NewBadRequest("invalid category id", err)
 	}
 
 	_, err = c.categoryService.DeleteCategory(ctx, int64(categoryID))
 	if err != nil {
-		return domain.ConvertToWebErr(err)
+		return err
 	}
 
 	return web.EncodeJSON(w, nil, http.StatusOK)
@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/service"
+	"github.com/mercadolibre/fury_go-core/pkg/web"
+)
+
+type CategoryAttributeController interface {
+	HandleGetCategoryAttributes(w http.ResponseWriter, r *http.Request) error
+	HandleCreateCategoryAttribute(w http.ResponseWriter, r *http.Request) error
+	HandleUpdateCategoryAttribute(w http.ResponseWriter, r *http.Request) error
+	HandleDeleteCategoryAttribute(w http.ResponseWriter, r *http.Request) error
+}
+
+type categoryAttributeController struct {
+	categoryAttributeService service.CategoryAttributeService
+}
+
+func NewCategoryAttributeController(categoryAttributeService service.CategoryAttributeService) CategoryAttributeController {
+	return &categoryAttributeController{
+		categoryAttributeService: categoryAttributeService,
+	}
+}
+
+// HandleGetCategoryAttributes godoc
+// @Summary Get category attributes
+// @Description Get the attributes the products of a category may have, by name
+// @Tags categories
+// @Produce  json
+// @Param id path int true "category id"
+// @Success 200 {array} dto.AttributeDefinitionDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Router /category/{id}/attributes [get]
+func (c *categoryAttributeController) HandleGetCategoryAttributes(w http.ResponseWriter, r *http.Request) error {
+	categoryID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid category id", err)
+	}
+
+	attributes, err := c.categoryAttributeService.GetAttributes(r.Context(), int64(categoryID))
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, attributes, http.StatusOK)
+}
+
+// HandleCreateCategoryAttribute godoc
+// @Summary Create category attribute
+// @Description Define an attribute for the products of a category. Values of number attributes are stored without trailing zeros and those of boolean attributes as true or false
+// @Tags categories
+// @Accept  json
+// @Produce  json
+// @Param id path int true "category id"
+// @Param attribute body dto.AttributeDefinitionDTO true "attribute"
+// @Param Idempotency-Key header string false "replays the response of a previous request with the same key"
+// @Success 201 {object} dto.AttributeDefinitionDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 409 {object} ErrorMessage
+// @Failure 413 {object} ErrorMessage
+// @Failure 422 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /category/{id}/attributes [post]
+func (c *categoryAttributeController) HandleCreateCategoryAttribute(w http.ResponseWriter, r *http.Request) error {
+	categoryID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid category id", err)
+	}
+	var attributeDTO dto.AttributeDefinitionDTO
+	if err = json.NewDecoder(r.Body).Decode(&attributeDTO); err != nil {
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if err = attributeDTO.Validate(); err != nil {
+		return err
+	}
+
+	attribute, err := c.categoryAttributeService.CreateAttribute(r.Context(), int64(categoryID), attributeDTO)
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, attribute, http.StatusCreated)
+}
+
+// HandleUpdateCategoryAttribute godoc
+// @Summary Update category attribute
+// @Description Update whether an attribute is required and its allowed values. Its name and type cannot change, and the values products already have are checked again when they are updated
+// @Tags categories
+// @Accept  json
+// @Produce  json
+// @Param id path int true "category id"
+// @Param attributeId path int true "attribute id"
+// @Param attribute body dto.AttributeDefinitionDTO true "attribute"
+// @Success 200 {object} dto.AttributeDefinitionDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /category/{id}/attributes/{attributeId} [put]
+func (c *categoryAttributeController) HandleUpdateCategoryAttribute(w http.ResponseWriter, r *http.Request) error {
+	categoryID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid category id", err)
+	}
+	id, err := web.ParamInt(r, "attributeId")
+	if err != nil {
+		return domain.NewBadRequest("invalid attribute id", err)
+	}
+	var attributeDTO dto.AttributeDefinitionDTO
+	if err = json.NewDecoder(r.Body).Decode(&attributeDTO); err != nil {
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if err = attributeDTO.Validate(); err != nil {
+		return err
+	}
+
+	attribute, err := c.categoryAttributeService.UpdateAttribute(r.Context(), int64(categoryID), int64(id), attributeDTO)
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, attribute, http.StatusOK)
+}
+
+// HandleDeleteCategoryAttribute godoc
+// @Summary Delete category attribute
+// @Description Delete an attribute of a category along with the values products have for it
+// @Tags categories
+// @Produce  json
+// @Param id path int true "category id"
+// @Param attributeId path int true "attribute id"
+// @Success 204
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /category/{id}/attributes/{attributeId} [delete]
+func (c *categoryAttributeController) HandleDeleteCategoryAttribute(w http.ResponseWriter, r *http.Request) error {
+	categoryID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid category id", err)
+	}
+	id, err := web.ParamInt(r, "attributeId")
+	if err != nil {
+		return domain.NewBadRequest("invalid attribute id", err)
+	}
+
+	if err = c.categoryAttributeService.DeleteAttribute(r.Context(), int64(categoryID), int64(id)); err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, nil, http.StatusNoContent)
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/service"
+	"github.com/mercadolibre/fury_go-core/pkg/web"
+)
+
+type HealthController interface {
+	HandleLiveness(w http.ResponseWriter, r *http.Request) error
+	HandleReadiness(w http.ResponseWriter, r *http.Request) error
+}
+
+type healthController struct {
+	healthService service.HealthService
+}
+
+func NewHealthController(healthService service.HealthService) HealthController {
+	return &healthController{
+		healthService: healthService,
+	}
+}
+
+// HandleLiveness godoc
+// @Summary Liveness probe
+// @Description Reports that the process is running
+// @Tags health
+// @Produce  json
+// @Success 200 {object} dto.HealthResponse
+// @Router /health/live [get]
+func (h *healthController) HandleLiveness(w http.ResponseWriter, r *http.Request) error {
+	return web.EncodeJSON(w, h.healthService.Liveness(r.Context()), http.StatusOK)
+}
+
+// HandleReadiness godoc
+// @Summary Readiness probe
+// @Description Reports whether the service and its dependencies can take traffic
+// @Tags health
+// @Produce  json
+// @Success 200 {object} dto.HealthResponse
+// @Failure 503 {object} dto.HealthResponse
+// @Router /health/ready [get]
+func (h *healthController) HandleReadiness(w http.ResponseWriter, r *http.Request) error {
+	health := h.healthService.Readiness(r.Context())
+	return web.EncodeJSON(w, health, readinessStatus(health))
+}
+
+func readinessStatus(health dto.HealthResponse) int {
+	if !health.IsUp() {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/metrics"
+)
+
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// HandleMetrics godoc
+// @Summary Prometheus metrics
+// @Description Exposes the service metrics in the Prometheus text format
+// @Tags metrics
+// @Produce  plain
+// @Success 200 {string} string
+// @Router /metrics [get]
+func HandleMetrics(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Set("Content-Type", prometheusContentType)
+	w.WriteHeader(http.StatusOK)
+	metrics.WritePrometheus(w)
+	return nil
+}
@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	errorhandling "github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/error_handling"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/service"
+	"github.com/mercadolibre/fury_go-core/pkg/web"
+)
+
+// maxPriceChangeReasonLength is the size of price_history.reason.
+const maxPriceChangeReasonLength = 255
+
+type PriceListController interface {
+	HandleGetProductPrices(w http.ResponseWriter, r *http.Request) error
+	HandleSetProductPrice(w http.ResponseWriter, r *http.Request) error
+	HandleDeleteProductPrice(w http.ResponseWriter, r *http.Request) error
+	HandleGetExchangeRates(w http.ResponseWriter, r *http.Request) error
+	HandleGetPriceHistory(w http.ResponseWriter, r *http.Request) error
+}
+
+type priceListController struct {
+	priceListService    service.PriceListService
+	exchangeRateService service.ExchangeRateService
+}
+
+func NewPriceListController(priceListService service.PriceListService, exchangeRateService service.ExchangeRateService) PriceListController {
+	return &priceListController{
+		priceListService:    priceListService,
+		exchangeRateService: exchangeRateService,
+	}
+}
+
+// HandleGetProductPrices godoc
+// @Summary Get product prices
+// @Description Get the prices of a product in the price list of each currency
+// @Tags prices
+// @Produce  json
+// @Param id path int true "product id"
+// @Success 200 {array} dto.ProductPriceDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Router /product/{id}/prices [get]
+func (p *priceListController) HandleGetProductPrices(w http.ResponseWriter, r *http.Request) error {
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+
+	prices, err := p.priceListService.GetPrices(r.Context(), int64(productID))
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, prices, http.StatusOK)
+}
+
+// HandleSetProductPrice godoc
+// @Summary Set product price
+// @Description Create or replace the price of a product in the price list of a currency
+// @Tags prices
+// @Accept  json
+// @Produce  json
+// @Param id path int true "product id"
+// @Param currency path string true "ISO 4217 currency"
+// @Param price body dto.ProductPriceDTO true "price"
+// @Success 200 {object} dto.ProductPriceDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /product/{id}/prices/{currency} [put]
+func (p *priceListController) HandleSetProductPrice(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+	currency := strings.ToUpper(web.Param(r, "currency"))
+
+	// The body is read in the currency of the path, which it may repeat.
+	priceDTO := dto.ProductPriceDTO{Currency: currency}
+	if err = json.NewDecoder(r.Body).Decode(&priceDTO); err != nil {
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if !strings.EqualFold(priceDTO.Currency, currency) {
+		return domain.NewBadRequest("currency of the body does not match the path", nil)
+	}
+	priceDTO.Currency = currency
+	if err = priceDTO.Validate(); err != nil {
+		return err
+	}
+
+	price, err := p.priceListService.SetPrice(ctx, int64(productID), priceDTO)
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, price, http.StatusOK)
+}
+
+// HandleDeleteProductPrice godoc
+// @Summary Delete product price
+// @Description Remove a product from the price list of a currency, so its price is converted again
+// @Tags prices
+// @Produce  json
+// @Param id path int true "product id"
+// @Param currency path string true "ISO 4217 currency"
+// @Param reason query string false "recorded in the price history"
+// @Success 204
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /product/{id}/prices/{currency} [delete]
+func (p *priceListController) HandleDeleteProductPrice(w http.ResponseWriter, r *http.Request) error {
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+	currency := strings.ToUpper(web.Param(r, "currency"))
+	reason := r.URL.Query().Get("reason")
+	if len(reason) > maxPriceChangeReasonLength {
+		return domain.NewBadRequest("reason must have at most 255 characters", nil)
+	}
+
+	if err = p.priceListService.DeletePrice(r.Context(), int64(productID), currency, reason); err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, nil, http.StatusNoContent)
+}
+
+// HandleGetExchangeRates godoc
+// @Summary Get exchange rates
+// @Description Get the exchange rates used to convert prices of products without a price in the requested currency
+// @Tags prices
+// @Produce  json
+// @Success 200 {array} dto.ExchangeRateDTO
+// @Failure 500 {object} ErrorMessage
+// @Router /exchange-rates [get]
+func (p *priceListController) HandleGetExchangeRates(w http.ResponseWriter, r *http.Request) error {
+	rates, err := p.exchangeRateService.GetRates(r.Context())
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, rates, http.StatusOK)
+}
+
+// HandleGetPriceHistory godoc
+// @Summary Get product price history
+// @Description Get the changes of the price of a product, latest first
+// @Tags prices
+// @Produce  json
+// @Param id path int true "product id"
+// @Param from query string false "changes at or after this date (YYYY-MM-DD) or time (RFC 3339)"
+// @Param to query string false "changes before this time (RFC 3339), or up to the end of this date (YYYY-MM-DD)"
+// @Success 200 {array} dto.PriceChangeDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Router /product/{id}/price-history [get]
+func (p *priceListController) HandleGetPriceHistory(w http.ResponseWriter, r *http.Request) error {
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+	filter, err := GetPriceHistoryFilter(r)
+	if err != nil {
+		return err
+	}
+
+	changes, err := p.priceListService.GetPriceHistory(r.Context(), int64(productID), filter)
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, changes, http.StatusOK)
+}
+
+// GetPriceHistoryFilter reads the from and to query parameters. A date
+// without time as to includes the whole day.
+func GetPriceHistoryFilter(r *http.Request) (domain.PriceHistoryFilter, error) {
+	filter := domain.PriceHistoryFilter{}
+	query := r.URL.Query()
+	if value := query.Get("from"); value != "" {
+		from, _, err := parseTimeParam(value)
+		if err != nil {
+			return filter, errorhandling.NewRequestError(fmt.Sprintf("from parameter value is not a date or RFC 3339 time. from = %s", value))
+		}
+		filter.From = &from
+	}
+	if value := query.Get("to"); value != "" {
+		to, dateOnly, err := parseTimeParam(value)
+		if err != nil {
+			return filter, errorhandling.NewRequestError(fmt.Sprintf("to parameter value is not a date or RFC 3339 time. to = %s", value))
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+	return filter, nil
+}
+
+func parseTimeParam(value string) (time.Time, bool, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
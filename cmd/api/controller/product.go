@@ -5,13 +5,14 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
-	"github.com/mercadolibre/fury_go-core/pkg/log"
 
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
 	errorhandling "github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/error_handling"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/service"
 	"github.com/mercadolibre/fury_go-core/pkg/web"
 )
@@ -23,27 +24,39 @@ type ProductController interface {
 	HandleFindProductByCategory(w http.ResponseWriter, r *http.Request) error
 	HandleUpdateProduct(w http.ResponseWriter, r *http.Request) error
 	HandleDeleteProduct(w http.ResponseWriter, r *http.Request) error
+	HandlePublishProduct(w http.ResponseWriter, r *http.Request) error
+	HandleArchiveProduct(w http.ResponseWriter, r *http.Request) error
+	HandleGetAdminProducts(w http.ResponseWriter, r *http.Request) error
+	HandleGetAdminProductByID(w http.ResponseWriter, r *http.Request) error
 }
 
 type productController struct {
-	productService service.ProductService
-	config         config.Environment
+	productService   service.ProductService
+	priceListService service.PriceListService
+	config           config.Environment
 }
 
+// ErrorMessage documents the payload rendered by config.ErrorResponse for
+// every failed request.
 type ErrorMessage struct {
-	Message string `json:"message"`
+	Message   string        `json:"message"`
+	Error     string        `json:"error"`
+	Status    int           `json:"status"`
+	Cause     []interface{} `json:"cause"`
+	RequestID string        `json:"request_id,omitempty"`
 }
 
-func NewProductController(productService service.ProductService, config config.Environment) ProductController {
+func NewProductController(productService service.ProductService, priceListService service.PriceListService, config config.Environment) ProductController {
 	return &productController{
-		productService: productService,
-		config:         config,
+		productService:   productService,
+		priceListService: priceListService,
+		config:           config,
 	}
 }
 
 // HandleGetProducts godoc
 // @Summary Get products
-// @Description Get products
+// @Description Get published products
 // @Tags products
 // @Accept  json
 // @Produce  json
@@ -54,6 +67,8 @@ func NewProductController(productService service.ProductService, config config.E
 // @Param offset query int false "offset"
 // @Param min query float64 false "min"
 // @Param max query float64 false "max"
+// @Param currency query string false "ISO 4217 currency to price products in; converted prices have price_derived set"
+// @Param attr.name query string false "keeps products whose attribute name has this value, compared in the type of the attribute, one parameter per attribute and at most 10, e.g. attr.screen_size=55"
 // @Success 200 {object} dto.ProductResponse
 // @Failure 400 {object} ErrorMessage
 // @Failure 400 {object} ErrorMessage
@@ -61,16 +76,105 @@ func NewProductController(productService service.ProductService, config config.E
 // @Failure 500 {object} ErrorMessage
 // @Router /products [get]
 func (p *productController) HandleGetProducts(w http.ResponseWriter, r *http.Request) error {
-	ctx := r.Context()
+	params, err := GetSearchParams(r)
+	if err != nil {
+		return err
+	}
+	published := domain.ProductPublished
+	params.Status = &published
+	params.Public = true
+
+	return p.getProducts(w, r, params)
+}
 
+// HandleGetAdminProducts godoc
+// @Summary Get products of any status
+// @Description Get products whatever their lifecycle status and publication window, or only those in status
+// @Tags admin
+// @Produce  json
+// @Param status query string false "lifecycle status" Enums(draft, published, archived)
+// @Param sort query string false "sort"
+// @Param title query string false "title"
+// @Param name query string false "name"
+// @Param limit query int false "limit"
+// @Param offset query int false "offset"
+// @Param min query float64 false "min"
+// @Param max query float64 false "max"
+// @Param currency query string false "ISO 4217 currency to price products in; converted prices have price_derived set"
+// @Param attr.name query string false "keeps products whose attribute name has this value, compared in the type of the attribute, one parameter per attribute and at most 10, e.g. attr.screen_size=55"
+// @Success 200 {object} dto.ProductResponse
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:admin"]
+// @Router /admin/products [get]
+func (p *productController) HandleGetAdminProducts(w http.ResponseWriter, r *http.Request) error {
 	params, err := GetSearchParams(r)
 	if err != nil {
 		return err
 	}
+	if value := r.URL.Query().Get("status"); value != "" {
+		switch value {
+		case domain.ProductDraft, domain.ProductPublished, domain.ProductArchived:
+			params.Status = &value
+		default:
+			return errorhandling.NewRequestError(fmt.Sprintf("status parameter value must be one of draft published archived. status = %s", value))
+		}
+	}
+
+	return p.getProducts(w, r, params)
+}
+
+// HandleGetAdminProductByID godoc
+// @Summary Get product of any status by id
+// @Description Get a product whatever its lifecycle status and publication window, so drafts can be previewed
+// @Tags admin
+// @Produce  json
+// @Param id path int true "product id"
+// @Success 200 {object} dto.ProductDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:admin"]
+// @Router /admin/products/{id} [get]
+func (p *productController) HandleGetAdminProductByID(w http.ResponseWriter, r *http.Request) error {
+	id, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+
+	product, err := p.productService.FindAnyById(r.Context(), int64(id))
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, product, http.StatusOK)
+}
+
+func (p *productController) getProducts(w http.ResponseWriter, r *http.Request, params dto.SearchParams) error {
+	ctx := r.Context()
+
+	currency, err := GetCurrencyParam(r)
+	if err != nil {
+		return err
+	}
 
 	products, err := p.productService.GetProducts(ctx, params)
 	if err != nil {
-		return domain.ConvertToWebErr(err)
+		return err
+	}
+	if currency != "" {
+		products.Data, err = p.priceListService.InCurrency(ctx, products.Data, currency)
+		if err != nil {
+			return err
+		}
 	}
 
 	return web.EncodeJSON(w, products, http.StatusOK)
@@ -78,11 +182,12 @@ func (p *productController) HandleGetProducts(w http.ResponseWriter, r *http.Req
 
 // HandleGetProductByID godoc
 // @Summary Get product by id
-// @Description Get product by id
+// @Description Get a published product inside its publication window by id
 // @Tags products
 // @Accept  json
 // @Produce  json
 // @Param id path int true "product id"
+// @Param currency query string false "ISO 4217 currency to price the product in; a converted price has price_derived set"
 // @Success 200 {object} dto.ProductDTO
 // @Failure 400 {object} ErrorMessage
 // @Failure 400 {object} ErrorMessage
@@ -92,13 +197,24 @@ func (p *productController) HandleGetProducts(w http.ResponseWriter, r *http.Req
 func (p *productController) HandleGetProductByID(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	id, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+	currency, err := GetCurrencyParam(r)
 	if err != nil {
 		return err
 	}
 
 	product, err := p.productService.FindById(ctx, int64(id))
 	if err != nil {
-		return domain.ConvertToWebErr(err)
+		return err
+	}
+	if currency != "" {
+		priced, err := p.priceListService.InCurrency(ctx, []dto.ProductDTO{product}, currency)
+		if err != nil {
+			return err
+		}
+		product = priced[0]
 	}
 
 	return web.EncodeJSON(w, product, http.StatusOK)
@@ -111,22 +227,34 @@ func (p *productController) HandleGetProductByID(w http.ResponseWriter, r *http.
 // @Accept  json
 // @Produce  json
 // @Param product body string true "product"
+// @Param Idempotency-Key header string false "replays the response of a previous request with the same key"
 // @Success 201 {object} dto.ProductDTO
 // @Failure 400 {object} ErrorMessage
 // @Failure 404 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 409 {object} ErrorMessage
+// @Failure 413 {object} ErrorMessage
+// @Failure 422 {object} ErrorMessage
 // @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
 // @Router /product [post]
 func (p *productController) HandleCreateProduct(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 
 	var productDTO dto.ProductDTO
 	if err := json.NewDecoder(r.Body).Decode(&productDTO); err != nil {
-		return errorhandling.NewBadRequestAPIError("invalid json body")
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if err := productDTO.Validate(); err != nil {
+		return err
 	}
 
 	product, err := p.productService.CreateProduct(ctx, productDTO)
 	if err != nil {
-		return domain.ConvertToWebErr(err)
+		return err
 	}
 	return web.EncodeJSON(w, product, http.StatusCreated)
 }
@@ -151,7 +279,7 @@ func (p *productController) HandleFindProductByCategory(w http.ResponseWriter, r
 
 	c, err := p.productService.GetProductsByCategory(ctx, category)
 	if err != nil {
-		return domain.ConvertToWebErr(err)
+		return err
 	}
 
 	return web.EncodeJSON(w, c, http.StatusOK)
@@ -170,7 +298,12 @@ func (p *productController) HandleFindProductByCategory(w http.ResponseWriter, r
 // @Failure 400 {object} ErrorMessage
 // @Failure 400 {object} ErrorMessage
 // @Failure 404 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
 // @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
 // @Router /product/{id} [put]
 func (p *productController) HandleUpdateProduct(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
@@ -178,20 +311,19 @@ func (p *productController) HandleUpdateProduct(w http.ResponseWriter, r *http.R
 
 	productID, err := web.ParamInt(r, "id")
 	if err != nil {
-		apiErr := domain.NewBadRequest("invalid id", nil)
-		log.Error(ctx, apiErr.Error())
-		return domain.ConvertToWebErr(apiErr)
+		return domain.NewBadRequest("invalid product id", err)
 	}
 
 	if err = json.NewDecoder(r.Body).Decode(&productDTO); err != nil {
-		apiErr := domain.NewInternalError("error unmarshalling body", err)
-		log.Error(ctx, apiErr.Error())
-		return domain.ConvertToWebErr(apiErr)
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if err = productDTO.Validate(); err != nil {
+		return err
 	}
 
 	product, err := p.productService.UpdateProduct(ctx, productDTO, int64(productID))
 	if err != nil {
-		return domain.ConvertToWebErr(err)
+		return err
 	}
 
 	return web.EncodeJSON(w, product, http.StatusOK)
@@ -208,24 +340,90 @@ func (p *productController) HandleUpdateProduct(w http.ResponseWriter, r *http.R
 // @Failure 400 {object} ErrorMessage
 // @Failure 400 {object} ErrorMessage
 // @Failure 404 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
 // @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
 // @Router /product/{id} [delete]
 func (p *productController) HandleDeleteProduct(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	productID, err := web.ParamInt(r, "id")
 	if err != nil {
-		apiErr := domain.NewBadRequest("invalid id", nil)
-		log.Error(ctx, apiErr.Error())
-		return domain.ConvertToWebErr(apiErr)
+		return domain.NewBadRequest("invalid product id", err)
 	}
 
 	if err = p.productService.DeleteProduct(ctx, int64(productID)); err != nil {
-		return domain.ConvertToWebErr(err)
+		return err
 	}
 
 	return web.EncodeJSON(w, nil, http.StatusNoContent)
 }
 
+// HandlePublishProduct godoc
+// @Summary Publish product
+// @Description Publish a draft or archived product, listing it to the public
+// @Tags products
+// @Produce  json
+// @Param id path int true "product id"
+// @Success 200 {object} dto.ProductDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 409 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /product/{id}/publish [post]
+func (p *productController) HandlePublishProduct(w http.ResponseWriter, r *http.Request) error {
+	return p.updateStatus(w, r, domain.ProductPublished)
+}
+
+// HandleArchiveProduct godoc
+// @Summary Archive product
+// @Description Archive a published product, removing it from public listings
+// @Tags products
+// @Produce  json
+// @Param id path int true "product id"
+// @Success 200 {object} dto.ProductDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 409 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /product/{id}/archive [post]
+func (p *productController) HandleArchiveProduct(w http.ResponseWriter, r *http.Request) error {
+	return p.updateStatus(w, r, domain.ProductArchived)
+}
+
+func (p *productController) updateStatus(w http.ResponseWriter, r *http.Request, status string) error {
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+
+	product, err := p.productService.UpdateStatus(r.Context(), int64(productID), status)
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, product, http.StatusOK)
+}
+
+// attributeParamPrefix prefixes the query parameters that filter products by
+// attribute value, e.g. attr.screen_size=55.
+const attributeParamPrefix = "attr."
+
+// maxAttributeParams caps the attribute filters of a search, each of which
+// adds a subquery.
+const maxAttributeParams = 10
+
 func GetSearchParams(r *http.Request) (dto.SearchParams, error) {
 	params := dto.SearchParams{}
 	query := r.URL.Query()
@@ -266,18 +464,45 @@ func GetSearchParams(r *http.Request) (dto.SearchParams, error) {
 	}
 
 	if value := query.Get("min"); value != "" {
-		min, err := strconv.ParseFloat(value, 64)
+		min, err := money.ParseDecimal(value)
 		if err != nil {
-			return params, errorhandling.NewRequestError(fmt.Sprintf("min parameter value is not a float. min = %s", value))
+			return params, errorhandling.NewRequestError(fmt.Sprintf("min parameter value is not a decimal number. min = %s", value))
 		}
 		params.Min = &min
 	}
 	if value := query.Get("max"); value != "" {
-		max, err := strconv.ParseFloat(value, 64)
+		max, err := money.ParseDecimal(value)
 		if err != nil {
-			return params, errorhandling.NewRequestError(fmt.Sprintf("max parameter value is not a float. max = %s", value))
+			return params, errorhandling.NewRequestError(fmt.Sprintf("max parameter value is not a decimal number. max = %s", value))
 		}
 		params.Max = &max
 	}
+	for key, values := range query {
+		name := strings.TrimPrefix(key, attributeParamPrefix)
+		if name == key || name == "" || values[0] == "" {
+			continue
+		}
+		if params.Attributes == nil {
+			params.Attributes = map[string]string{}
+		}
+		if len(params.Attributes) == maxAttributeParams {
+			return params, errorhandling.NewRequestError(fmt.Sprintf("at most %d attribute parameters are allowed", maxAttributeParams))
+		}
+		params.Attributes[name] = values[0]
+	}
 	return params, nil
 }
+
+// GetCurrencyParam returns the ISO 4217 code of the currency query parameter,
+// or an empty string when it is missing.
+func GetCurrencyParam(r *http.Request) (string, error) {
+	value := r.URL.Query().Get("currency")
+	if value == "" {
+		return "", nil
+	}
+	currency, ok := money.LookupCurrency(value)
+	if !ok {
+		return "", errorhandling.NewRequestError(fmt.Sprintf("currency parameter value is not a supported ISO 4217 code. currency = %s", value))
+	}
+	return currency.Code, nil
+}
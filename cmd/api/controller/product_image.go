@@ -0,0 +1,209 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/service"
+	"github.com/mercadolibre/fury_go-core/pkg/web"
+)
+
+type ProductImageController interface {
+	HandleGetProductImages(w http.ResponseWriter, r *http.Request) error
+	HandleAddProductImage(w http.ResponseWriter, r *http.Request) error
+	HandleUpdateProductImage(w http.ResponseWriter, r *http.Request) error
+	HandleReorderProductImages(w http.ResponseWriter, r *http.Request) error
+	HandleDeleteProductImage(w http.ResponseWriter, r *http.Request) error
+}
+
+type productImageController struct {
+	productImageService service.ProductImageService
+}
+
+func NewProductImageController(productImageService service.ProductImageService) ProductImageController {
+	return &productImageController{
+		productImageService: productImageService,
+	}
+}
+
+// HandleGetProductImages godoc
+// @Summary Get product images
+// @Description Get the images of a product by ascending position
+// @Tags products
+// @Produce  json
+// @Param id path int true "product id"
+// @Success 200 {array} dto.ProductImageDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Router /product/{id}/images [get]
+func (p *productImageController) HandleGetProductImages(w http.ResponseWriter, r *http.Request) error {
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+
+	images, err := p.productImageService.GetImages(r.Context(), int64(productID))
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, images, http.StatusOK)
+}
+
+// HandleAddProductImage godoc
+// @Summary Add product image
+// @Description Add an image after the existing ones. The first image of a product, or one sent as primary, becomes the image of the product
+// @Tags products
+// @Accept  json
+// @Produce  json
+// @Param id path int true "product id"
+// @Param image body dto.ProductImageDTO true "product image"
+// @Param Idempotency-Key header string false "replays the response of a previous request with the same key"
+// @Success 201 {object} dto.ProductImageDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 409 {object} ErrorMessage
+// @Failure 413 {object} ErrorMessage
+// @Failure 422 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /product/{id}/images [post]
+func (p *productImageController) HandleAddProductImage(w http.ResponseWriter, r *http.Request) error {
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+	var imageDTO dto.ProductImageDTO
+	if err = json.NewDecoder(r.Body).Decode(&imageDTO); err != nil {
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if err = imageDTO.Validate(); err != nil {
+		return err
+	}
+
+	image, err := p.productImageService.AddImage(r.Context(), int64(productID), imageDTO)
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, image, http.StatusCreated)
+}
+
+// HandleUpdateProductImage godoc
+// @Summary Update product image
+// @Description Update the URL, alt text, dimensions and primary flag of an image. Unsetting the primary flag makes the first image the primary one
+// @Tags products
+// @Accept  json
+// @Produce  json
+// @Param id path int true "product id"
+// @Param imageId path int true "image id"
+// @Param image body dto.ProductImageDTO true "product image"
+// @Success 200 {object} dto.ProductImageDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /product/{id}/images/{imageId} [put]
+func (p *productImageController) HandleUpdateProductImage(w http.ResponseWriter, r *http.Request) error {
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+	id, err := web.ParamInt(r, "imageId")
+	if err != nil {
+		return domain.NewBadRequest("invalid image id", err)
+	}
+	var imageDTO dto.ProductImageDTO
+	if err = json.NewDecoder(r.Body).Decode(&imageDTO); err != nil {
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if err = imageDTO.Validate(); err != nil {
+		return err
+	}
+
+	image, err := p.productImageService.UpdateImage(r.Context(), int64(productID), int64(id), imageDTO)
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, image, http.StatusOK)
+}
+
+// HandleReorderProductImages godoc
+// @Summary Reorder product images
+// @Description Set the order of the images of a product. image_ids must list every image of the product once
+// @Tags products
+// @Accept  json
+// @Produce  json
+// @Param id path int true "product id"
+// @Param order body dto.ProductImageOrderDTO true "image ids in their new order"
+// @Success 200 {array} dto.ProductImageDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /product/{id}/images/order [put]
+func (p *productImageController) HandleReorderProductImages(w http.ResponseWriter, r *http.Request) error {
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+	var orderDTO dto.ProductImageOrderDTO
+	if err = json.NewDecoder(r.Body).Decode(&orderDTO); err != nil {
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if err = orderDTO.Validate(); err != nil {
+		return err
+	}
+
+	images, err := p.productImageService.ReorderImages(r.Context(), int64(productID), orderDTO.ImageIDs)
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, images, http.StatusOK)
+}
+
+// HandleDeleteProductImage godoc
+// @Summary Delete product image
+// @Description Delete an image. When it was the primary one, the first remaining image takes its place
+// @Tags products
+// @Produce  json
+// @Param id path int true "product id"
+// @Param imageId path int true "image id"
+// @Success 204
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /product/{id}/images/{imageId} [delete]
+func (p *productImageController) HandleDeleteProductImage(w http.ResponseWriter, r *http.Request) error {
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+	id, err := web.ParamInt(r, "imageId")
+	if err != nil {
+		return domain.NewBadRequest("invalid image id", err)
+	}
+
+	if err = p.productImageService.DeleteImage(r.Context(), int64(productID), int64(id)); err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, nil, http.StatusNoContent)
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/service"
+	"github.com/mercadolibre/fury_go-core/pkg/web"
+)
+
+type PromotionController interface {
+	HandleGetPromotions(w http.ResponseWriter, r *http.Request) error
+	HandleGetPromotion(w http.ResponseWriter, r *http.Request) error
+	HandleCreatePromotion(w http.ResponseWriter, r *http.Request) error
+	HandleUpdatePromotion(w http.ResponseWriter, r *http.Request) error
+	HandleDeletePromotion(w http.ResponseWriter, r *http.Request) error
+}
+
+type promotionController struct {
+	promotionService service.PromotionService
+}
+
+func NewPromotionController(promotionService service.PromotionService) PromotionController {
+	return &promotionController{
+		promotionService: promotionService,
+	}
+}
+
+// HandleGetPromotions godoc
+// @Summary Get promotions
+// @Description Get every promotion, including scheduled and finished ones, by descending priority
+// @Tags promotions
+// @Produce  json
+// @Success 200 {array} dto.PromotionDTO
+// @Failure 500 {object} ErrorMessage
+// @Router /promotions [get]
+func (p *promotionController) HandleGetPromotions(w http.ResponseWriter, r *http.Request) error {
+	promotions, err := p.promotionService.GetPromotions(r.Context())
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, promotions, http.StatusOK)
+}
+
+// HandleGetPromotion godoc
+// @Summary Get promotion by id
+// @Description Get promotion by id
+// @Tags promotions
+// @Produce  json
+// @Param id path int true "promotion id"
+// @Success 200 {object} dto.PromotionDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Router /promotion/{id} [get]
+func (p *promotionController) HandleGetPromotion(w http.ResponseWriter, r *http.Request) error {
+	id, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid promotion id", err)
+	}
+
+	promotion, err := p.promotionService.FindByID(r.Context(), int64(id))
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, promotion, http.StatusOK)
+}
+
+// HandleCreatePromotion godoc
+// @Summary Create promotion
+// @Description Create a percentage or fixed discount for products, categories or a price range
+// @Tags promotions
+// @Accept  json
+// @Produce  json
+// @Param promotion body dto.PromotionDTO true "promotion"
+// @Param Idempotency-Key header string false "replays the response of a previous request with the same key"
+// @Success 201 {object} dto.PromotionDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 409 {object} ErrorMessage
+// @Failure 413 {object} ErrorMessage
+// @Failure 422 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /promotion [post]
+func (p *promotionController) HandleCreatePromotion(w http.ResponseWriter, r *http.Request) error {
+	var promotionDTO dto.PromotionDTO
+	if err := json.NewDecoder(r.Body).Decode(&promotionDTO); err != nil {
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if err := promotionDTO.Validate(); err != nil {
+		return err
+	}
+
+	promotion, err := p.promotionService.CreatePromotion(r.Context(), promotionDTO)
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, promotion, http.StatusCreated)
+}
+
+// HandleUpdatePromotion godoc
+// @Summary Update promotion
+// @Description Replace a promotion and its targets
+// @Tags promotions
+// @Accept  json
+// @Produce  json
+// @Param id path int true "promotion id"
+// @Param promotion body dto.PromotionDTO true "promotion"
+// @Success 200 {object} dto.PromotionDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /promotion/{id} [put]
+func (p *promotionController) HandleUpdatePromotion(w http.ResponseWriter, r *http.Request) error {
+	id, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid promotion id", err)
+	}
+
+	var promotionDTO dto.PromotionDTO
+	if err = json.NewDecoder(r.Body).Decode(&promotionDTO); err != nil {
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if err = promotionDTO.Validate(); err != nil {
+		return err
+	}
+
+	promotion, err := p.promotionService.UpdatePromotion(r.Context(), promotionDTO, int64(id))
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, promotion, http.StatusOK)
+}
+
+// HandleDeletePromotion godoc
+// @Summary Delete promotion
+// @Description Delete promotion
+// @Tags promotions
+// @Produce  json
+// @Param id path int true "promotion id"
+// @Success 204
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /promotion/{id} [delete]
+func (p *promotionController) HandleDeletePromotion(w http.ResponseWriter, r *http.Request) error {
+	id, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid promotion id", err)
+	}
+
+	if err = p.promotionService.DeletePromotion(r.Context(), int64(id)); err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, nil, http.StatusNoContent)
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	errorhandling "github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/error_handling"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/service"
+	"github.com/mercadolibre/fury_go-core/pkg/web"
+)
+
+type ScheduledPriceController interface {
+	HandleGetScheduledPrices(w http.ResponseWriter, r *http.Request) error
+	HandleSchedulePrice(w http.ResponseWriter, r *http.Request) error
+	HandleCancelScheduledPrice(w http.ResponseWriter, r *http.Request) error
+}
+
+type scheduledPriceController struct {
+	scheduledPriceService service.ScheduledPriceService
+}
+
+func NewScheduledPriceController(scheduledPriceService service.ScheduledPriceService) ScheduledPriceController {
+	return &scheduledPriceController{
+		scheduledPriceService: scheduledPriceService,
+	}
+}
+
+// HandleGetScheduledPrices godoc
+// @Summary Get scheduled price changes
+// @Description Get the scheduled price changes of a product, pending, applied, failed and cancelled, in the order they take effect
+// @Tags prices
+// @Produce  json
+// @Param id path int true "product id"
+// @Param status query string false "only changes in this status" Enums(pending, applied, failed, cancelled)
+// @Success 200 {array} dto.ScheduledPriceChangeDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /product/{id}/scheduled-prices [get]
+func (s *scheduledPriceController) HandleGetScheduledPrices(w http.ResponseWriter, r *http.Request) error {
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", domain.ScheduledPricePending, domain.ScheduledPriceApplied, domain.ScheduledPriceFailed, domain.ScheduledPriceCancelled:
+	default:
+		return errorhandling.NewRequestError(fmt.Sprintf("status parameter value must be one of pending applied failed cancelled. status = %s", status))
+	}
+
+	changes, err := s.scheduledPriceService.GetScheduled(r.Context(), int64(productID), status)
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, changes, http.StatusOK)
+}
+
+// HandleSchedulePrice godoc
+// @Summary Schedule price change
+// @Description Schedule a new price for a product, applied in the background once effective_at is reached. Without currency the product keeps its own
+// @Tags prices
+// @Accept  json
+// @Produce  json
+// @Param id path int true "product id"
+// @Param change body dto.ScheduledPriceChangeDTO true "scheduled price change"
+// @Param Idempotency-Key header string false "replays the response of a previous request with the same key"
+// @Success 201 {object} dto.ScheduledPriceChangeDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 409 {object} ErrorMessage
+// @Failure 413 {object} ErrorMessage
+// @Failure 422 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /product/{id}/scheduled-prices [post]
+func (s *scheduledPriceController) HandleSchedulePrice(w http.ResponseWriter, r *http.Request) error {
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+	var changeDTO dto.ScheduledPriceChangeDTO
+	if err = json.NewDecoder(r.Body).Decode(&changeDTO); err != nil {
+		return domain.NewBadRequest("invalid json body", err)
+	}
+	if err = changeDTO.Validate(); err != nil {
+		return err
+	}
+
+	change, err := s.scheduledPriceService.Schedule(r.Context(), int64(productID), changeDTO)
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, change, http.StatusCreated)
+}
+
+// HandleCancelScheduledPrice godoc
+// @Summary Cancel scheduled price change
+// @Description Cancel a pending scheduled price change. Applied, failed and cancelled changes cannot be cancelled
+// @Tags prices
+// @Produce  json
+// @Param id path int true "product id"
+// @Param changeId path int true "scheduled price change id"
+// @Success 200 {object} dto.ScheduledPriceChangeDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 404 {object} ErrorMessage
+// @Failure 409 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /product/{id}/scheduled-prices/{changeId}/cancel [post]
+func (s *scheduledPriceController) HandleCancelScheduledPrice(w http.ResponseWriter, r *http.Request) error {
+	productID, err := web.ParamInt(r, "id")
+	if err != nil {
+		return domain.NewBadRequest("invalid product id", err)
+	}
+	id, err := web.ParamInt(r, "changeId")
+	if err != nil {
+		return domain.NewBadRequest("invalid scheduled price change id", err)
+	}
+
+	change, err := s.scheduledPriceService.Cancel(r.Context(), int64(productID), int64(id))
+	if err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, change, http.StatusOK)
+}
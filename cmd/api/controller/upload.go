@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/service"
+	"github.com/mercadolibre/fury_go-core/pkg/web"
+)
+
+// multipartOverhead is the room left in upload requests for the multipart
+// boundaries and headers around the file.
+const multipartOverhead = 64 << 10
+
+type UploadController interface {
+	HandleUploadImage(w http.ResponseWriter, r *http.Request) error
+	HandleGetUpload(w http.ResponseWriter, r *http.Request) error
+}
+
+type uploadController struct {
+	uploadService service.UploadService
+	config        config.UploadsConfig
+}
+
+func NewUploadController(uploadService service.UploadService, config config.UploadsConfig) UploadController {
+	return &uploadController{
+		uploadService: uploadService,
+		config:        config,
+	}
+}
+
+// HandleUploadImage godoc
+// @Summary Upload image
+// @Description Upload a JPEG or PNG image in the file field of a multipart form. The type is detected from the content, and the size and dimensions are checked against the configured limits. The returned url, width and height can be sent to POST /product/{id}/images
+// @Tags products
+// @Accept  mpfd
+// @Produce  json
+// @Param file formData file true "JPEG or PNG image"
+// @Success 201 {object} dto.UploadDTO
+// @Failure 400 {object} ErrorMessage
+// @Failure 401 {object} ErrorMessage
+// @Failure 403 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @x-required-scopes ["catalog:write"]
+// @Router /uploads/images [post]
+func (u *uploadController) HandleUploadImage(w http.ResponseWriter, r *http.Request) error {
+	r.Body = http.MaxBytesReader(w, r.Body, u.config.MaxSize()+multipartOverhead)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			return domain.NewBadRequest(fmt.Sprintf("file must be at most %d KB", u.config.MaxSize()>>10), err)
+		case errors.Is(err, http.ErrMissingFile):
+			return domain.NewBadRequest("file is required", err)
+		default:
+			return domain.NewBadRequest("invalid multipart body", err)
+		}
+	}
+	defer file.Close()
+
+	var upload dto.UploadDTO
+	if upload, err = u.uploadService.UploadImage(r.Context(), file); err != nil {
+		return err
+	}
+	return web.EncodeJSON(w, upload, http.StatusCreated)
+}
+
+// HandleGetUpload godoc
+// @Summary Get uploaded file
+// @Description Serve an uploaded file. Keys are random and never reused, so files can be cached forever
+// @Tags products
+// @Produce  jpeg,png
+// @Param key path string true "file key"
+// @Success 200 {file} file
+// @Failure 404 {object} ErrorMessage
+// @Failure 500 {object} ErrorMessage
+// @Router /uploads/{key} [get]
+func (u *uploadController) HandleGetUpload(w http.ResponseWriter, r *http.Request) error {
+	key := web.Param(r, "key")
+	file, err := u.uploadService.Open(r.Context(), key)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", mime.TypeByExtension(filepath.Ext(key)))
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.WriteHeader(http.StatusOK)
+	// The status is already sent, so a failed copy can only cut the body.
+	_, _ = io.Copy(w, file)
+	return nil
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of the value of an attribute.
+const (
+	AttributeString  = "string"
+	AttributeNumber  = "number"
+	AttributeBoolean = "boolean"
+)
+
+// MaxAttributeValueLength is the length limit of attribute values.
+const MaxAttributeValueLength = 255
+
+// AttributeDefinition is an attribute the products of a category may have.
+// Required attributes must be set on every product of the category and, when
+// AllowedValues is not empty, values must be one of them.
+type AttributeDefinition struct {
+	ID            int64
+	CategoryID    int64
+	Name          string
+	Type          string
+	Required      bool
+	AllowedValues []string
+	CreatedAt     time.Time
+}
+
+// Normalize returns value in the canonical form of the type of d, so that
+// equal values are stored and filtered the same way: numbers are written
+// without trailing zeros and booleans as true or false.
+func (d AttributeDefinition) Normalize(value string) (string, error) {
+	switch d.Type {
+	case AttributeNumber:
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return "", NewBadRequest(fmt.Sprintf("attribute %s must be a number", d.Name), err)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case AttributeBoolean:
+		boolean, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return "", NewBadRequest(fmt.Sprintf("attribute %s must be true or false", d.Name), err)
+		}
+		return strconv.FormatBool(boolean), nil
+	}
+	return value, nil
+}
+
+// NormalizeAllowedValues normalizes the allowed values of d and drops the
+// repeated ones. Boolean attributes cannot restrict their values.
+func (d *AttributeDefinition) NormalizeAllowedValues() error {
+	if len(d.AllowedValues) == 0 {
+		return nil
+	}
+	if d.Type == AttributeBoolean {
+		return NewBadRequest(fmt.Sprintf("attribute %s is boolean and cannot have allowed values", d.Name), nil)
+	}
+	allowed := make([]string, 0, len(d.AllowedValues))
+	seen := make(map[string]bool, len(d.AllowedValues))
+	for _, value := range d.AllowedValues {
+		normalized, err := d.Normalize(value)
+		if err != nil {
+			return err
+		}
+		if !seen[normalized] {
+			seen[normalized] = true
+			allowed = append(allowed, normalized)
+		}
+	}
+	d.AllowedValues = allowed
+	return nil
+}
+
+func (d AttributeDefinition) allows(value string) bool {
+	if len(d.AllowedValues) == 0 {
+		return true
+	}
+	for _, allowed := range d.AllowedValues {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateAttributes checks the attribute values of a product against the
+// definitions of its category and returns them normalized. Attributes are
+// checked by name, so the error reported for invalid values does not depend
+// on map order. Empty values leave the attribute unset.
+func ValidateAttributes(category Category, definitions []AttributeDefinition, values map[string]string) (map[string]string, error) {
+	byName := make(map[string]AttributeDefinition, len(definitions))
+	for _, definition := range definitions {
+		byName[definition.Name] = definition
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	normalized := make(map[string]string, len(values))
+	for _, name := range names {
+		definition, ok := byName[name]
+		if !ok {
+			return nil, NewBadRequest(fmt.Sprintf("attribute %s is not defined for category %s", name, category.Name), nil)
+		}
+		if strings.TrimSpace(values[name]) == "" {
+			continue
+		}
+		if len(values[name]) > MaxAttributeValueLength {
+			return nil, NewBadRequest(fmt.Sprintf("attribute %s must have at most %d characters", name, MaxAttributeValueLength), nil)
+		}
+		value, err := definition.Normalize(values[name])
+		if err != nil {
+			return nil, err
+		}
+		if !definition.allows(value) {
+			return nil, NewBadRequest(fmt.Sprintf("attribute %s must be one of [%s]", name, strings.Join(definition.AllowedValues, " ")), nil)
+		}
+		normalized[name] = value
+	}
+
+	for _, definition := range definitions {
+		if _, ok := normalized[definition.Name]; definition.Required && !ok {
+			return nil, NewBadRequest(fmt.Sprintf("attribute %s is required", definition.Name), nil)
+		}
+	}
+	return normalized, nil
+}
+
+// NormalizeAttributeFilters normalizes the values products are filtered by,
+// by attribute name, for each definition of that name in definitions, and
+// returns them by name and attribute ID. A value only has to be valid for one
+// of the definitions of its name, since categories may define a name with
+// different types.
+func NormalizeAttributeFilters(definitions []AttributeDefinition, values map[string]string) (map[string]map[int64]string, error) {
+	byName := make(map[string][]AttributeDefinition, len(values))
+	for _, definition := range definitions {
+		byName[definition.Name] = append(byName[definition.Name], definition)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	filters := make(map[string]map[int64]string, len(values))
+	for _, name := range names {
+		if len(byName[name]) == 0 {
+			return nil, NewBadRequest(fmt.Sprintf("attribute %s is not defined", name), nil)
+		}
+		var invalid error
+		normalized := make(map[int64]string, len(byName[name]))
+		for _, definition := range byName[name] {
+			value, err := definition.Normalize(values[name])
+			if err != nil {
+				invalid = err
+				continue
+			}
+			normalized[definition.ID] = value
+		}
+		if len(normalized) == 0 {
+			return nil, invalid
+		}
+		filters[name] = normalized
+	}
+	return filters, nil
+}
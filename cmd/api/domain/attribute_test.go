@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var televisions = Category{ID: 1, Name: "televisions"}
+
+var televisionAttributes = []AttributeDefinition{
+	{Name: "color", Type: AttributeString, AllowedValues: []string{"black", "silver"}},
+	{Name: "screen_size", Type: AttributeNumber, Required: true},
+	{Name: "smart", Type: AttributeBoolean},
+}
+
+func TestValidateAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   map[string]string
+		expected map[string]string
+		err      string
+	}{
+		{
+			name:     "normalizes values",
+			values:   map[string]string{"color": "black", "screen_size": "55.50", "smart": "1"},
+			expected: map[string]string{"color": "black", "screen_size": "55.5", "smart": "true"},
+		},
+		{
+			name:     "leaves empty values unset",
+			values:   map[string]string{"color": " ", "screen_size": "55"},
+			expected: map[string]string{"screen_size": "55"},
+		},
+		{
+			name:   "missing required attribute",
+			values: map[string]string{"color": "black"},
+			err:    "attribute screen_size is required",
+		},
+		{
+			name:   "undefined attribute",
+			values: map[string]string{"screen_size": "55", "weight": "12"},
+			err:    "attribute weight is not defined for category televisions",
+		},
+		{
+			name:   "not a number",
+			values: map[string]string{"screen_size": "large"},
+			err:    "attribute screen_size must be a number",
+		},
+		{
+			name:   "not a boolean",
+			values: map[string]string{"screen_size": "55", "smart": "maybe"},
+			err:    "attribute smart must be true or false",
+		},
+		{
+			name:   "value not allowed",
+			values: map[string]string{"color": "red", "screen_size": "55"},
+			err:    "attribute color must be one of [black silver]",
+		},
+		{
+			name:   "value too long",
+			values: map[string]string{"color": strings.Repeat("a", 256), "screen_size": "55"},
+			err:    "attribute color must have at most 255 characters",
+		},
+		{
+			name:   "first attribute by name is reported",
+			values: map[string]string{"smart": "maybe", "color": "red", "screen_size": "55"},
+			err:    "attribute color must be one of [black silver]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := ValidateAttributes(televisions, televisionAttributes, tt.values)
+			if tt.err != "" {
+				assert.IsType(t, &BadRequest{}, err)
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err, "Error should not be returned")
+			assert.Equal(t, tt.expected, values)
+		})
+	}
+}
+
+func TestAttributeDefinition_NormalizeAllowedValues(t *testing.T) {
+	definition := AttributeDefinition{Name: "screen_size", Type: AttributeNumber, AllowedValues: []string{"55.0", "65", "55"}}
+
+	err := definition.NormalizeAllowedValues()
+
+	assert.Nil(t, err, "Error should not be returned")
+	assert.Equal(t, []string{"55", "65"}, definition.AllowedValues)
+}
+
+func TestAttributeDefinition_NormalizeAllowedValues_WithError(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition AttributeDefinition
+		err        string
+	}{
+		{
+			name:       "boolean",
+			definition: AttributeDefinition{Name: "smart", Type: AttributeBoolean, AllowedValues: []string{"true"}},
+			err:        "attribute smart is boolean and cannot have allowed values",
+		},
+		{
+			name:       "not a number",
+			definition: AttributeDefinition{Name: "screen_size", Type: AttributeNumber, AllowedValues: []string{"55", "large"}},
+			err:        "attribute screen_size must be a number",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.definition.NormalizeAllowedValues()
+			assert.IsType(t, &BadRequest{}, err)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestNormalizeAttributeFilters(t *testing.T) {
+	definitions := []AttributeDefinition{
+		{ID: 1, Name: "screen_size", Type: AttributeNumber},
+		{ID: 2, Name: "smart", Type: AttributeBoolean},
+		{ID: 3, Name: "size", Type: AttributeNumber},
+		{ID: 4, Name: "size", Type: AttributeString},
+	}
+
+	tests := []struct {
+		name     string
+		values   map[string]string
+		expected map[string]map[int64]string
+		err      string
+	}{
+		{
+			name:     "normalizes values",
+			values:   map[string]string{"screen_size": "55.0", "smart": "True"},
+			expected: map[string]map[int64]string{"screen_size": {1: "55"}, "smart": {2: "true"}},
+		},
+		{
+			name:     "normalizes for each definition",
+			values:   map[string]string{"size": "42.0"},
+			expected: map[string]map[int64]string{"size": {3: "42", 4: "42.0"}},
+		},
+		{
+			name:     "skips definitions the value is not valid for",
+			values:   map[string]string{"size": "large"},
+			expected: map[string]map[int64]string{"size": {4: "large"}},
+		},
+		{
+			name:   "undefined attribute",
+			values: map[string]string{"weight": "12"},
+			err:    "attribute weight is not defined",
+		},
+		{
+			name:   "not valid for any definition",
+			values: map[string]string{"smart": "maybe"},
+			err:    "attribute smart must be true or false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := NormalizeAttributeFilters(definitions, tt.values)
+			if tt.err != "" {
+				assert.IsType(t, &BadRequest{}, err)
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err, "Error should not be returned")
+			assert.Equal(t, tt.expected, filters)
+		})
+	}
+}
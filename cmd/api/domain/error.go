@@ -1,12 +1,6 @@
 package domain
 
-import (
-	"errors"
-	"fmt"
-	"net/http"
-
-	"github.com/mercadolibre/fury_go-core/pkg/web"
-)
+import "errors"
 
 type AppError struct {
 	Message string
@@ -28,6 +22,10 @@ func (e *AppError) Error() string {
 	return e.Message
 }
 
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
 type NotFoundError struct {
 	*AppError // AppError
 }
@@ -40,6 +38,10 @@ type BadRequest struct {
 	*AppError // AppError
 }
 
+type ConflictError struct {
+	*AppError // AppError
+}
+
 func NewNotFoundError(message string, originalError error) *NotFoundError {
 	return &NotFoundError{
 		AppError: NewAppError(message, originalError),
@@ -58,26 +60,8 @@ func NewBadRequest(message string, originalError error) *BadRequest {
 	}
 }
 
-func ConvertToWebErr(err error) error {
-	switch e := err.(type) {
-	case *AppError:
-		return web.NewError(http.StatusInternalServerError, e.Error())
-	case *NotFoundError:
-		return web.NewError(http.StatusNotFound, e.Error())
-	case *InternalError:
-		return web.NewError(http.StatusInternalServerError, e.Error())
-	case *BadRequest:
-		return web.NewError(http.StatusBadRequest, e.Error())
-	default:
-		return web.NewError(http.StatusInternalServerError, err.Error())
-
-	}
-}
-
-// Funções para obter a mensagem de erro
-func (e *AppError) getMessage() string {
-	if e.Err != nil {
-		return fmt.Sprintf("%s original_cause: %s", e.Message, e.Err.Error())
+func NewConflictError(message string, originalError error) *ConflictError {
+	return &ConflictError{
+		AppError: NewAppError(message, originalError),
 	}
-	return e.Message
 }
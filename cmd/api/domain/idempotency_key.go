@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// IdempotencyKey is the outcome of a request sent with an Idempotency-Key
+// header. StatusCode is zero while the first request is being processed.
+// ResponseHeaders holds the response headers replayed along with the body.
+type IdempotencyKey struct {
+	Scope           string
+	Key             string
+	RequestHash     string
+	StatusCode      int
+	ResponseBody    []byte
+	ResponseHeaders map[string]string
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+}
+
+func (k IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
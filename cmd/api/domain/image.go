@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// Thumbnail statuses of a product image. Only images stored by the service
+// get renditions; the others are skipped.
+const (
+	ThumbnailPending   = "pending"
+	ThumbnailGenerated = "generated"
+	ThumbnailFailed    = "failed"
+	ThumbnailSkipped   = "skipped"
+)
+
+// RenditionSize is a resized copy generated for every uploaded image, whose
+// longest side is at most MaxSide pixels.
+type RenditionSize struct {
+	Name    string
+	MaxSide int
+}
+
+var RenditionSizes = []RenditionSize{
+	{Name: "small", MaxSide: 160},
+	{Name: "medium", MaxSide: 480},
+	{Name: "large", MaxSide: 1024},
+}
+
+// Rendition is a resized copy of a product image.
+type Rendition struct {
+	Size   string `json:"size"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// ProductImage is one of the images of a product. Images are shown by
+// ascending Position and the Primary one is the image of the product.
+type ProductImage struct {
+	ID        int64     `json:"id"`
+	ProductID int64     `json:"product_id"`
+	URL       string    `json:"url"`
+	Position  int       `json:"position"`
+	AltText   string    `json:"alt_text"`
+	Width     int       `json:"width"`
+	Height    int       `json:"height"`
+	Primary   bool      `json:"primary"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ThumbnailStatus   string      `json:"thumbnail_status"`
+	ThumbnailAttempts int         `json:"thumbnail_attempts"`
+	Renditions        []Rendition `json:"renditions"`
+}
+
+// SetURL replaces the URL of an image and reports whether it changed, in
+// which case its renditions are dropped and generated again.
+func (i *ProductImage) SetURL(url string) bool {
+	if i.URL == url {
+		return false
+	}
+	i.URL = url
+	i.ThumbnailStatus = ThumbnailPending
+	i.ThumbnailAttempts = 0
+	i.Renditions = nil
+	return true
+}
+
+// ArrangeImages sorts images by position and numbers them from 0, keeping a
+// single primary image: the first one flagged, or the first image when none
+// is.
+func ArrangeImages(images []ProductImage) []ProductImage {
+	sort.SliceStable(images, func(i, j int) bool {
+		if images[i].Position != images[j].Position {
+			return images[i].Position < images[j].Position
+		}
+		return images[i].ID < images[j].ID
+	})
+	primary := -1
+	for i := range images {
+		images[i].Position = i
+		if images[i].Primary && primary < 0 {
+			primary = i
+		}
+		images[i].Primary = false
+	}
+	if len(images) > 0 {
+		if primary < 0 {
+			primary = 0
+		}
+		images[primary].Primary = true
+	}
+	return images
+}
+
+// PrimaryImage returns the URL of the primary image, or an empty string when
+// there are no images.
+func PrimaryImage(images []ProductImage) string {
+	for _, image := range images {
+		if image.Primary {
+			return image.URL
+		}
+	}
+	return ""
+}
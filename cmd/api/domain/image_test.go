@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrangeImages(t *testing.T) {
+	tests := []struct {
+		name        string
+		images      []ProductImage
+		wantIDs     []int64
+		wantPrimary int64
+	}{
+		{
+			name:   "no images",
+			images: []ProductImage{},
+		},
+		{
+			name: "sorts by position and then ID",
+			images: []ProductImage{
+				{ID: 3, Position: 1},
+				{ID: 2, Position: 5, Primary: true},
+				{ID: 1, Position: 1},
+			},
+			wantIDs:     []int64{1, 3, 2},
+			wantPrimary: 2,
+		},
+		{
+			name: "first image becomes primary",
+			images: []ProductImage{
+				{ID: 1, Position: 1},
+				{ID: 2, Position: 0},
+			},
+			wantIDs:     []int64{2, 1},
+			wantPrimary: 2,
+		},
+		{
+			name: "keeps the first primary",
+			images: []ProductImage{
+				{ID: 1, Position: 0},
+				{ID: 2, Position: 1, Primary: true},
+				{ID: 3, Position: 2, Primary: true},
+			},
+			wantIDs:     []int64{1, 2, 3},
+			wantPrimary: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			images := ArrangeImages(tt.images)
+
+			var ids []int64
+			var primary int64
+			for i, image := range images {
+				assert.Equal(t, i, image.Position)
+				ids = append(ids, image.ID)
+				if image.Primary {
+					assert.Zero(t, primary, "only one image should be primary")
+					primary = image.ID
+				}
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantPrimary, primary)
+		})
+	}
+}
+
+func TestPrimaryImage(t *testing.T) {
+	images := []ProductImage{
+		{ID: 1, URL: "https://cdn.example.com/1.jpg"},
+		{ID: 2, URL: "https://cdn.example.com/2.jpg", Primary: true},
+	}
+
+	assert.Equal(t, "https://cdn.example.com/2.jpg", PrimaryImage(images))
+	assert.Empty(t, PrimaryImage(nil))
+}
+
+func TestProductImage_SetURL(t *testing.T) {
+	image := ProductImage{
+		URL:               "https://cdn.example.com/1.jpg",
+		ThumbnailStatus:   ThumbnailGenerated,
+		ThumbnailAttempts: 1,
+		Renditions:        []Rendition{{Size: "small", URL: "https://cdn.example.com/1_small.jpg"}},
+	}
+
+	assert.False(t, image.SetURL("https://cdn.example.com/1.jpg"))
+	assert.Equal(t, ThumbnailGenerated, image.ThumbnailStatus)
+	assert.Len(t, image.Renditions, 1)
+
+	assert.True(t, image.SetURL("https://cdn.example.com/2.jpg"))
+	assert.Equal(t, "https://cdn.example.com/2.jpg", image.URL)
+	assert.Equal(t, ThumbnailPending, image.ThumbnailStatus)
+	assert.Zero(t, image.ThumbnailAttempts)
+	assert.Empty(t, image.Renditions)
+}
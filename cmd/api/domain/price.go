@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+)
+
+// ProductPrice is the price of a product in the price list of a currency,
+// used instead of converting its base price for that market.
+type ProductPrice struct {
+	ProductID int64         `json:"product_id"`
+	Currency  string        `json:"currency"`
+	Price     money.Decimal `json:"price"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// ExchangeRate converts amounts in Base into Quote: 1 Base = Rate Quote.
+type ExchangeRate struct {
+	Base      string        `json:"base"`
+	Quote     string        `json:"quote"`
+	Rate      money.Decimal `json:"rate"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// PriceChange records a change of the base price of a product: who made it,
+// when and why, with the price before and after. With PriceList set it is a
+// change of the price in the price list of NewCurrency instead, and OldPrice
+// or NewPrice is nil when that list price was created or removed.
+type PriceChange struct {
+	ID          int64          `json:"id"`
+	ProductID   int64          `json:"product_id"`
+	OldPrice    *money.Decimal `json:"old_price"`
+	OldCurrency string         `json:"old_currency"`
+	NewPrice    *money.Decimal `json:"new_price"`
+	NewCurrency string         `json:"new_currency"`
+	PriceList   bool           `json:"price_list"`
+	ChangedBy   string         `json:"changed_by"`
+	Reason      string         `json:"reason"`
+	ChangedAt   time.Time      `json:"changed_at"`
+}
+
+// PriceHistoryFilter bounds price changes to [From, To). Nil bounds are open.
+type PriceHistoryFilter struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// Statuses of a ScheduledPriceChange. Only pending changes are applied or
+// cancelled; the others are kept as history.
+const (
+	ScheduledPricePending   = "pending"
+	ScheduledPriceApplied   = "applied"
+	ScheduledPriceFailed    = "failed"
+	ScheduledPriceCancelled = "cancelled"
+)
+
+// ScheduledPriceChange is a price that takes effect at EffectiveAt. Failed
+// attempts are counted in Attempts and retried from NextAttemptAt.
+type ScheduledPriceChange struct {
+	ID            int64
+	ProductID     int64
+	NewPrice      money.Decimal
+	NewCurrency   string
+	EffectiveAt   time.Time
+	Reason        string
+	CreatedBy     string
+	Status        string
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	AppliedAt     *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
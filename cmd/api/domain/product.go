@@ -1,13 +1,48 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+)
+
+// Lifecycle statuses of a product. Only published products are listed to the
+// public.
+const (
+	ProductDraft     = "draft"
+	ProductPublished = "published"
+	ProductArchived  = "archived"
+)
+
+// productTransitions lists the statuses each status can move to.
+var productTransitions = map[string][]string{
+	ProductDraft:     {ProductPublished},
+	ProductPublished: {ProductArchived},
+	ProductArchived:  {ProductPublished},
+}
 
 type Product struct {
-	ID          int64     `json:"id,omitempty"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Image       string    `json:"image"`
-	CategoryID  int64     `json:"category_id"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          int64         `json:"id,omitempty"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Price       money.Decimal `json:"price"`
+	Currency    string        `json:"currency"`
+	Image       string        `json:"image"`
+	CategoryID  int64         `json:"category_id"`
+	Status      string        `json:"status"`
+	// PublishAt and UnpublishAt bound the window in which the product is
+	// visible. A nil bound leaves that side of the window open.
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CanTransitionTo reports whether the product may move to status.
+func (p Product) CanTransitionTo(status string) bool {
+	for _, next := range productTransitions[p.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
 }
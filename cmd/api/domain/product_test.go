@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProduct_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{ProductDraft, ProductPublished, true},
+		{ProductDraft, ProductArchived, false},
+		{ProductPublished, ProductArchived, true},
+		{ProductPublished, ProductDraft, false},
+		{ProductPublished, ProductPublished, false},
+		{ProductArchived, ProductPublished, true},
+		{ProductArchived, ProductDraft, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, Product{Status: tt.from}.CanTransitionTo(tt.to))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+)
+
+const (
+	// PromotionPercentage takes Value percent off the price.
+	PromotionPercentage = "percentage"
+	// PromotionFixed takes Value, in Currency, off the price.
+	PromotionFixed = "fixed"
+)
+
+var hundred = money.NewDecimal(100, 0)
+
+// Promotion is a time-boxed discount. It targets the products listed in
+// ProductIDs, the products of the categories in CategoryIDs, or both, and
+// only those priced between MinPrice and MaxPrice when either is set.
+type Promotion struct {
+	ID          int64
+	Name        string
+	Type        string
+	Value       money.Decimal
+	Currency    string
+	ProductIDs  []int64
+	CategoryIDs []int64
+	MinPrice    *money.Decimal
+	MaxPrice    *money.Decimal
+	Priority    int
+	Stackable   bool
+	StartsAt    time.Time
+	EndsAt      time.Time
+	CreatedAt   time.Time
+}
+
+// ActiveAt reports whether t is within [StartsAt, EndsAt).
+func (p Promotion) ActiveAt(t time.Time) bool {
+	return !t.Before(p.StartsAt) && t.Before(p.EndsAt)
+}
+
+// AppliesTo reports whether product is targeted by p. Fixed discounts and
+// price ranges are in Currency, so they only apply to products priced in it.
+func (p Promotion) AppliesTo(product Product) bool {
+	if (p.Type == PromotionFixed || p.MinPrice != nil || p.MaxPrice != nil) && product.Currency != p.Currency {
+		return false
+	}
+	if p.MinPrice != nil && product.Price.Cmp(*p.MinPrice) < 0 {
+		return false
+	}
+	if p.MaxPrice != nil && product.Price.Cmp(*p.MaxPrice) > 0 {
+		return false
+	}
+	if len(p.ProductIDs) == 0 && len(p.CategoryIDs) == 0 {
+		return true
+	}
+	return containsID(p.ProductIDs, product.ID) || containsID(p.CategoryIDs, product.CategoryID)
+}
+
+// discount returns the amount p takes off price, rounded to digits.
+func (p Promotion) discount(price money.Decimal, digits int32) money.Decimal {
+	if p.Type == PromotionPercentage {
+		return price.Mul(p.Value).Quo(hundred, digits)
+	}
+	return p.Value.Round(digits)
+}
+
+// ApplyPromotions returns the price of product after the promotions that
+// apply to it and their IDs, in the order they were applied. Promotions are
+// tried by descending Priority, then ascending ID:
+//
+//   - the first one that applies is always applied;
+//   - when it is not stackable, it is the only one;
+//   - otherwise every following stackable one that applies is applied on the
+//     already discounted price, and non-stackable ones are skipped.
+//
+// Targets and price ranges are matched against the original price, and the
+// final price is never negative.
+func ApplyPromotions(product Product, promotions []Promotion) (money.Decimal, []int64) {
+	sorted := make([]Promotion, len(promotions))
+	copy(sorted, promotions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	digits := int32(product.Price.Scale())
+	if currency, ok := money.LookupCurrency(product.Currency); ok {
+		digits = currency.Digits
+	}
+
+	price := product.Price
+	var applied []int64
+	for _, promotion := range sorted {
+		if !promotion.AppliesTo(product) {
+			continue
+		}
+		if len(applied) > 0 && !promotion.Stackable {
+			continue
+		}
+		price = price.Sub(promotion.discount(price, digits))
+		applied = append(applied, promotion.ID)
+		if !promotion.Stackable {
+			break
+		}
+	}
+	if price.Sign() < 0 {
+		price = money.NewDecimal(0, digits)
+	}
+	return price, applied
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
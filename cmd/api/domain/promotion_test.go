@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func decimal(s string) *money.Decimal {
+	d := money.MustParseDecimal(s)
+	return &d
+}
+
+func TestPromotion_ActiveAt(t *testing.T) {
+	start := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	promotion := Promotion{StartsAt: start, EndsAt: start.Add(24 * time.Hour)}
+
+	assert.False(t, promotion.ActiveAt(start.Add(-time.Second)))
+	assert.True(t, promotion.ActiveAt(start))
+	assert.True(t, promotion.ActiveAt(start.Add(23*time.Hour)))
+	assert.False(t, promotion.ActiveAt(start.Add(24*time.Hour)))
+}
+
+func TestPromotion_AppliesTo(t *testing.T) {
+	product := Product{ID: 1, CategoryID: 10, Price: money.MustParseDecimal("100.00"), Currency: "BRL"}
+
+	tests := []struct {
+		name      string
+		promotion Promotion
+		applies   bool
+	}{
+		{name: "product target", promotion: Promotion{Type: PromotionPercentage, ProductIDs: []int64{1}}, applies: true},
+		{name: "other product", promotion: Promotion{Type: PromotionPercentage, ProductIDs: []int64{2}}, applies: false},
+		{name: "category target", promotion: Promotion{Type: PromotionPercentage, CategoryIDs: []int64{10}}, applies: true},
+		{name: "product or category", promotion: Promotion{Type: PromotionPercentage, ProductIDs: []int64{2}, CategoryIDs: []int64{10}}, applies: true},
+		{name: "within price range", promotion: Promotion{Type: PromotionPercentage, Currency: "BRL", MinPrice: decimal("50"), MaxPrice: decimal("100")}, applies: true},
+		{name: "below price range", promotion: Promotion{Type: PromotionPercentage, Currency: "BRL", MinPrice: decimal("100.01")}, applies: false},
+		{name: "above price range", promotion: Promotion{Type: PromotionPercentage, Currency: "BRL", MaxPrice: decimal("99.99")}, applies: false},
+		{name: "price range in other currency", promotion: Promotion{Type: PromotionPercentage, Currency: "USD", MinPrice: decimal("50")}, applies: false},
+		{name: "category and price range", promotion: Promotion{Type: PromotionPercentage, Currency: "BRL", CategoryIDs: []int64{10}, MaxPrice: decimal("50")}, applies: false},
+		{name: "fixed in other currency", promotion: Promotion{Type: PromotionFixed, Currency: "USD", ProductIDs: []int64{1}}, applies: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.applies, tt.promotion.AppliesTo(product))
+		})
+	}
+}
+
+func TestApplyPromotions(t *testing.T) {
+	product := Product{ID: 1, CategoryID: 10, Price: money.MustParseDecimal("100.00"), Currency: "BRL"}
+
+	tenPercent := Promotion{ID: 1, Type: PromotionPercentage, Value: money.MustParseDecimal("10"), ProductIDs: []int64{1}, Priority: 1, Stackable: true}
+	fiveOff := Promotion{ID: 2, Type: PromotionFixed, Value: money.MustParseDecimal("5"), Currency: "BRL", CategoryIDs: []int64{10}, Priority: 2, Stackable: true}
+	halfPrice := Promotion{ID: 3, Type: PromotionPercentage, Value: money.MustParseDecimal("50"), CategoryIDs: []int64{10}, Priority: 3}
+	otherCategory := Promotion{ID: 4, Type: PromotionPercentage, Value: money.MustParseDecimal("90"), CategoryIDs: []int64{20}, Priority: 9}
+	tooMuch := Promotion{ID: 5, Type: PromotionFixed, Value: money.MustParseDecimal("500"), Currency: "BRL", ProductIDs: []int64{1}}
+
+	tests := []struct {
+		name       string
+		promotions []Promotion
+		price      string
+		applied    []int64
+	}{
+		{name: "no promotions", promotions: nil, price: "100.00", applied: nil},
+		{name: "not targeted", promotions: []Promotion{otherCategory}, price: "100.00", applied: nil},
+		{name: "stacked by priority", promotions: []Promotion{tenPercent, fiveOff}, price: "85.50", applied: []int64{2, 1}},
+		{name: "exclusive first", promotions: []Promotion{tenPercent, fiveOff, halfPrice}, price: "50.00", applied: []int64{3}},
+		{name: "exclusive after stackable is skipped", promotions: []Promotion{fiveOff, {ID: 6, Type: PromotionPercentage, Value: money.MustParseDecimal("50"), ProductIDs: []int64{1}}}, price: "95.00", applied: []int64{2}},
+		{name: "never negative", promotions: []Promotion{tooMuch}, price: "0.00", applied: []int64{5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, applied := ApplyPromotions(product, tt.promotions)
+			assert.Equal(t, tt.price, price.String())
+			assert.Equal(t, tt.applied, applied)
+		})
+	}
+}
+
+func TestApplyPromotions_RoundsToCurrency(t *testing.T) {
+	product := Product{ID: 1, Price: money.MustParseDecimal("999"), Currency: "CLP"}
+	promotion := Promotion{ID: 1, Type: PromotionPercentage, Value: money.MustParseDecimal("15"), ProductIDs: []int64{1}}
+
+	price, _ := ApplyPromotions(product, []Promotion{promotion})
+
+	assert.Equal(t, "849", price.String())
+}
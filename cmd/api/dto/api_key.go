@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type APIKeyDTO struct {
+	Name   string   `json:"name" validate:"required,max=190"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=catalog:read catalog:write catalog:admin"`
+}
+
+type APIKeyResponseDTO struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// APIKeyCreatedResponseDTO is the only response that carries the key itself;
+// it cannot be recovered afterwards.
+type APIKeyCreatedResponseDTO struct {
+	APIKeyResponseDTO
+	Key string `json:"key"`
+}
+
+func (a *APIKeyDTO) Validate() error {
+	return validate.Struct(a)
+}
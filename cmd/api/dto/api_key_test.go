@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func validAPIKey() APIKeyDTO {
+	return APIKeyDTO{
+		Name:   "storefront",
+		Scopes: []string{"catalog:read", "catalog:write"},
+	}
+}
+
+func TestAPIKeyDTO_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(a *APIKeyDTO)
+		field  string
+		tag    string
+	}{
+		{name: "valid", modify: func(a *APIKeyDTO) {}},
+		{name: "admin", modify: func(a *APIKeyDTO) { a.Scopes = []string{"catalog:admin"} }},
+		{name: "missing name", modify: func(a *APIKeyDTO) { a.Name = "" }, field: "name", tag: "required"},
+		{name: "missing scopes", modify: func(a *APIKeyDTO) { a.Scopes = nil }, field: "scopes", tag: "required"},
+		{name: "empty scopes", modify: func(a *APIKeyDTO) { a.Scopes = []string{} }, field: "scopes", tag: "min"},
+		{name: "unknown scope", modify: func(a *APIKeyDTO) { a.Scopes = []string{"catalog:read", "catalog:delete"} }, field: "scopes[1]", tag: "oneof"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKey := validAPIKey()
+			tt.modify(&apiKey)
+
+			err := apiKey.Validate()
+
+			if tt.field == "" {
+				assert.NoError(t, err, "Error should not be returned")
+				return
+			}
+			var validationErrors validator.ValidationErrors
+			if assert.ErrorAs(t, err, &validationErrors) {
+				assert.Len(t, validationErrors, 1)
+				assert.Equal(t, tt.field, validationErrors[0].Field())
+				assert.Equal(t, tt.tag, validationErrors[0].Tag())
+			}
+		})
+	}
+}
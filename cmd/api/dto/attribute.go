@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"regexp"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// AttributeDefinitionDTO is an attribute of the products of a category. Name
+// and Type cannot change once the attribute is created.
+type AttributeDefinitionDTO struct {
+	ID            int64    `json:"id,omitempty"`
+	Name          string   `json:"name" validate:"required,max=64,attribute_name" example:"screen_size"`
+	Type          string   `json:"type" validate:"required,oneof=string number boolean" example:"number"`
+	Required      bool     `json:"required"`
+	AllowedValues []string `json:"allowed_values,omitempty" validate:"dive,required,max=255" example:"43,55,65"`
+}
+
+func (a *AttributeDefinitionDTO) Validate() error {
+	return validate.Struct(a)
+}
+
+var attributeName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// validateAttributeName keeps attribute names usable as attr.<name> query
+// parameters.
+func validateAttributeName(fl validator.FieldLevel) bool {
+	return attributeName.MatchString(fl.Field().String())
+}
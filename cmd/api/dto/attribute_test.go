@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func validAttributeDefinition() AttributeDefinitionDTO {
+	return AttributeDefinitionDTO{
+		Name:          "screen_size",
+		Type:          "number",
+		Required:      true,
+		AllowedValues: []string{"43", "55", "65"},
+	}
+}
+
+func TestAttributeDefinitionDTO_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(a *AttributeDefinitionDTO)
+		field  string
+		tag    string
+	}{
+		{name: "valid", modify: func(a *AttributeDefinitionDTO) {}},
+		{name: "valid without allowed values", modify: func(a *AttributeDefinitionDTO) { a.AllowedValues = nil }},
+		{name: "missing name", modify: func(a *AttributeDefinitionDTO) { a.Name = "" }, field: "name", tag: "required"},
+		{name: "uppercase name", modify: func(a *AttributeDefinitionDTO) { a.Name = "Screen_Size" }, field: "name", tag: "attribute_name"},
+		{name: "name with dot", modify: func(a *AttributeDefinitionDTO) { a.Name = "screen.size" }, field: "name", tag: "attribute_name"},
+		{name: "name starting with digit", modify: func(a *AttributeDefinitionDTO) { a.Name = "4k" }, field: "name", tag: "attribute_name"},
+		{name: "long name", modify: func(a *AttributeDefinitionDTO) { a.Name = strings.Repeat("a", 65) }, field: "name", tag: "max"},
+		{name: "unknown type", modify: func(a *AttributeDefinitionDTO) { a.Type = "date" }, field: "type", tag: "oneof"},
+		{name: "empty allowed value", modify: func(a *AttributeDefinitionDTO) { a.AllowedValues = []string{"43", ""} }, field: "allowed_values[1]", tag: "required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attribute := validAttributeDefinition()
+			tt.modify(&attribute)
+
+			err := attribute.Validate()
+
+			if tt.field == "" {
+				assert.NoError(t, err, "Error should not be returned")
+				return
+			}
+			var validationErrors validator.ValidationErrors
+			if assert.ErrorAs(t, err, &validationErrors) {
+				assert.Len(t, validationErrors, 1)
+				assert.Equal(t, tt.field, validationErrors[0].Field())
+				assert.Equal(t, tt.tag, validationErrors[0].Tag())
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package dto
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+type HealthResponse struct {
+	Status     string                     `json:"status"`
+	Components map[string]HealthComponent `json:"components,omitempty"`
+}
+
+type HealthComponent struct {
+	Status  string      `json:"status"`
+	Error   string      `json:"error,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+type PoolStatsDTO struct {
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`
+	WaitDuration       string `json:"wait_duration"`
+	MaxIdleClosed      int64  `json:"max_idle_closed"`
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`
+}
+
+type MigrationsDTO struct {
+	Pending []string `json:"pending,omitempty"`
+}
+
+func (h HealthResponse) IsUp() bool {
+	return h.Status == HealthStatusUp
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// ProductImageDTO is an image of a product. Position is read-only: new images
+// go last and are moved with the reorder endpoint. ThumbnailStatus and
+// Renditions are read-only too: renditions are generated in the background
+// for uploaded images.
+type ProductImageDTO struct {
+	ID        int64     `json:"id,omitempty"`
+	URL       string    `json:"url" validate:"required,url,max=255" example:"https://cdn.example.com/products/1/front.jpg"`
+	Position  int       `json:"position"`
+	AltText   string    `json:"alt_text,omitempty" validate:"max=255" example:"Front view"`
+	Width     int       `json:"width,omitempty" validate:"gte=0" example:"1200"`
+	Height    int       `json:"height,omitempty" validate:"gte=0" example:"900"`
+	Primary   bool      `json:"primary"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ThumbnailStatus string         `json:"thumbnail_status,omitempty" example:"generated"`
+	Renditions      []RenditionDTO `json:"renditions,omitempty"`
+}
+
+// RenditionDTO is a resized copy of an image: small, medium or large.
+type RenditionDTO struct {
+	Size   string `json:"size" example:"small"`
+	URL    string `json:"url" example:"http://localhost:8080/uploads/4f0c9a1e2b7d8c3a5e6f7a8b9c0d1e2f_small.jpg"`
+	Width  int    `json:"width" example:"160"`
+	Height int    `json:"height" example:"120"`
+}
+
+func (p *ProductImageDTO) Validate() error {
+	return validate.Struct(p)
+}
+
+// ProductImageOrderDTO lists every image of a product in its new order.
+type ProductImageOrderDTO struct {
+	ImageIDs []int64 `json:"image_ids" validate:"required,unique,dive,gt=0" example:"3,1,2"`
+}
+
+func (p *ProductImageOrderDTO) Validate() error {
+	return validate.Struct(p)
+}
+
+// UploadDTO describes an uploaded image. URL, Width and Height are meant to
+// be sent as they are to add the image to a product.
+type UploadDTO struct {
+	URL         string `json:"url" example:"http://localhost:8080/uploads/4f0c9a1e2b7d8c3a5e6f7a8b9c0d1e2f.jpg"`
+	ContentType string `json:"content_type" example:"image/jpeg"`
+	Size        int64  `json:"size" example:"204800"`
+	Width       int    `json:"width" example:"1200"`
+	Height      int    `json:"height" example:"900"`
+}
@@ -1,13 +1,51 @@
 package dto
 
+import (
+	"sort"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+)
+
 type SearchParams struct {
 	Limit  *int64
 	Offset *int64
-	Min    *float64
-	Max    *float64
+	Min    *money.Decimal
+	Max    *money.Decimal
 	Sort   *string
 	Title  *string
 	Name   *string
+	// Status restricts products to a lifecycle status.
+	Status *string
+	// Public restricts products to those whose publication window contains
+	// the current time, as public listings do. Admin listings leave it unset.
+	Public bool
+	// Attributes restricts products to those with these attribute values,
+	// by attribute name, as requested.
+	Attributes map[string]string
+	// AttributeValues are the values of Attributes normalized for each
+	// definition of their name, by attribute ID. Searches filter by them.
+	AttributeValues map[string]map[int64]string
+}
+
+// AttributeNames returns the names of the attributes to filter by in order.
+func (p SearchParams) AttributeNames() []string {
+	names := make([]string, 0, len(p.AttributeValues))
+	for name := range p.AttributeValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AttributeIDs returns the IDs of the attributes of a name to filter by in
+// order.
+func (p SearchParams) AttributeIDs(name string) []int64 {
+	ids := make([]int64, 0, len(p.AttributeValues[name]))
+	for id := range p.AttributeValues[name] {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
 }
 
 type Metadata struct {
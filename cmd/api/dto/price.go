@@ -0,0 +1,228 @@
+package dto
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+)
+
+// Pricing is how prices are read from and written to JSON payloads.
+type Pricing struct {
+	Format          money.Format
+	DefaultCurrency string
+}
+
+var pricing = Pricing{Format: money.FormatNumber, DefaultCurrency: "BRL"}
+
+// SetPricing configures the price format and the currency of prices sent
+// without one. It must be called before serving requests.
+func SetPricing(p Pricing) {
+	pricing = p
+}
+
+// PriceCurrency returns code, or the default currency when code is empty.
+func PriceCurrency(code string) string {
+	if code == "" {
+		return pricing.DefaultCurrency
+	}
+	return code
+}
+
+// priceCurrency resolves the currency a price is written in. Unknown codes
+// are rejected by validation; until then they are formatted with two digits.
+func priceCurrency(code string) money.Currency {
+	code = PriceCurrency(code)
+	if currency, ok := money.LookupCurrency(code); ok {
+		return currency
+	}
+	return money.Currency{Code: code, Digits: 2}
+}
+
+func encodePrice(price money.Decimal, currency string) (json.RawMessage, error) {
+	return pricing.Format.Encode(price, priceCurrency(currency))
+}
+
+// encodeOptionalPrice writes nil prices as null.
+func encodeOptionalPrice(price *money.Decimal, currency string) (json.RawMessage, error) {
+	if price == nil {
+		return json.RawMessage("null"), nil
+	}
+	return encodePrice(*price, currency)
+}
+
+func decodePrice(raw json.RawMessage, currency string) (money.Decimal, error) {
+	return pricing.Format.Decode(raw, priceCurrency(currency))
+}
+
+// validateCurrency accepts the ISO 4217 codes money knows the minor unit of.
+func validateCurrency(fl validator.FieldLevel) bool {
+	_, ok := money.LookupCurrency(fl.Field().String())
+	return ok
+}
+
+// validatePricePrecision rejects prices with more decimal places than the
+// minor unit of their currency, e.g. BRL 10.001 or CLP 10.5.
+func validatePricePrecision(sl validator.StructLevel) {
+	var price money.Decimal
+	var code string
+	switch p := sl.Current().Interface().(type) {
+	case ProductDTO:
+		price, code = p.Price, p.Currency
+	case ProductUpdateDTO:
+		price, code = p.Price, p.Currency
+	case ProductPriceDTO:
+		price, code = p.Price, p.Currency
+	case ScheduledPriceChangeDTO:
+		price, code = p.Price, p.Currency
+	default:
+		return
+	}
+	currency, ok := money.LookupCurrency(PriceCurrency(code))
+	if !ok || currency.Fits(price) {
+		return
+	}
+	sl.ReportError(price, "price", "Price", "precision", strconv.Itoa(int(currency.Digits)))
+}
+
+// ProductPriceDTO is the price of a product in the price list of a currency.
+type ProductPriceDTO struct {
+	ProductID int64         `json:"product_id,omitempty"`
+	Currency  string        `json:"currency" validate:"required,currency" example:"ARS"`
+	Price     money.Decimal `json:"price" validate:"gte=0" swaggertype:"string" example:"15999.90"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	// PriceChangeReason is recorded in the price history when the price
+	// changes. It is never returned.
+	PriceChangeReason string `json:"price_change_reason,omitempty" validate:"max=255"`
+}
+
+func (p *ProductPriceDTO) Validate() error {
+	return validate.Struct(p)
+}
+
+// MarshalJSON writes the price in the configured format.
+func (p ProductPriceDTO) MarshalJSON() ([]byte, error) {
+	type productPrice ProductPriceDTO
+	price, err := encodePrice(p.Price, p.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		productPrice
+		Price json.RawMessage `json:"price"`
+	}{productPrice(p), price})
+}
+
+// UnmarshalJSON reads the price in the configured format.
+func (p *ProductPriceDTO) UnmarshalJSON(data []byte) error {
+	type productPrice ProductPriceDTO
+	aux := struct {
+		*productPrice
+		Price json.RawMessage `json:"price"`
+	}{productPrice: (*productPrice)(p)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	price, err := decodePrice(aux.Price, p.Currency)
+	if err != nil {
+		return err
+	}
+	p.Price = price
+	return nil
+}
+
+// ExchangeRateDTO converts amounts in Base into Quote: 1 Base = Rate Quote.
+type ExchangeRateDTO struct {
+	Base      string        `json:"base" example:"BRL"`
+	Quote     string        `json:"quote" example:"ARS"`
+	Rate      money.Decimal `json:"rate" swaggertype:"string" example:"180.25"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// PriceChangeDTO is an entry of the price history of a product. Entries with
+// PriceList set are changes of the price list of NewCurrency, where OldPrice
+// is null for a new list price and NewPrice for a removed one.
+type PriceChangeDTO struct {
+	ID          int64          `json:"id"`
+	ProductID   int64          `json:"product_id"`
+	OldPrice    *money.Decimal `json:"old_price" swaggertype:"string" example:"12.90"`
+	OldCurrency string         `json:"old_currency" example:"BRL"`
+	NewPrice    *money.Decimal `json:"new_price" swaggertype:"string" example:"10.50"`
+	NewCurrency string         `json:"new_currency" example:"BRL"`
+	PriceList   bool           `json:"price_list"`
+	ChangedBy   string         `json:"changed_by"`
+	Reason      string         `json:"reason"`
+	ChangedAt   time.Time      `json:"changed_at"`
+}
+
+// MarshalJSON writes prices in the configured format.
+func (p PriceChangeDTO) MarshalJSON() ([]byte, error) {
+	type priceChange PriceChangeDTO
+	oldPrice, err := encodeOptionalPrice(p.OldPrice, p.OldCurrency)
+	if err != nil {
+		return nil, err
+	}
+	newPrice, err := encodeOptionalPrice(p.NewPrice, p.NewCurrency)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		priceChange
+		OldPrice json.RawMessage `json:"old_price"`
+		NewPrice json.RawMessage `json:"new_price"`
+	}{priceChange(p), oldPrice, newPrice})
+}
+
+// ScheduledPriceChangeDTO is a price that takes effect at EffectiveAt. It is
+// written without currency to keep the one of the product.
+type ScheduledPriceChangeDTO struct {
+	ID          int64         `json:"id,omitempty"`
+	ProductID   int64         `json:"product_id,omitempty"`
+	Price       money.Decimal `json:"price" validate:"gte=0" swaggertype:"string" example:"10.50"`
+	Currency    string        `json:"currency,omitempty" validate:"omitempty,currency" example:"BRL"`
+	EffectiveAt time.Time     `json:"effective_at" validate:"required"`
+	Reason      string        `json:"reason,omitempty" validate:"max=255"`
+	CreatedBy   string        `json:"created_by,omitempty"`
+	Status      string        `json:"status,omitempty" example:"pending"`
+	Attempts    int           `json:"attempts"`
+	LastError   string        `json:"last_error,omitempty"`
+	AppliedAt   *time.Time    `json:"applied_at,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+func (s *ScheduledPriceChangeDTO) Validate() error {
+	return validate.Struct(s)
+}
+
+// MarshalJSON writes the price in the configured format.
+func (s ScheduledPriceChangeDTO) MarshalJSON() ([]byte, error) {
+	type scheduledPriceChange ScheduledPriceChangeDTO
+	price, err := encodePrice(s.Price, s.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		scheduledPriceChange
+		Price json.RawMessage `json:"price"`
+	}{scheduledPriceChange(s), price})
+}
+
+// UnmarshalJSON reads the price in the configured format.
+func (s *ScheduledPriceChangeDTO) UnmarshalJSON(data []byte) error {
+	type scheduledPriceChange ScheduledPriceChangeDTO
+	aux := struct {
+		*scheduledPriceChange
+		Price json.RawMessage `json:"price"`
+	}{scheduledPriceChange: (*scheduledPriceChange)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	price, err := decodePrice(aux.Price, s.Currency)
+	if err != nil {
+		return err
+	}
+	s.Price = price
+	return nil
+}
@@ -1,30 +1,66 @@
 package dto
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/go-playground/validator/v10"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
 )
 
-var validate *validator.Validate
-
-func init() {
-	validate = validator.New()
-}
-
 type ProductDTO struct {
-	ID          int64   `json:"id,omitempty"`
-	Title       string  `json:"title" validate:"required"`
-	Description string  `json:"description" validate:"required"`
-	Price       float64 `json:"price" validate:"gte=0"`
-	Image       string  `json:"image" validate:"required"`
-	Category    string  `json:"category" validate:"required"`
+	ID          int64         `json:"id,omitempty"`
+	Title       string        `json:"title" validate:"required"`
+	Description string        `json:"description" validate:"required"`
+	Price       money.Decimal `json:"price" validate:"gte=0" swaggertype:"string" example:"10.50"`
+	Currency    string        `json:"currency,omitempty" validate:"omitempty,currency" example:"BRL"`
+	Image       string        `json:"image" validate:"required"`
+	Category    string        `json:"category" validate:"required"`
+	// Status is the lifecycle status of the product. It is read-only: new
+	// products are drafts and move through the transition endpoints.
+	Status string `json:"status,omitempty" example:"published"`
+	// PublishAt and UnpublishAt bound the window in which the product is
+	// visible.
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+	// PriceDerived is set when the price was converted from another currency
+	// because the product has no price in the price list of this one.
+	PriceDerived bool `json:"price_derived,omitempty"`
+	// LowestPrice30Days is the lowest price of the product over the last 30
+	// days, shown next to discounts as consumer law requires.
+	LowestPrice30Days *money.Decimal `json:"lowest_price_30_days,omitempty" swaggertype:"string" example:"9.90"`
+	// OriginalPrice and FinalPrice are the price before and after the
+	// promotions listed in AppliedPromotionIDs.
+	OriginalPrice       *money.Decimal `json:"original_price,omitempty" swaggertype:"string" example:"10.50"`
+	FinalPrice          *money.Decimal `json:"final_price,omitempty" swaggertype:"string" example:"9.45"`
+	AppliedPromotionIDs []int64        `json:"applied_promotion_ids,omitempty"`
+	// PromotionsChangeAt is when the next promotion starts or ends, and so
+	// when FinalPrice may change. It is only used to expire cached products.
+	PromotionsChangeAt *time.Time `json:"-"`
+	// Images are the images of the product, whose primary one is also in
+	// Image. They are only returned for a single product.
+	Images []ProductImageDTO `json:"images,omitempty"`
+	// Attributes are the values of the attributes defined for the category
+	// of the product, by name.
+	Attributes map[string]string `json:"attributes,omitempty" example:"screen_size:55,smart:true"`
 }
 
 type ProductUpdateDTO struct {
-	Title        string  `json:"title" validate:"required"`
-	Description  string  `json:"description" validate:"required"`
-	Price        float64 `json:"price" validate:"gte=0"`
-	Image        string  `json:"image" validate:"required"`
-	CategoryName string  `json:"category" validate:"required"`
+	Title        string        `json:"title" validate:"required"`
+	Description  string        `json:"description" validate:"required"`
+	Price        money.Decimal `json:"price" validate:"gte=0" swaggertype:"string" example:"10.50"`
+	Currency     string        `json:"currency,omitempty" validate:"omitempty,currency" example:"BRL"`
+	Image        string        `json:"image" validate:"required"`
+	CategoryName string        `json:"category" validate:"required"`
+	// PriceChangeReason is recorded in the price history when the price
+	// changes.
+	PriceChangeReason string `json:"price_change_reason,omitempty" validate:"max=255"`
+	// PublishAt and UnpublishAt replace the publication window of the
+	// product; leaving them out opens the window.
+	PublishAt   *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"`
+	// Attributes replace the attribute values of the product.
+	Attributes map[string]string `json:"attributes,omitempty" example:"screen_size:55,smart:true"`
 }
 
 type ProductResponse struct {
@@ -35,3 +71,85 @@ type ProductResponse struct {
 func (p *ProductDTO) Validate() error {
 	return validate.Struct(p)
 }
+
+func (p *ProductUpdateDTO) Validate() error {
+	return validate.Struct(p)
+}
+
+// validateProduct checks the price precision and that the publication window
+// of a product is not empty.
+func validateProduct(sl validator.StructLevel) {
+	validatePricePrecision(sl)
+
+	var publishAt, unpublishAt *time.Time
+	switch p := sl.Current().Interface().(type) {
+	case ProductDTO:
+		publishAt, unpublishAt = p.PublishAt, p.UnpublishAt
+	case ProductUpdateDTO:
+		publishAt, unpublishAt = p.PublishAt, p.UnpublishAt
+	}
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		sl.ReportError(unpublishAt, "unpublish_at", "UnpublishAt", "gt", "publish_at")
+	}
+}
+
+// MarshalJSON writes prices in the configured format.
+func (p ProductDTO) MarshalJSON() ([]byte, error) {
+	type product ProductDTO
+	price, err := encodePrice(p.Price, p.Currency)
+	if err != nil {
+		return nil, err
+	}
+	var optional [3]json.RawMessage
+	for i, amount := range []*money.Decimal{p.LowestPrice30Days, p.OriginalPrice, p.FinalPrice} {
+		if amount == nil {
+			continue
+		}
+		if optional[i], err = encodePrice(*amount, p.Currency); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(struct {
+		product
+		Price             json.RawMessage `json:"price"`
+		LowestPrice30Days json.RawMessage `json:"lowest_price_30_days,omitempty"`
+		OriginalPrice     json.RawMessage `json:"original_price,omitempty"`
+		FinalPrice        json.RawMessage `json:"final_price,omitempty"`
+	}{product(p), price, optional[0], optional[1], optional[2]})
+}
+
+// UnmarshalJSON reads the price in the configured format.
+func (p *ProductDTO) UnmarshalJSON(data []byte) error {
+	type product ProductDTO
+	aux := struct {
+		*product
+		Price json.RawMessage `json:"price"`
+	}{product: (*product)(p)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	price, err := decodePrice(aux.Price, p.Currency)
+	if err != nil {
+		return err
+	}
+	p.Price = price
+	return nil
+}
+
+// UnmarshalJSON reads the price in the configured format.
+func (p *ProductUpdateDTO) UnmarshalJSON(data []byte) error {
+	type product ProductUpdateDTO
+	aux := struct {
+		*product
+		Price json.RawMessage `json:"price"`
+	}{product: (*product)(p)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	price, err := decodePrice(aux.Price, p.Currency)
+	if err != nil {
+		return err
+	}
+	p.Price = price
+	return nil
+}
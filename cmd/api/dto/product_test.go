@@ -1,8 +1,12 @@
 package dto
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/go-playground/validator/v10"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,7 +21,7 @@ func TestProductDTO_Validate(t *testing.T) {
 			product: &ProductDTO{
 				Title:       "Test Product",
 				Description: "Test Description",
-				Price:       10.00,
+				Price:       money.MustParseDecimal("10.00"),
 				Image:       "test.jpg",
 				Category:    "Test Category",
 			},
@@ -27,7 +31,7 @@ func TestProductDTO_Validate(t *testing.T) {
 			name: "Test 2: Invalid ProductDTO",
 			product: &ProductDTO{
 				Description: "Test Description",
-				Price:       10.00,
+				Price:       money.MustParseDecimal("10.00"),
 				Image:       "test.jpg",
 				Category:    "Test Category",
 			},
@@ -46,3 +50,178 @@ func TestProductDTO_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestProductUpdateDTO_ValidateReportsJSONNames(t *testing.T) {
+	product := &ProductUpdateDTO{
+		Title:       "Test Product",
+		Description: "Test Description",
+		Price:       money.NewDecimal(-1, 0),
+		Image:       "test.jpg",
+	}
+
+	err := product.Validate()
+
+	var validationErrors validator.ValidationErrors
+	if assert.ErrorAs(t, err, &validationErrors) {
+		assert.Len(t, validationErrors, 2)
+		assert.Equal(t, "price", validationErrors[0].Field())
+		assert.Equal(t, "gte", validationErrors[0].Tag())
+		assert.Equal(t, "category", validationErrors[1].Field())
+		assert.Equal(t, "required", validationErrors[1].Tag())
+	}
+}
+
+func TestProductDTO_ValidatePricePrecision(t *testing.T) {
+	product := &ProductDTO{
+		Title:       "Test Product",
+		Description: "Test Description",
+		Price:       money.MustParseDecimal("10.5"),
+		Currency:    "CLP",
+		Image:       "test.jpg",
+		Category:    "Test Category",
+	}
+
+	err := product.Validate()
+
+	var validationErrors validator.ValidationErrors
+	if assert.ErrorAs(t, err, &validationErrors) {
+		assert.Len(t, validationErrors, 1)
+		assert.Equal(t, "price", validationErrors[0].Field())
+		assert.Equal(t, "precision", validationErrors[0].Tag())
+		assert.Equal(t, "0", validationErrors[0].Param())
+	}
+
+	product.Currency = "USD"
+	assert.NoError(t, product.Validate(), "Error should not be returned")
+
+	product.Currency = "XYZ"
+	if assert.ErrorAs(t, product.Validate(), &validationErrors) {
+		assert.Equal(t, "currency", validationErrors[0].Field())
+		assert.Equal(t, "currency", validationErrors[0].Tag())
+	}
+}
+
+func TestProductDTO_JSONPriceFormats(t *testing.T) {
+	defer SetPricing(pricing)
+
+	product := ProductDTO{ID: 1, Title: "TV", Price: money.MustParseDecimal("1999.9"), Currency: "BRL"}
+	tests := []struct {
+		format money.Format
+		price  string
+	}{
+		{format: money.FormatNumber, price: `1999.90`},
+		{format: money.FormatString, price: `"1999.90"`},
+		{format: money.FormatMinorUnits, price: `199990`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			SetPricing(Pricing{Format: tt.format, DefaultCurrency: "BRL"})
+
+			data, err := json.Marshal(product)
+			assert.NoError(t, err, "Error should not be returned")
+			assert.Contains(t, string(data), `"price":`+tt.price)
+			assert.Contains(t, string(data), `"currency":"BRL"`)
+
+			var decoded ProductDTO
+			assert.NoError(t, json.Unmarshal(data, &decoded), "Error should not be returned")
+			assert.True(t, product.Price.Equal(decoded.Price))
+			assert.Equal(t, "TV", decoded.Title)
+		})
+	}
+}
+
+func TestProductUpdateDTO_UnmarshalMinorUnitsInCurrency(t *testing.T) {
+	defer SetPricing(pricing)
+	SetPricing(Pricing{Format: money.FormatMinorUnits, DefaultCurrency: "BRL"})
+
+	var product ProductUpdateDTO
+	assert.NoError(t, json.Unmarshal([]byte(`{"title":"TV","price":1500,"currency":"CLP"}`), &product))
+	assert.Equal(t, "1500", product.Price.String())
+
+	var withoutCurrency ProductUpdateDTO
+	assert.NoError(t, json.Unmarshal([]byte(`{"title":"TV","price":1500}`), &withoutCurrency))
+	assert.Equal(t, "15.00", withoutCurrency.Price.String())
+}
+
+func TestProductDTO_MarshalLowestPrice(t *testing.T) {
+	defer SetPricing(pricing)
+	SetPricing(Pricing{Format: money.FormatMinorUnits, DefaultCurrency: "BRL"})
+
+	product := ProductDTO{ID: 1, Title: "TV", Price: money.MustParseDecimal("1999.9"), Currency: "BRL"}
+	data, err := json.Marshal(product)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NotContains(t, string(data), "lowest_price_30_days")
+
+	lowest := money.MustParseDecimal("1899.9")
+	product.LowestPrice30Days = &lowest
+	data, err = json.Marshal(product)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Contains(t, string(data), `"lowest_price_30_days":189990`)
+}
+
+func TestProductDTO_ValidatePublicationWindow(t *testing.T) {
+	publishAt := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	unpublishAt := publishAt.Add(24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		publishAt   *time.Time
+		unpublishAt *time.Time
+		wantErr     bool
+	}{
+		{name: "open window"},
+		{name: "only publish_at", publishAt: &publishAt},
+		{name: "only unpublish_at", unpublishAt: &unpublishAt},
+		{name: "ordered window", publishAt: &publishAt, unpublishAt: &unpublishAt},
+		{name: "empty window", publishAt: &publishAt, unpublishAt: &publishAt, wantErr: true},
+		{name: "reversed window", publishAt: &unpublishAt, unpublishAt: &publishAt, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := &ProductDTO{
+				Title:       "Test Product",
+				Description: "Test Description",
+				Price:       money.MustParseDecimal("10.00"),
+				Image:       "test.jpg",
+				Category:    "Test Category",
+				PublishAt:   tt.publishAt,
+				UnpublishAt: tt.unpublishAt,
+			}
+
+			err := product.Validate()
+
+			if !tt.wantErr {
+				assert.NoError(t, err, "Error should not be returned")
+				return
+			}
+			var validationErrors validator.ValidationErrors
+			if assert.ErrorAs(t, err, &validationErrors) {
+				assert.Len(t, validationErrors, 1)
+				assert.Equal(t, "unpublish_at", validationErrors[0].Field())
+				assert.Equal(t, "gt", validationErrors[0].Tag())
+			}
+		})
+	}
+}
+
+func TestProductUpdateDTO_ValidatePublicationWindow(t *testing.T) {
+	publishAt := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	unpublishAt := publishAt.Add(-time.Hour)
+	product := &ProductUpdateDTO{
+		Title:        "Test Product",
+		Description:  "Test Description",
+		Price:        money.MustParseDecimal("10.00"),
+		Image:        "test.jpg",
+		CategoryName: "Test Category",
+		PublishAt:    &publishAt,
+		UnpublishAt:  &unpublishAt,
+	}
+
+	err := product.Validate()
+
+	var validationErrors validator.ValidationErrors
+	if assert.ErrorAs(t, err, &validationErrors) {
+		assert.Len(t, validationErrors, 1)
+		assert.Equal(t, "unpublish_at", validationErrors[0].Field())
+	}
+}
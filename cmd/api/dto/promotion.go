@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+)
+
+// PromotionDTO is a time-boxed discount. Its amounts are always decimal
+// strings, whatever the configured price format.
+type PromotionDTO struct {
+	ID          int64          `json:"id,omitempty"`
+	Name        string         `json:"name" validate:"required,max=255"`
+	Type        string         `json:"type" validate:"required,oneof=percentage fixed" example:"percentage"`
+	Value       money.Decimal  `json:"value" validate:"gt=0" swaggertype:"string" example:"10"`
+	Currency    string         `json:"currency,omitempty" validate:"omitempty,currency" example:"BRL"`
+	ProductIDs  []int64        `json:"product_ids,omitempty" validate:"unique,dive,gt=0"`
+	CategoryIDs []int64        `json:"category_ids,omitempty" validate:"unique,dive,gt=0"`
+	MinPrice    *money.Decimal `json:"min_price,omitempty" validate:"omitempty,gte=0" swaggertype:"string" example:"100.00"`
+	MaxPrice    *money.Decimal `json:"max_price,omitempty" validate:"omitempty,gte=0" swaggertype:"string"`
+	Priority    int            `json:"priority"`
+	Stackable   bool           `json:"stackable"`
+	StartsAt    time.Time      `json:"starts_at" validate:"required"`
+	EndsAt      time.Time      `json:"ends_at" validate:"required"`
+	Active      bool           `json:"active"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+func (p *PromotionDTO) Validate() error {
+	return validate.Struct(p)
+}
+
+// validatePromotion checks the rules between fields of a promotion: fixed
+// discounts and price ranges are in a currency, percentages go up to 100 and
+// both the time box and the price range must not be empty.
+func validatePromotion(sl validator.StructLevel) {
+	p := sl.Current().Interface().(PromotionDTO)
+
+	if p.Type == "percentage" && p.Value.Cmp(money.NewDecimal(100, 0)) > 0 {
+		sl.ReportError(p.Value, "value", "Value", "lte", "100")
+	}
+	if p.Currency == "" && (p.Type == "fixed" || p.MinPrice != nil || p.MaxPrice != nil) {
+		sl.ReportError(p.Currency, "currency", "Currency", "required", "")
+	}
+	if p.MinPrice != nil && p.MaxPrice != nil && p.MaxPrice.Cmp(*p.MinPrice) < 0 {
+		sl.ReportError(p.MaxPrice, "max_price", "MaxPrice", "gte", "min_price")
+	}
+	if !p.StartsAt.IsZero() && !p.EndsAt.After(p.StartsAt) {
+		sl.ReportError(p.EndsAt, "ends_at", "EndsAt", "gt", "starts_at")
+	}
+
+	currency, ok := money.LookupCurrency(p.Currency)
+	if !ok {
+		return
+	}
+	digits := strconv.Itoa(int(currency.Digits))
+	if p.Type == "fixed" && !currency.Fits(p.Value) {
+		sl.ReportError(p.Value, "value", "Value", "precision", digits)
+	}
+	if p.MinPrice != nil && !currency.Fits(*p.MinPrice) {
+		sl.ReportError(p.MinPrice, "min_price", "MinPrice", "precision", digits)
+	}
+	if p.MaxPrice != nil && !currency.Fits(*p.MaxPrice) {
+		sl.ReportError(p.MaxPrice, "max_price", "MaxPrice", "precision", digits)
+	}
+}
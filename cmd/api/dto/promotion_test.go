@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func validPromotion() PromotionDTO {
+	start := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	return PromotionDTO{
+		Name:        "Black Friday",
+		Type:        "percentage",
+		Value:       money.MustParseDecimal("10"),
+		CategoryIDs: []int64{1},
+		StartsAt:    start,
+		EndsAt:      start.Add(72 * time.Hour),
+	}
+}
+
+func decimalPointer(s string) *money.Decimal {
+	d := money.MustParseDecimal(s)
+	return &d
+}
+
+func TestPromotionDTO_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *PromotionDTO)
+		field  string
+		tag    string
+	}{
+		{name: "valid", modify: func(p *PromotionDTO) {}},
+		{name: "valid fixed", modify: func(p *PromotionDTO) { p.Type, p.Value, p.Currency = "fixed", money.MustParseDecimal("5.90"), "BRL" }},
+		{name: "valid price range", modify: func(p *PromotionDTO) {
+			p.Currency, p.MinPrice, p.MaxPrice = "BRL", decimalPointer("10"), decimalPointer("10")
+		}},
+		{name: "unknown type", modify: func(p *PromotionDTO) { p.Type = "bogo" }, field: "type", tag: "oneof"},
+		{name: "zero value", modify: func(p *PromotionDTO) { p.Value = money.Decimal{} }, field: "value", tag: "gt"},
+		{name: "percentage over 100", modify: func(p *PromotionDTO) { p.Value = money.MustParseDecimal("100.01") }, field: "value", tag: "lte"},
+		{name: "fixed without currency", modify: func(p *PromotionDTO) { p.Type = "fixed" }, field: "currency", tag: "required"},
+		{name: "fixed precision", modify: func(p *PromotionDTO) { p.Type, p.Value, p.Currency = "fixed", money.MustParseDecimal("5.5"), "CLP" }, field: "value", tag: "precision"},
+		{name: "price range without currency", modify: func(p *PromotionDTO) { p.MinPrice = decimalPointer("10") }, field: "currency", tag: "required"},
+		{name: "empty price range", modify: func(p *PromotionDTO) {
+			p.Currency, p.MinPrice, p.MaxPrice = "BRL", decimalPointer("20"), decimalPointer("10")
+		}, field: "max_price", tag: "gte"},
+		{name: "ends before start", modify: func(p *PromotionDTO) { p.EndsAt = p.StartsAt }, field: "ends_at", tag: "gt"},
+		{name: "duplicate targets", modify: func(p *PromotionDTO) { p.ProductIDs = []int64{1, 1} }, field: "product_ids", tag: "unique"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promotion := validPromotion()
+			tt.modify(&promotion)
+
+			err := promotion.Validate()
+
+			if tt.field == "" {
+				assert.NoError(t, err, "Error should not be returned")
+				return
+			}
+			var validationErrors validator.ValidationErrors
+			if assert.ErrorAs(t, err, &validationErrors) {
+				assert.Len(t, validationErrors, 1)
+				assert.Equal(t, tt.field, validationErrors[0].Field())
+				assert.Equal(t, tt.tag, validationErrors[0].Tag())
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+)
+
+var validate *validator.Validate
+
+func init() {
+	validate = validator.New()
+	validate.RegisterTagNameFunc(jsonFieldName)
+	validate.RegisterCustomTypeFunc(decimalValue, money.Decimal{})
+	_ = validate.RegisterValidation("currency", validateCurrency)
+	_ = validate.RegisterValidation("attribute_name", validateAttributeName)
+	validate.RegisterStructValidation(validateProduct, ProductDTO{}, ProductUpdateDTO{})
+	validate.RegisterStructValidation(validatePricePrecision, ProductPriceDTO{}, ScheduledPriceChangeDTO{})
+	validate.RegisterStructValidation(validatePromotion, PromotionDTO{})
+}
+
+// decimalValue lets numeric rules such as gte=0 compare decimals.
+func decimalValue(field reflect.Value) interface{} {
+	return field.Interface().(money.Decimal).Float64()
+}
+
+// jsonFieldName makes validation errors report fields by the name clients
+// send, e.g. category instead of CategoryName.
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	apiKeyPrefix      = "ck_"
+	apiKeyRandomBytes = 32
+	apiKeyLookupChars = 8
+)
+
+// GenerateAPIKey returns a new random key and its public prefix, used to
+// identify the key without revealing it.
+func GenerateAPIKey() (key string, prefix string, err error) {
+	b := make([]byte, apiKeyRandomBytes)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	key = apiKeyPrefix + hex.EncodeToString(b)
+	return key, key[:len(apiKeyPrefix)+apiKeyLookupChars], nil
+}
+
+// HashAPIKey returns the digest stored in place of the key. A plain SHA-256
+// is enough because keys are random and long, unlike passwords.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
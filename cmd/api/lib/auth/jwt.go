@@ -0,0 +1,254 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+const clockSkew = 30 * time.Second
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token is expired")
+)
+
+type JWTConfig struct {
+	HMACSecret string
+	JWKSPath   string
+	Issuer     string
+	Audience   string
+}
+
+// JWTVerifier checks the signature and the registered claims of bearer
+// tokens. HMAC tokens are verified with the shared secret and RSA tokens with
+// the keys of the JWKS file; any other algorithm is rejected.
+type JWTVerifier struct {
+	hmacSecret []byte
+	rsaKeys    map[string]*rsa.PublicKey
+	issuer     string
+	audience   string
+	now        func() time.Time
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string      `json:"sub"`
+	Issuer    string      `json:"iss"`
+	Audience  interface{} `json:"aud"`
+	ExpiresAt *int64      `json:"exp"`
+	NotBefore *int64      `json:"nbf"`
+	Scope     string      `json:"scope"`
+	Scp       []string    `json:"scp"`
+}
+
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+func NewJWTVerifier(config JWTConfig) (*JWTVerifier, error) {
+	verifier := &JWTVerifier{
+		hmacSecret: []byte(config.HMACSecret),
+		rsaKeys:    map[string]*rsa.PublicKey{},
+		issuer:     config.Issuer,
+		audience:   config.Audience,
+		now:        time.Now,
+	}
+
+	if config.JWKSPath != "" {
+		data, err := os.ReadFile(config.JWKSPath)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read jwks file: %w", err)
+		}
+		if verifier.rsaKeys, err = parseJWKS(data); err != nil {
+			return nil, err
+		}
+	}
+	return verifier, nil
+}
+
+func (v *JWTVerifier) Enabled() bool {
+	return len(v.hmacSecret) > 0 || len(v.rsaKeys) > 0
+}
+
+func (v *JWTVerifier) Verify(token string) (Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Principal{}, ErrInvalidToken
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return Principal{}, ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Principal{}, ErrInvalidToken
+	}
+	if err = v.verifySignature(header, parts[0]+"."+parts[1], signature); err != nil {
+		return Principal{}, err
+	}
+
+	var claims jwtClaims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return Principal{}, ErrInvalidToken
+	}
+	if err = v.validateClaims(claims); err != nil {
+		return Principal{}, err
+	}
+
+	scopes := claims.Scp
+	if claims.Scope != "" {
+		scopes = append(scopes, strings.Fields(claims.Scope)...)
+	}
+	return Principal{Subject: claims.Subject, Method: MethodJWT, Scopes: scopes}, nil
+}
+
+func (v *JWTVerifier) verifySignature(header jwtHeader, signingInput string, signature []byte) error {
+	switch header.Alg {
+	case "HS256", "HS384", "HS512":
+		if len(v.hmacSecret) == 0 {
+			return ErrInvalidToken
+		}
+		mac := hmac.New(hmacHash(header.Alg), v.hmacSecret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return ErrInvalidToken
+		}
+		return nil
+	case "RS256", "RS384", "RS512":
+		key := v.rsaKey(header.Kid)
+		if key == nil {
+			return ErrInvalidToken
+		}
+		hashFunc := rsaHash(header.Alg)
+		h := hashFunc.New()
+		h.Write([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(key, hashFunc, h.Sum(nil), signature); err != nil {
+			return ErrInvalidToken
+		}
+		return nil
+	default:
+		return ErrInvalidToken
+	}
+}
+
+func (v *JWTVerifier) rsaKey(kid string) *rsa.PublicKey {
+	if kid == "" && len(v.rsaKeys) == 1 {
+		for _, key := range v.rsaKeys {
+			return key
+		}
+	}
+	return v.rsaKeys[kid]
+}
+
+func (v *JWTVerifier) validateClaims(claims jwtClaims) error {
+	now := v.now()
+	if claims.ExpiresAt == nil || now.After(time.Unix(*claims.ExpiresAt, 0).Add(clockSkew)) {
+		return ErrExpiredToken
+	}
+	if claims.NotBefore != nil && now.Add(clockSkew).Before(time.Unix(*claims.NotBefore, 0)) {
+		return ErrInvalidToken
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return ErrInvalidToken
+	}
+	if v.audience != "" && !hasAudience(claims.Audience, v.audience) {
+		return ErrInvalidToken
+	}
+	if claims.Subject == "" {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func hasAudience(audience interface{}, expected string) bool {
+	switch aud := audience.(type) {
+	case string:
+		return aud == expected
+	case []interface{}:
+		for _, a := range aud {
+			if a == expected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hmacHash(alg string) func() hash.Hash {
+	switch alg {
+	case "HS384":
+		return sha512.New384
+	case "HS512":
+		return sha512.New
+	default:
+		return sha256.New
+	}
+}
+
+func rsaHash(alg string) crypto.Hash {
+	switch alg {
+	case "RS384":
+		return crypto.SHA384
+	case "RS512":
+		return crypto.SHA512
+	default:
+		return crypto.SHA256
+	}
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func parseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("fail to parse jwks file: %w", err)
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus for key %s: %w", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent for key %s: %w", key.Kid, err)
+		}
+		keys[key.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	data, err := json.Marshal(v)
+	assert.NoError(t, err, "Error should not be returned")
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signHS256(t *testing.T, secret string, claims map[string]interface{}) string {
+	input := encodeSegment(t, map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	input := encodeSegment(t, map[string]string{"alg": "RS256", "kid": kid}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(input))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	assert.NoError(t, err, "Error should not be returned")
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"sub":   "integration-1",
+		"iss":   "catalog",
+		"aud":   []string{"catalog-api"},
+		"exp":   testNow.Add(time.Hour).Unix(),
+		"scope": "catalog:read catalog:write",
+	}
+}
+
+func newHMACVerifier(t *testing.T) *JWTVerifier {
+	verifier, err := NewJWTVerifier(JWTConfig{HMACSecret: "secret", Issuer: "catalog", Audience: "catalog-api"})
+	assert.NoError(t, err, "Error should not be returned")
+	verifier.now = func() time.Time { return testNow }
+	return verifier
+}
+
+func TestVerify_HS256(t *testing.T) {
+	verifier := newHMACVerifier(t)
+
+	principal, err := verifier.Verify(signHS256(t, "secret", validClaims()))
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, Principal{Subject: "integration-1", Method: MethodJWT, Scopes: []string{"catalog:read", "catalog:write"}}, principal)
+}
+
+func TestVerify_WithInvalidTokens(t *testing.T) {
+	verifier := newHMACVerifier(t)
+	expired := validClaims()
+	expired["exp"] = testNow.Add(-time.Hour).Unix()
+	otherAudience := validClaims()
+	otherAudience["aud"] = "other-api"
+	unsigned := encodeSegment(t, map[string]string{"alg": "none"}) + "." + encodeSegment(t, validClaims()) + "."
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{name: "wrong secret", token: signHS256(t, "other", validClaims()), want: ErrInvalidToken},
+		{name: "expired", token: signHS256(t, "secret", expired), want: ErrExpiredToken},
+		{name: "other audience", token: signHS256(t, "secret", otherAudience), want: ErrInvalidToken},
+		{name: "alg none", token: unsigned, want: ErrInvalidToken},
+		{name: "malformed", token: "not-a-jwt", want: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(tt.token)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestVerify_RS256WithJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err, "Error should not be returned")
+
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	jwksFile := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"k1","n":"%s","e":"%s"}]}`,
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()))
+	assert.NoError(t, os.WriteFile(jwksPath, []byte(jwksFile), 0o600))
+
+	verifier, err := NewJWTVerifier(JWTConfig{JWKSPath: jwksPath})
+	assert.NoError(t, err, "Error should not be returned")
+	verifier.now = func() time.Time { return testNow }
+
+	principal, err := verifier.Verify(signRS256(t, key, "k1", validClaims()))
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, "integration-1", principal.Subject)
+
+	_, err = verifier.Verify(signRS256(t, key, "unknown", validClaims()))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = verifier.Verify(signHS256(t, "secret", validClaims()))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestHashAPIKey(t *testing.T) {
+	key, prefix, err := GenerateAPIKey()
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, key[:len(prefix)], prefix)
+	assert.Len(t, HashAPIKey(key), sha256.Size*2)
+	assert.Equal(t, HashAPIKey(key), HashAPIKey(key))
+	assert.NotEqual(t, HashAPIKey(key), HashAPIKey(key+"x"))
+}
+
+func TestPrincipal_HasScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		granted []string
+		scope   string
+		want    bool
+	}{
+		{name: "granted directly", granted: []string{ScopeCatalogRead}, scope: ScopeCatalogRead, want: true},
+		{name: "read does not grant write", granted: []string{ScopeCatalogRead}, scope: ScopeCatalogWrite, want: false},
+		{name: "write implies read", granted: []string{ScopeCatalogWrite}, scope: ScopeCatalogRead, want: true},
+		{name: "admin implies write", granted: []string{ScopeCatalogAdmin}, scope: ScopeCatalogWrite, want: true},
+		{name: "write does not grant admin", granted: []string{ScopeCatalogWrite}, scope: ScopeCatalogAdmin, want: false},
+		{name: "no scopes", granted: nil, scope: ScopeCatalogRead, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Principal{Scopes: tt.granted}.HasScope(tt.scope))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package auth
+
+import "context"
+
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Subject string
+	Method  string
+	Scopes  []string
+}
+
+// HasScope reports whether scope was granted, directly or implied by a
+// broader scope.
+func (p Principal) HasScope(scope string) bool {
+	for _, granted := range p.Scopes {
+		if granted == scope {
+			return true
+		}
+		for _, implied := range impliedScopes[granted] {
+			if implied == scope {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(contextKey{}).(Principal)
+	return principal, ok
+}
+
+// Credentials are the raw secrets presented by the caller.
+type Credentials struct {
+	BearerToken string
+	APIKey      string
+}
@@ -0,0 +1,14 @@
+package auth
+
+const (
+	ScopeCatalogRead  = "catalog:read"
+	ScopeCatalogWrite = "catalog:write"
+	ScopeCatalogAdmin = "catalog:admin"
+)
+
+// impliedScopes lists the scopes granted along with a broader one, so an
+// admin integration does not need to be given every scope explicitly.
+var impliedScopes = map[string][]string{
+	ScopeCatalogWrite: {ScopeCatalogRead},
+	ScopeCatalogAdmin: {ScopeCatalogWrite, ScopeCatalogRead},
+}
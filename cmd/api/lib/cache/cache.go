@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/logging"
+	"github.com/mercadolibre/fury_go-core/pkg/log"
+)
+
+// Cache is a byte oriented key/value store with per-key TTL. It mirrors the
+// GET/SET EX/DEL subset of Redis so a shared backend can replace the
+// in-process LRU without touching the callers.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key. A zero ttl means the key never expires.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Namespace groups keys that can be invalidated at once. Every key embeds the
+// namespace version, so bumping the version orphans the old entries and lets
+// them expire by TTL. Versions are kept in process rather than in the cache,
+// where they could be evicted and fall back to an older version whose
+// entries are still cached.
+type Namespace struct {
+	name    string
+	version *atomic.Int64
+}
+
+type namespaceID struct {
+	cache Cache
+	name  string
+}
+
+// versions holds the version of every namespace by cache and name, so the
+// namespaces created by different decorators with the same name share it.
+var versions sync.Map
+
+func NewNamespace(cache Cache, name string) Namespace {
+	version, _ := versions.LoadOrStore(namespaceID{cache: cache, name: name}, new(atomic.Int64))
+	return Namespace{name: name, version: version.(*atomic.Int64)}
+}
+
+func (n Namespace) Key(_ context.Context, suffix string) string {
+	return n.name + ":v" + strconv.FormatInt(n.version.Load(), 10) + ":" + suffix
+}
+
+func (n Namespace) Invalidate(_ context.Context) {
+	n.version.Add(1)
+}
+
+// loadTimeout bounds a shared load, which no longer follows the deadline of
+// the caller that started it.
+const loadTimeout = 10 * time.Second
+
+// GetOrLoad reads suffix from namespace and, on a miss, calls load once per
+// key across concurrent callers and stores its result for ttl. Cache failures
+// are logged and degrade to calling load.
+func GetOrLoad[T any](ctx context.Context, c Cache, group *Group, namespace Namespace, suffix string, ttl time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	return GetOrLoadWithTTL(ctx, c, group, namespace, suffix, func(T) time.Duration { return ttl }, load)
+}
+
+// GetOrLoadWithTTL is GetOrLoad for values whose ttl depends on the value
+// itself, which ttl is called with once it is loaded.
+//
+// The shared load runs detached from the caller that started it, so its
+// cancellation does not fail the other waiters, and its result is not stored
+// when the namespace was invalidated while it ran.
+func GetOrLoadWithTTL[T any](ctx context.Context, c Cache, group *Group, namespace Namespace, suffix string, ttl func(T) time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	var value T
+	key := namespace.Key(ctx, suffix)
+
+	if data, ok, err := c.Get(ctx, key); err != nil {
+		logging.Warn(ctx, "fail to read from cache", log.String("key", key), log.Err(err))
+	} else if ok {
+		if err = json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+		logging.Warn(ctx, "fail to decode cached value", log.String("key", key), log.Err(err))
+	}
+
+	type outcome struct {
+		value interface{}
+		err   error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := group.Do(key, func() (interface{}, error) {
+			loadCtx, cancel := context.WithTimeout(detachedContext{ctx}, loadTimeout)
+			defer cancel()
+
+			loaded, err := load(loadCtx)
+			if err != nil {
+				return loaded, err
+			}
+			if namespace.Key(loadCtx, suffix) != key {
+				return loaded, nil
+			}
+			if data, err := json.Marshal(loaded); err == nil {
+				if err = c.Set(loadCtx, key, data, ttl(loaded)); err != nil {
+					logging.Warn(loadCtx, "fail to write to cache", log.String("key", key), log.Err(err))
+				}
+			}
+			return loaded, nil
+		})
+		done <- outcome{value: result, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return value, ctx.Err()
+	case result := <-done:
+		if result.err != nil {
+			return value, result.err
+		}
+		return result.value.(T), nil
+	}
+}
+
+// detachedContext keeps the values of its parent, such as the request id, but
+// not its deadline or cancellation.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+// Delete removes keys, logging instead of failing the caller: the entries
+// expire by TTL anyway.
+func Delete(ctx context.Context, c Cache, keys ...string) {
+	if err := c.Delete(ctx, keys...); err != nil {
+		logging.Warn(ctx, "fail to delete from cache", log.Err(err))
+	}
+}
@@ -0,0 +1,201 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestGetOrLoad_ReadsThrough(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(10)
+	group := &Group{}
+	items := NewNamespace(lru, "item")
+	loads := 0
+	load := func(context.Context) (item, error) {
+		loads++
+		return item{ID: 1, Name: "test"}, nil
+	}
+
+	first, err := GetOrLoad(ctx, lru, group, items, "1", time.Minute, load)
+	assert.NoError(t, err, "Error should not be returned")
+	second, err := GetOrLoad(ctx, lru, group, items, "1", time.Minute, load)
+	assert.NoError(t, err, "Error should not be returned")
+
+	assert.Equal(t, item{ID: 1, Name: "test"}, first)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, loads)
+}
+
+func TestGetOrLoad_DoesNotCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(10)
+
+	_, err := GetOrLoad(ctx, lru, &Group{}, NewNamespace(lru, "item"), "1", time.Minute, func(context.Context) (item, error) {
+		return item{}, errors.New("not found")
+	})
+
+	assert.Error(t, err, "Error should be returned")
+	assert.Equal(t, 0, lru.Len())
+}
+
+func TestGetOrLoad_LoadsOncePerKeyConcurrently(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(10)
+	group := &Group{}
+	items := NewNamespace(lru, "item")
+	release := make(chan struct{})
+	var loads int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = GetOrLoad(ctx, lru, group, items, "1", time.Minute, func(context.Context) (item, error) {
+				atomic.AddInt32(&loads, 1)
+				<-release
+				return item{ID: 1}, nil
+			})
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+}
+
+func TestNamespace_Invalidate(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(10)
+	namespace := NewNamespace(lru, "items")
+
+	before := namespace.Key(ctx, "1")
+	namespace.Invalidate(ctx)
+	after := namespace.Key(ctx, "1")
+
+	assert.NotEqual(t, before, after)
+}
+
+func TestNamespace_InvalidateIsSharedByName(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(10)
+	namespace := NewNamespace(lru, "items")
+
+	before := namespace.Key(ctx, "1")
+	NewNamespace(lru, "items").Invalidate(ctx)
+
+	assert.NotEqual(t, before, namespace.Key(ctx, "1"))
+	assert.Equal(t, "items:v0:1", NewNamespace(NewLRU(10), "items").Key(ctx, "1"))
+}
+
+func TestNamespace_InvalidateSurvivesEviction(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(2)
+	namespace := NewNamespace(lru, "items")
+	stale := namespace.Key(ctx, "1")
+	assert.NoError(t, lru.Set(ctx, stale, []byte(`{"id":1,"name":"stale"}`), time.Minute), "Error should not be returned")
+
+	// Keep the stale entry recently used while filling the cache, so anything
+	// the invalidation wrote to it is evicted first.
+	namespace.Invalidate(ctx)
+	_, _, _ = lru.Get(ctx, stale)
+	assert.NoError(t, lru.Set(ctx, "other", []byte("{}"), time.Minute), "Error should not be returned")
+
+	loaded, err := GetOrLoad(ctx, lru, &Group{}, namespace, "1", time.Minute, func(context.Context) (item, error) {
+		return item{ID: 1, Name: "fresh"}, nil
+	})
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, item{ID: 1, Name: "fresh"}, loaded)
+}
+
+func TestGetOrLoadWithTTL_UsesTTLOfValue(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(10)
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	lru.now = func() time.Time { return now }
+	ttl := func(value item) time.Duration {
+		if value.Name == "expiring" {
+			return time.Second
+		}
+		return time.Hour
+	}
+
+	items := NewNamespace(lru, "item")
+	_, err := GetOrLoadWithTTL(ctx, lru, &Group{}, items, "1", ttl, func(context.Context) (item, error) {
+		return item{ID: 1, Name: "expiring"}, nil
+	})
+	assert.NoError(t, err, "Error should not be returned")
+
+	now = now.Add(2 * time.Second)
+	_, ok, err := lru.Get(ctx, items.Key(ctx, "1"))
+	assert.NoError(t, err, "Error should not be returned")
+	assert.False(t, ok)
+}
+
+func TestGetOrLoad_CancelledCallerDoesNotFailWaiters(t *testing.T) {
+	lru := NewLRU(10)
+	group := &Group{}
+	items := NewNamespace(lru, "item")
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := func(ctx context.Context) (item, error) {
+		close(started)
+		select {
+		case <-release:
+			return item{ID: 1}, nil
+		case <-ctx.Done():
+			return item{}, ctx.Err()
+		}
+	}
+
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := GetOrLoad(firstCtx, lru, group, items, "1", time.Minute, load)
+		firstErr <- err
+	}()
+	<-started
+
+	second := make(chan item, 1)
+	go func() {
+		loaded, err := GetOrLoad(context.Background(), lru, group, items, "1", time.Minute, load)
+		assert.NoError(t, err, "Error should not be returned")
+		second <- loaded
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+	close(release)
+
+	assert.Equal(t, item{ID: 1}, <-second)
+}
+
+func TestGetOrLoad_DoesNotStoreAfterInvalidation(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(10)
+	items := NewNamespace(lru, "item")
+	key := items.Key(ctx, "1")
+
+	loaded, err := GetOrLoad(ctx, lru, &Group{}, items, "1", time.Minute, func(ctx context.Context) (item, error) {
+		items.Invalidate(ctx)
+		return item{ID: 1, Name: "stale"}, nil
+	})
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, item{ID: 1, Name: "stale"}, loaded)
+	assert.Equal(t, 0, lru.Len())
+	assert.NotEqual(t, key, items.Key(ctx, "1"))
+}
@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(2)
+
+	_ = lru.Set(ctx, "a", []byte("1"), 0)
+	_ = lru.Set(ctx, "b", []byte("2"), 0)
+	_, _, _ = lru.Get(ctx, "a")
+	_ = lru.Set(ctx, "c", []byte("3"), 0)
+
+	_, ok, _ := lru.Get(ctx, "b")
+	assert.False(t, ok, "b should have been evicted")
+	value, ok, _ := lru.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+	assert.Equal(t, 2, lru.Len())
+}
+
+func TestLRU_ExpiresByTTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	lru := NewLRU(10)
+	lru.now = func() time.Time { return now }
+
+	_ = lru.Set(ctx, "a", []byte("1"), time.Minute)
+
+	_, ok, _ := lru.Get(ctx, "a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok, _ = lru.Get(ctx, "a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, lru.Len())
+}
+
+func TestLRU_Delete(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(10)
+
+	_ = lru.Set(ctx, "a", []byte("1"), 0)
+	_ = lru.Delete(ctx, "a", "missing")
+
+	_, ok, _ := lru.Get(ctx, "a")
+	assert.False(t, ok)
+}
@@ -449,3 +449,15 @@ func Test_apiErr_Message(t *testing.T) {
 		})
 	}
 }
+
+func TestWithRequestID(t *testing.T) {
+	err := NewBadRequestAPIError("message")
+
+	got := WithRequestID(err, "abc")
+
+	assert.Equal(t, "abc", got.RequestID())
+	assert.Equal(t, err.Message(), got.Message())
+	assert.Equal(t, err.Code(), got.Code())
+	assert.Equal(t, err.Status(), got.Status())
+	assert.Equal(t, "", err.RequestID())
+}
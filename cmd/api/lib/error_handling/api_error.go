@@ -12,14 +12,16 @@ type ApiError interface {
 	Code() string
 	Status() int
 	Cause() CauseList
+	RequestID() string
 	Error() string
 }
 
 type apiErr struct {
-	ErrorMessage string    `json:"message"`
-	ErrorCode    string    `json:"error"`
-	ErrorStatus  int       `json:"status"`
-	ErrorCause   CauseList `json:"cause"`
+	ErrorMessage   string    `json:"message"`
+	ErrorCode      string    `json:"error"`
+	ErrorStatus    int       `json:"status"`
+	ErrorCause     CauseList `json:"cause"`
+	ErrorRequestID string    `json:"request_id,omitempty"`
 } // @name ApiError
 
 func (c CauseList) ToString() string {
@@ -46,28 +48,37 @@ func (e apiErr) Message() string {
 	return e.ErrorMessage
 }
 
+func (e apiErr) RequestID() string {
+	return e.ErrorRequestID
+}
+
+// WithRequestID returns a copy of err that reports requestID in its payload.
+func WithRequestID(err ApiError, requestID string) ApiError {
+	return apiErr{err.Message(), err.Code(), err.Status(), err.Cause(), requestID}
+}
+
 func NewAPIError(message string, error string, status int, cause CauseList) ApiError {
-	return apiErr{message, error, status, cause}
+	return apiErr{message, error, status, cause, ""}
 }
 
 func NewNotFoundAPIError(message string) ApiError {
-	return apiErr{message, "not_found", http.StatusNotFound, CauseList{}}
+	return apiErr{message, "not_found", http.StatusNotFound, CauseList{}, ""}
 }
 
 func NewTooManyRequestsError(message string) ApiError {
-	return apiErr{message, "too_many_requests", http.StatusTooManyRequests, CauseList{}}
+	return apiErr{message, "too_many_requests", http.StatusTooManyRequests, CauseList{}, ""}
 }
 
 func NewBadRequestAPIError(message string) ApiError {
-	return apiErr{message, "bad_request", http.StatusBadRequest, CauseList{}}
+	return apiErr{message, "bad_request", http.StatusBadRequest, CauseList{}, ""}
 }
 
 func NewValidationAPIError(message string, error string, cause CauseList) ApiError {
-	return apiErr{message, error, http.StatusBadRequest, cause}
+	return apiErr{message, error, http.StatusBadRequest, cause, ""}
 }
 
 func NewMethodNotAllowedAPIError() ApiError {
-	return apiErr{"Method not allowed", "method_not_allowed", http.StatusMethodNotAllowed, CauseList{}}
+	return apiErr{"Method not allowed", "method_not_allowed", http.StatusMethodNotAllowed, CauseList{}, ""}
 }
 
 func NewInternalServerAPIError(message string, err error) ApiError {
@@ -75,17 +86,32 @@ func NewInternalServerAPIError(message string, err error) ApiError {
 	if err != nil {
 		cause = append(cause, err.Error())
 	}
-	return apiErr{message, "internal_server_error", http.StatusInternalServerError, cause}
+	return apiErr{message, "internal_server_error", http.StatusInternalServerError, cause, ""}
 }
 
 func NewForbiddenAPIError(message string) ApiError {
-	return apiErr{message, "forbidden", http.StatusForbidden, CauseList{}}
+	return apiErr{message, "forbidden", http.StatusForbidden, CauseList{}, ""}
 }
 
 func NewUnauthorizedAPIError(message string) ApiError {
-	return apiErr{message, "unauthorized_scopes", http.StatusUnauthorized, CauseList{}}
+	return apiErr{message, "unauthorized_scopes", http.StatusUnauthorized, CauseList{}, ""}
 }
 
 func NewConflictAPIError(id string) ApiError {
-	return apiErr{"Can't update " + id + " due to a conflict error", "conflict_error", http.StatusConflict, CauseList{}}
+	return apiErr{"Can't update " + id + " due to a conflict error", "conflict_error", http.StatusConflict, CauseList{}, ""}
+}
+
+// Localize returns a copy of err whose message and field error messages went
+// through translate. Codes are left untouched so clients can keep matching on
+// them whatever the language.
+func Localize(err ApiError, translate func(string) string) ApiError {
+	cause := make(CauseList, 0, len(err.Cause()))
+	for _, c := range err.Cause() {
+		if fieldError, ok := c.(FieldError); ok {
+			fieldError.Message = translate(fieldError.Message)
+			c = fieldError
+		}
+		cause = append(cause, c)
+	}
+	return apiErr{translate(err.Message()), err.Code(), err.Status(), cause, err.RequestID()}
 }
@@ -12,6 +12,17 @@ type customError interface {
 	Handle() (ApiError, log.Level)
 }
 
+// Handle returns the ApiError and log level of err when it is one of the
+// errors of this package.
+func Handle(err error) (ApiError, log.Level, bool) {
+	custom, ok := err.(customError)
+	if !ok {
+		return nil, log.ErrorLevel, false
+	}
+	apiErr, level := custom.Handle()
+	return apiErr, level, true
+}
+
 type RequestError struct {
 	message string
 }
@@ -37,21 +48,31 @@ const (
 	internalErrorType    = "InternalError"
 )
 
+// sqlError keeps the driver error for logs only: Handle reports message alone
+// so SQL text never reaches clients.
 type sqlError struct {
 	message string
 	method  string
 	errType string
+	err     error
 }
 
 func (se *sqlError) Error() string {
+	if se.err != nil {
+		return fmt.Sprintf("Sql error in method [%s]: %s. %s.", se.method, se.message, se.err.Error())
+	}
 	return fmt.Sprintf("Sql error in method [%s]: %s.", se.method, se.message)
 }
 
+func (se *sqlError) Unwrap() error {
+	return se.err
+}
+
 func (se *sqlError) Handle() (ApiError, log.Level) {
 	if se.errType == notFoundSQLErrorType {
 		return NewNotFoundAPIError(se.message), log.WarnLevel
 	}
-	return NewInternalServerAPIError(se.message, se), log.ErrorLevel
+	return NewInternalServerAPIError(se.message, nil), log.ErrorLevel
 }
 
 func NewSQLError(err error, message string, method string) error {
@@ -62,5 +83,5 @@ func NewSQLError(err error, message string, method string) error {
 		errType = internalErrorType
 	}
 
-	return &sqlError{message: fmt.Sprintf("%s. %s", message, err.Error()), method: method, errType: errType}
+	return &sqlError{message: message, method: method, errType: errType, err: err}
 }
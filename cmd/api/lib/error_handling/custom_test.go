@@ -2,8 +2,10 @@ package errorhandling
 
 import (
 	"database/sql"
+	"errors"
 	"testing"
 
+	"github.com/mercadolibre/fury_go-core/pkg/log"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -116,7 +118,7 @@ func TestSqlError_Handle(t *testing.T) {
 		{
 			name: "TestSqlError_Handle",
 			se:   &sqlError{message: "message", method: "method", errType: "errType"},
-			want: NewInternalServerAPIError("message", &sqlError{message: "message", method: "method", errType: "errType"}),
+			want: NewInternalServerAPIError("message", nil),
 		},
 		{
 			name: "TestSqlError_Handle_notFoundSQLErrorType",
@@ -156,3 +158,22 @@ func TestNewSqlError(t *testing.T) {
 		})
 	}
 }
+
+func TestNewSqlError_HandleHidesCause(t *testing.T) {
+	err := NewSQLError(errors.New("Error 1054: Unknown column 'titel' in 'field list'"), "fail to get product", "FindByID")
+
+	apiErr, level, ok := Handle(err)
+
+	assert.True(t, ok)
+	assert.Equal(t, log.ErrorLevel, level)
+	assert.Equal(t, "fail to get product", apiErr.Message())
+	assert.Empty(t, apiErr.Cause())
+	assert.NotContains(t, apiErr.Error(), "Unknown column")
+	assert.Contains(t, err.Error(), "Unknown column")
+}
+
+func TestHandle_OtherErrors(t *testing.T) {
+	_, _, ok := Handle(errors.New("other"))
+
+	assert.False(t, ok)
+}
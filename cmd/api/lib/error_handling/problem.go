@@ -0,0 +1,84 @@
+package errorhandling
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	jsonContentType    = "application/json"
+
+	// problemTypePrefix makes the type of a problem a URI reference relative
+	// to the API, one per error code.
+	problemTypePrefix = "/problems/"
+)
+
+// FieldError describes why one field of the request was rejected. Causes of
+// this type are listed in the errors member of problem documents.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Problem is an RFC 7807 problem details document.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+} // @name Problem
+
+// NewProblem describes err as a problem that occurred on instance, usually
+// the path of the request.
+func NewProblem(err ApiError, instance string) Problem {
+	problem := Problem{
+		Type:      ProblemType(err.Code()),
+		Title:     http.StatusText(err.Status()),
+		Status:    err.Status(),
+		Detail:    err.Message(),
+		Instance:  instance,
+		RequestID: err.RequestID(),
+	}
+	for _, cause := range err.Cause() {
+		if fieldError, ok := cause.(FieldError); ok {
+			problem.Errors = append(problem.Errors, fieldError)
+		}
+	}
+	return problem
+}
+
+func ProblemType(code string) string {
+	return problemTypePrefix + code
+}
+
+// AcceptsProblem reports whether the Accept header prefers problem documents
+// over plain JSON.
+func AcceptsProblem(accept string) bool {
+	problemQuality, jsonQuality := 0.0, 0.0
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if value, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case ProblemContentType:
+			problemQuality = quality
+		case jsonContentType:
+			jsonQuality = quality
+		}
+	}
+	return problemQuality > 0 && problemQuality >= jsonQuality
+}
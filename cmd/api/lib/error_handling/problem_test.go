@@ -0,0 +1,46 @@
+package errorhandling
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProblem(t *testing.T) {
+	fieldError := FieldError{Field: "price", Rule: "gt", Message: "price must be greater than 0"}
+	err := WithRequestID(NewValidationAPIError("invalid product", "validation_error", CauseList{fieldError, "ignored"}), "abc")
+
+	got := NewProblem(err, "/product")
+
+	assert.Equal(t, Problem{
+		Type:      "/problems/validation_error",
+		Title:     "Bad Request",
+		Status:    http.StatusBadRequest,
+		Detail:    "invalid product",
+		Instance:  "/product",
+		RequestID: "abc",
+		Errors:    []FieldError{fieldError},
+	}, got)
+}
+
+func TestAcceptsProblem(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{accept: "", want: false},
+		{accept: "application/json", want: false},
+		{accept: "*/*", want: false},
+		{accept: "application/problem+json", want: true},
+		{accept: "application/json, application/problem+json", want: true},
+		{accept: "application/problem+json;q=0.5, application/json", want: false},
+		{accept: "application/problem+json;q=0", want: false},
+		{accept: "text/html, application/problem+json; q=0.9", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			assert.Equal(t, tt.want, AcceptsProblem(tt.accept))
+		})
+	}
+}
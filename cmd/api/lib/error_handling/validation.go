@@ -0,0 +1,71 @@
+package errorhandling
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// NewValidationErrorsAPIError lists every rejected field as a FieldError
+// cause.
+func NewValidationErrorsAPIError(errs validator.ValidationErrors) ApiError {
+	cause := CauseList{}
+	for _, fieldErr := range errs {
+		cause = append(cause, FieldError{
+			Field:   fieldErr.Field(),
+			Rule:    fieldErr.Tag(),
+			Message: fieldMessage(fieldErr),
+		})
+	}
+	return NewValidationAPIError("invalid request body", "validation_error", cause)
+}
+
+func fieldMessage(fieldErr validator.FieldError) string {
+	field, param := fieldErr.Field(), fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, param)
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", field, param)
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s", field, param)
+	case "lte":
+		return fmt.Sprintf("%s must be less than or equal to %s", field, param)
+	case "min":
+		if unit := sizeUnit(fieldErr.Kind()); unit != "" {
+			return fmt.Sprintf("%s must have at least %s %s", field, param, unit)
+		}
+		return fmt.Sprintf("%s must be at least %s", field, param)
+	case "max":
+		if unit := sizeUnit(fieldErr.Kind()); unit != "" {
+			return fmt.Sprintf("%s must have at most %s %s", field, param, unit)
+		}
+		return fmt.Sprintf("%s must be at most %s", field, param)
+	case "unique":
+		return fmt.Sprintf("%s must not have duplicate items", field)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of [%s]", field, param)
+	case "url":
+		return fmt.Sprintf("%s must be a valid URL", field)
+	case "currency":
+		return fmt.Sprintf("%s must be a supported ISO 4217 currency code", field)
+	case "attribute_name":
+		return fmt.Sprintf("%s must be snake_case starting with a letter", field)
+	case "precision":
+		return fmt.Sprintf("%s must have at most %s decimal places", field, param)
+	}
+	return fmt.Sprintf("%s is invalid", field)
+}
+
+func sizeUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	case reflect.String:
+		return "characters"
+	}
+	return ""
+}
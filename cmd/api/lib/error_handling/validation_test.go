@@ -0,0 +1,44 @@
+package errorhandling
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type validationTestDTO struct {
+	Name   string   `validate:"required"`
+	Price  float64  `validate:"gte=0"`
+	Scopes []string `validate:"max=1"`
+	Code   string   `validate:"max=3"`
+	Rating int      `validate:"max=5"`
+	Email  string   `validate:"omitempty,email"`
+}
+
+func TestNewValidationErrorsAPIError(t *testing.T) {
+	err := validator.New().Struct(validationTestDTO{
+		Price:  -1,
+		Scopes: []string{"a", "b"},
+		Code:   "abcd",
+		Rating: 6,
+		Email:  "nope",
+	})
+	var validationErrors validator.ValidationErrors
+	assert.True(t, errors.As(err, &validationErrors))
+
+	apiErr := NewValidationErrorsAPIError(validationErrors)
+
+	assert.Equal(t, http.StatusBadRequest, apiErr.Status())
+	assert.Equal(t, "validation_error", apiErr.Code())
+	assert.Equal(t, CauseList{
+		FieldError{Field: "Name", Rule: "required", Message: "Name is required"},
+		FieldError{Field: "Price", Rule: "gte", Message: "Price must be greater than or equal to 0"},
+		FieldError{Field: "Scopes", Rule: "max", Message: "Scopes must have at most 1 items"},
+		FieldError{Field: "Code", Rule: "max", Message: "Code must have at most 3 characters"},
+		FieldError{Field: "Rating", Rule: "max", Message: "Rating must be at most 5"},
+		FieldError{Field: "Email", Rule: "email", Message: "Email is invalid"},
+	}, apiErr.Cause())
+}
@@ -0,0 +1,8 @@
+package i18n
+
+// catalogs holds the translations of every supported locale but English, by
+// English message.
+var catalogs = map[string]map[string]string{
+	LocalePortuguese: portuguese,
+	LocaleSpanish:    spanish,
+}
@@ -0,0 +1,142 @@
+package i18n
+
+var spanish = map[string]string{
+	// HTTP status titles
+	"Bad Request":           "Solicitud incorrecta",
+	"Unauthorized":          "No autorizado",
+	"Forbidden":             "Prohibido",
+	"Not Found":             "No encontrado",
+	"Method Not Allowed":    "Método no permitido",
+	"Conflict":              "Conflicto",
+	"Unprocessable Entity":  "Entidad no procesable",
+	"Too Many Requests":     "Demasiadas solicitudes",
+	"Internal Server Error": "Error interno del servidor",
+	"Method not allowed":    "Método no permitido",
+
+	// requests
+	"internal server error":             "error interno del servidor",
+	"invalid json body":                 "cuerpo JSON inválido",
+	"invalid request body":              "cuerpo de la solicitud inválido",
+	"fail to read request body":         "error al leer el cuerpo de la solicitud",
+	"invalid product id":                "id de producto inválido",
+	"invalid promotion id":              "id de promoción inválido",
+	"invalid scheduled price change id": "id de cambio de precio programado inválido",
+	"invalid image id":                  "id de imagen inválido",
+	"invalid attribute id":              "id de atributo inválido",
+	"invalid multipart body":            "cuerpo multipart inválido",
+	"file is required":                  "el archivo es obligatorio",
+	"file is not a valid image":         "el archivo no es una imagen válida",
+	"invalid category id":               "id de categoría inválido",
+	"invalid api key id":                "id de clave de API inválido",
+	"category name is required":         "el nombre de la categoría es obligatorio",
+	"invalid or missing credentials":    "credenciales inválidas o ausentes",
+	"fail to authenticate request":      "error al autenticar la solicitud",
+	"missing required scope {0}":        "falta el alcance requerido {0}",
+	"rate limit exceeded, retry later":  "límite de solicitudes excedido, reintente más tarde",
+	"currency parameter value is not a supported ISO 4217 code. currency = {0}":            "el valor del parámetro currency no es un código ISO 4217 soportado. currency = {0}",
+	"from parameter value is not a date or RFC 3339 time. from = {0}":                      "el valor del parámetro from no es una fecha ni una hora RFC 3339. from = {0}",
+	"to parameter value is not a date or RFC 3339 time. to = {0}":                          "el valor del parámetro to no es una fecha ni una hora RFC 3339. to = {0}",
+	"status parameter value must be one of pending applied failed cancelled. status = {0}": "el valor del parámetro status debe ser uno de pending applied failed cancelled. status = {0}",
+	"status parameter value must be one of draft published archived. status = {0}":         "el valor del parámetro status debe ser uno de draft published archived. status = {0}",
+	"product with ID {0} is {1} and cannot be {2}":                                         "el producto con ID {0} está en {1} y no puede pasar a {2}",
+	"effective_at must be in the future":                                                   "effective_at debe estar en el futuro",
+	"file must be at most {0} KB":                                                          "el archivo debe tener como máximo {0} KB",
+	"file type {0} is not supported, upload a JPEG or PNG image":                           "el tipo de archivo {0} no es soportado, suba una imagen JPEG o PNG",
+	"image must be at most {0}x{1} pixels":                                                 "la imagen debe tener como máximo {0}x{1} píxeles",
+	"image_ids must list every image of the product once":                                  "image_ids debe listar cada imagen del producto una sola vez",
+	"scheduled price change with ID {0} is {1} and cannot be cancelled":                    "el cambio de precio programado con ID {0} está {1} y no se puede cancelar",
+	"currency of the body does not match the path":                                         "la moneda del cuerpo no coincide con la de la ruta",
+	"currency {0} is not supported":                                                        "la moneda {0} no es soportada",
+	"no exchange rate from {0} to {1}":                                                     "no hay tipo de cambio de {0} a {1}",
+	"attribute {0} is required":                                                            "el atributo {0} es obligatorio",
+	"attribute {0} is not defined for category {1}":                                        "el atributo {0} no está definido para la categoría {1}",
+	"attribute {0} must be a number":                                                       "el atributo {0} debe ser un número",
+	"attribute {0} must be true or false":                                                  "el atributo {0} debe ser true o false",
+	"attribute {0} must be one of [{1}]":                                                   "el atributo {0} debe ser uno de [{1}]",
+	"attribute {0} must have at most {1} characters":                                       "el atributo {0} debe tener como máximo {1} caracteres",
+	"attribute {0} is boolean and cannot have allowed values":                              "el atributo {0} es booleano y no puede tener valores permitidos",
+	"name and type of attribute {0} cannot change":                                         "el nombre y el tipo del atributo {0} no pueden cambiar",
+	"attribute {0} already exists in category with id {1}":                                 "el atributo {0} ya existe en la categoría con id {1}",
+
+	"limit parameter value is not an integer. limit = {0}":   "el valor del parámetro limit no es un número entero. limit = {0}",
+	"offset parameter value is not an integer. offset = {0}": "el valor del parámetro offset no es un número entero. offset = {0}",
+	"min parameter value is not a decimal number. min = {0}": "el valor del parámetro min no es un número. min = {0}",
+	"max parameter value is not a decimal number. max = {0}": "el valor del parámetro max no es un número. max = {0}",
+
+	// idempotency
+	"Idempotency-Key must have at most 255 characters":                 "Idempotency-Key debe tener como máximo 255 caracteres",
+	"reason must have at most 255 characters":                          "reason debe tener como máximo 255 caracteres",
+	"Idempotency-Key was already used with a different request body":   "Idempotency-Key ya fue usada con un cuerpo de solicitud diferente",
+	"a request with the same Idempotency-Key is still being processed": "una solicitud con la misma Idempotency-Key todavía se está procesando",
+	"idempotency key already in use":                                   "Idempotency-Key ya está en uso",
+	"fail to reserve idempotency key":                                  "error al reservar la Idempotency-Key",
+
+	// not found
+	"product not found":                                       "producto no encontrado",
+	"product with ID {0} not found":                           "producto con ID {0} no encontrado",
+	"product with category ID {0} not found":                  "ningún producto encontrado para la categoría con ID {0}",
+	"category with id {0} not found":                          "categoría con id {0} no encontrada",
+	"category with name {0} not found":                        "categoría con nombre {0} no encontrada",
+	"api key not found":                                       "clave de API no encontrada",
+	"api key with id {0} not found":                           "clave de API con id {0} no encontrada",
+	"active api key with id {0} not found":                    "clave de API activa con id {0} no encontrada",
+	"price in {0} of product with ID {1} not found":           "precio en {0} del producto con ID {1} no encontrado",
+	"exchange rate from {0} to {1} not found":                 "tipo de cambio de {0} a {1} no encontrado",
+	"promotion with ID {0} not found":                         "promoción con ID {0} no encontrada",
+	"scheduled price change with ID {0} not found":            "cambio de precio programado con ID {0} no encontrado",
+	"image with ID {0} of product with ID {1} not found":      "imagen con ID {0} del producto con ID {1} no encontrada",
+	"upload {0} not found":                                    "archivo subido {0} no encontrado",
+	"attribute with ID {0} of category with id {1} not found": "atributo con ID {0} de la categoría con id {1} no encontrado",
+
+	// internal errors
+	"fail to create product":                  "error al crear el producto",
+	"fail to get product":                     "error al obtener el producto",
+	"fail to update product":                  "error al actualizar el producto",
+	"fail to update product status":           "error al actualizar el estado del producto",
+	"fail to delete product":                  "error al eliminar el producto",
+	"fail to count products":                  "error al contar los productos",
+	"fail to get products by category":        "error al obtener los productos de la categoría",
+	"fail to create category":                 "error al crear la categoría",
+	"fail to get category":                    "error al obtener la categoría",
+	"fail to update category":                 "error al actualizar la categoría",
+	"fail to delete category":                 "error al eliminar la categoría",
+	"fail to count categories":                "error al contar las categorías",
+	"fail to save product price":              "error al guardar el precio del producto",
+	"fail to get product prices":              "error al obtener los precios del producto",
+	"fail to delete product price":            "error al eliminar el precio del producto",
+	"fail to get exchange rates":              "error al obtener los tipos de cambio",
+	"fail to create product image":            "error al crear la imagen del producto",
+	"fail to get product images":              "error al obtener las imágenes del producto",
+	"fail to update product image":            "error al actualizar la imagen del producto",
+	"fail to delete product image":            "error al eliminar la imagen del producto",
+	"fail to get product image renditions":    "error al obtener las versiones reducidas de la imagen del producto",
+	"fail to update product image renditions": "error al actualizar las versiones reducidas de la imagen del producto",
+	"fail to store uploaded file":             "error al guardar el archivo subido",
+	"fail to read uploaded file":              "error al leer el archivo subido",
+	"fail to create category attribute":       "error al crear el atributo de la categoría",
+	"fail to get category attributes":         "error al obtener los atributos de la categoría",
+	"fail to update category attribute":       "error al actualizar el atributo de la categoría",
+	"fail to delete category attribute":       "error al eliminar el atributo de la categoría",
+	"fail to get product attributes":          "error al obtener los atributos del producto",
+	"fail to update product attributes":       "error al actualizar los atributos del producto",
+
+	// validation
+	"{0} is required":                                "{0} es obligatorio",
+	"{0} is invalid":                                 "{0} es inválido",
+	"{0} must be greater than {1}":                   "{0} debe ser mayor que {1}",
+	"{0} must be greater than or equal to {1}":       "{0} debe ser mayor o igual a {1}",
+	"{0} must be less than {1}":                      "{0} debe ser menor que {1}",
+	"{0} must be less than or equal to {1}":          "{0} debe ser menor o igual a {1}",
+	"{0} must be at least {1}":                       "{0} debe ser como mínimo {1}",
+	"{0} must be at most {1}":                        "{0} debe ser como máximo {1}",
+	"{0} must have at least {1} characters":          "{0} debe tener al menos {1} caracteres",
+	"{0} must have at most {1} characters":           "{0} debe tener como máximo {1} caracteres",
+	"{0} must have at least {1} items":               "{0} debe tener al menos {1} elementos",
+	"{0} must have at most {1} items":                "{0} debe tener como máximo {1} elementos",
+	"{0} must not have duplicate items":              "{0} no debe tener elementos repetidos",
+	"{0} must be one of [{1}]":                       "{0} debe ser uno de [{1}]",
+	"{0} must be a valid URL":                        "{0} debe ser una URL válida",
+	"{0} must be a supported ISO 4217 currency code": "{0} debe ser un código de moneda ISO 4217 soportado",
+	"{0} must be snake_case starting with a letter":  "{0} debe estar en snake_case y empezar con una letra",
+	"{0} must have at most {1} decimal places":       "{0} debe tener como máximo {1} decimales",
+}
@@ -0,0 +1,142 @@
+package i18n
+
+var portuguese = map[string]string{
+	// HTTP status titles
+	"Bad Request":           "Requisição inválida",
+	"Unauthorized":          "Não autorizado",
+	"Forbidden":             "Proibido",
+	"Not Found":             "Não encontrado",
+	"Method Not Allowed":    "Método não permitido",
+	"Conflict":              "Conflito",
+	"Unprocessable Entity":  "Entidade não processável",
+	"Too Many Requests":     "Muitas requisições",
+	"Internal Server Error": "Erro interno do servidor",
+	"Method not allowed":    "Método não permitido",
+
+	// requests
+	"internal server error":             "erro interno do servidor",
+	"invalid json body":                 "corpo JSON inválido",
+	"invalid request body":              "corpo da requisição inválido",
+	"fail to read request body":         "falha ao ler o corpo da requisição",
+	"invalid product id":                "id de produto inválido",
+	"invalid promotion id":              "id de promoção inválido",
+	"invalid scheduled price change id": "id de alteração de preço agendada inválido",
+	"invalid image id":                  "id de imagem inválido",
+	"invalid attribute id":              "id de atributo inválido",
+	"invalid multipart body":            "corpo multipart inválido",
+	"file is required":                  "o arquivo é obrigatório",
+	"file is not a valid image":         "o arquivo não é uma imagem válida",
+	"invalid category id":               "id de categoria inválido",
+	"invalid api key id":                "id de chave de API inválido",
+	"category name is required":         "o nome da categoria é obrigatório",
+	"invalid or missing credentials":    "credenciais inválidas ou ausentes",
+	"fail to authenticate request":      "falha ao autenticar a requisição",
+	"missing required scope {0}":        "falta o escopo obrigatório {0}",
+	"rate limit exceeded, retry later":  "limite de requisições excedido, tente novamente mais tarde",
+	"currency parameter value is not a supported ISO 4217 code. currency = {0}":            "o valor do parâmetro currency não é um código ISO 4217 suportado. currency = {0}",
+	"from parameter value is not a date or RFC 3339 time. from = {0}":                      "o valor do parâmetro from não é uma data ou um horário RFC 3339. from = {0}",
+	"to parameter value is not a date or RFC 3339 time. to = {0}":                          "o valor do parâmetro to não é uma data ou um horário RFC 3339. to = {0}",
+	"status parameter value must be one of pending applied failed cancelled. status = {0}": "o valor do parâmetro status deve ser um de pending applied failed cancelled. status = {0}",
+	"status parameter value must be one of draft published archived. status = {0}":         "o valor do parâmetro status deve ser um de draft published archived. status = {0}",
+	"product with ID {0} is {1} and cannot be {2}":                                         "o produto com ID {0} está em {1} e não pode passar para {2}",
+	"effective_at must be in the future":                                                   "effective_at deve estar no futuro",
+	"file must be at most {0} KB":                                                          "o arquivo deve ter no máximo {0} KB",
+	"file type {0} is not supported, upload a JPEG or PNG image":                           "o tipo de arquivo {0} não é suportado, envie uma imagem JPEG ou PNG",
+	"image must be at most {0}x{1} pixels":                                                 "a imagem deve ter no máximo {0}x{1} pixels",
+	"image_ids must list every image of the product once":                                  "image_ids deve listar cada imagem do produto uma única vez",
+	"scheduled price change with ID {0} is {1} and cannot be cancelled":                    "a alteração de preço agendada com ID {0} está {1} e não pode ser cancelada",
+	"currency of the body does not match the path":                                         "a moeda do corpo não corresponde à do caminho",
+	"currency {0} is not supported":                                                        "a moeda {0} não é suportada",
+	"no exchange rate from {0} to {1}":                                                     "não há taxa de câmbio de {0} para {1}",
+	"attribute {0} is required":                                                            "o atributo {0} é obrigatório",
+	"attribute {0} is not defined for category {1}":                                        "o atributo {0} não está definido para a categoria {1}",
+	"attribute {0} must be a number":                                                       "o atributo {0} deve ser um número",
+	"attribute {0} must be true or false":                                                  "o atributo {0} deve ser true ou false",
+	"attribute {0} must be one of [{1}]":                                                   "o atributo {0} deve ser um de [{1}]",
+	"attribute {0} must have at most {1} characters":                                       "o atributo {0} deve ter no máximo {1} caracteres",
+	"attribute {0} is boolean and cannot have allowed values":                              "o atributo {0} é booleano e não pode ter valores permitidos",
+	"name and type of attribute {0} cannot change":                                         "o nome e o tipo do atributo {0} não podem mudar",
+	"attribute {0} already exists in category with id {1}":                                 "o atributo {0} já existe na categoria com id {1}",
+
+	"limit parameter value is not an integer. limit = {0}":   "o valor do parâmetro limit não é um número inteiro. limit = {0}",
+	"offset parameter value is not an integer. offset = {0}": "o valor do parâmetro offset não é um número inteiro. offset = {0}",
+	"min parameter value is not a decimal number. min = {0}": "o valor do parâmetro min não é um número. min = {0}",
+	"max parameter value is not a decimal number. max = {0}": "o valor do parâmetro max não é um número. max = {0}",
+
+	// idempotency
+	"Idempotency-Key must have at most 255 characters":                 "Idempotency-Key deve ter no máximo 255 caracteres",
+	"reason must have at most 255 characters":                          "reason deve ter no máximo 255 caracteres",
+	"Idempotency-Key was already used with a different request body":   "Idempotency-Key já foi usada com um corpo de requisição diferente",
+	"a request with the same Idempotency-Key is still being processed": "uma requisição com a mesma Idempotency-Key ainda está sendo processada",
+	"idempotency key already in use":                                   "Idempotency-Key já está em uso",
+	"fail to reserve idempotency key":                                  "falha ao reservar a Idempotency-Key",
+
+	// not found
+	"product not found":                                       "produto não encontrado",
+	"product with ID {0} not found":                           "produto com ID {0} não encontrado",
+	"product with category ID {0} not found":                  "nenhum produto encontrado para a categoria com ID {0}",
+	"category with id {0} not found":                          "categoria com id {0} não encontrada",
+	"category with name {0} not found":                        "categoria com nome {0} não encontrada",
+	"api key not found":                                       "chave de API não encontrada",
+	"api key with id {0} not found":                           "chave de API com id {0} não encontrada",
+	"active api key with id {0} not found":                    "chave de API ativa com id {0} não encontrada",
+	"price in {0} of product with ID {1} not found":           "preço em {0} do produto com ID {1} não encontrado",
+	"exchange rate from {0} to {1} not found":                 "taxa de câmbio de {0} para {1} não encontrada",
+	"promotion with ID {0} not found":                         "promoção com ID {0} não encontrada",
+	"scheduled price change with ID {0} not found":            "alteração de preço agendada com ID {0} não encontrada",
+	"image with ID {0} of product with ID {1} not found":      "imagem com ID {0} do produto com ID {1} não encontrada",
+	"upload {0} not found":                                    "arquivo enviado {0} não encontrado",
+	"attribute with ID {0} of category with id {1} not found": "atributo com ID {0} da categoria com id {1} não encontrado",
+
+	// internal errors
+	"fail to create product":                  "falha ao criar o produto",
+	"fail to get product":                     "falha ao obter o produto",
+	"fail to update product":                  "falha ao atualizar o produto",
+	"fail to update product status":           "falha ao atualizar o status do produto",
+	"fail to delete product":                  "falha ao excluir o produto",
+	"fail to count products":                  "falha ao contar os produtos",
+	"fail to get products by category":        "falha ao obter os produtos da categoria",
+	"fail to create category":                 "falha ao criar a categoria",
+	"fail to get category":                    "falha ao obter a categoria",
+	"fail to update category":                 "falha ao atualizar a categoria",
+	"fail to delete category":                 "falha ao excluir a categoria",
+	"fail to count categories":                "falha ao contar as categorias",
+	"fail to save product price":              "falha ao salvar o preço do produto",
+	"fail to get product prices":              "falha ao obter os preços do produto",
+	"fail to delete product price":            "falha ao excluir o preço do produto",
+	"fail to get exchange rates":              "falha ao obter as taxas de câmbio",
+	"fail to create product image":            "falha ao criar a imagem do produto",
+	"fail to get product images":              "falha ao obter as imagens do produto",
+	"fail to update product image":            "falha ao atualizar a imagem do produto",
+	"fail to delete product image":            "falha ao excluir a imagem do produto",
+	"fail to get product image renditions":    "falha ao obter as versões reduzidas da imagem do produto",
+	"fail to update product image renditions": "falha ao atualizar as versões reduzidas da imagem do produto",
+	"fail to store uploaded file":             "falha ao salvar o arquivo enviado",
+	"fail to read uploaded file":              "falha ao ler o arquivo enviado",
+	"fail to create category attribute":       "falha ao criar o atributo da categoria",
+	"fail to get category attributes":         "falha ao obter os atributos da categoria",
+	"fail to update category attribute":       "falha ao atualizar o atributo da categoria",
+	"fail to delete category attribute":       "falha ao excluir o atributo da categoria",
+	"fail to get product attributes":          "falha ao obter os atributos do produto",
+	"fail to update product attributes":       "falha ao atualizar os atributos do produto",
+
+	// validation
+	"{0} is required":                                "{0} é obrigatório",
+	"{0} is invalid":                                 "{0} é inválido",
+	"{0} must be greater than {1}":                   "{0} deve ser maior que {1}",
+	"{0} must be greater than or equal to {1}":       "{0} deve ser maior ou igual a {1}",
+	"{0} must be less than {1}":                      "{0} deve ser menor que {1}",
+	"{0} must be less than or equal to {1}":          "{0} deve ser menor ou igual a {1}",
+	"{0} must be at least {1}":                       "{0} deve ser no mínimo {1}",
+	"{0} must be at most {1}":                        "{0} deve ser no máximo {1}",
+	"{0} must have at least {1} characters":          "{0} deve ter pelo menos {1} caracteres",
+	"{0} must have at most {1} characters":           "{0} deve ter no máximo {1} caracteres",
+	"{0} must have at least {1} items":               "{0} deve ter pelo menos {1} itens",
+	"{0} must have at most {1} items":                "{0} deve ter no máximo {1} itens",
+	"{0} must not have duplicate items":              "{0} não deve ter itens repetidos",
+	"{0} must be one of [{1}]":                       "{0} deve ser um de [{1}]",
+	"{0} must be a valid URL":                        "{0} deve ser uma URL válida",
+	"{0} must be a supported ISO 4217 currency code": "{0} deve ser um código de moeda ISO 4217 suportado",
+	"{0} must be snake_case starting with a letter":  "{0} deve estar em snake_case e começar com uma letra",
+	"{0} must have at most {1} decimal places":       "{0} deve ter no máximo {1} casas decimais",
+}
@@ -0,0 +1,179 @@
+// Package i18n translates the English messages of the API into the language
+// asked for in Accept-Language.
+//
+// English messages are the catalog keys: dynamic parts are written as {0},
+// {1}... so a message such as "product with ID 7 not found" matches the key
+// "product with ID {0} not found" and its parameters are carried over to the
+// translation. Messages without a catalog entry are kept in English.
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/pt_BR"
+	ut "github.com/go-playground/universal-translator"
+)
+
+const (
+	LocaleEnglish    = "en"
+	LocaleSpanish    = "es"
+	LocalePortuguese = "pt_BR"
+)
+
+// languageLocales resolves languages without a supported region, e.g. pt-PT.
+var languageLocales = map[string]string{
+	"pt": LocalePortuguese,
+}
+
+var (
+	universal *ut.UniversalTranslator
+	templates []template
+)
+
+type template struct {
+	key     string
+	pattern *regexp.Regexp
+	fixed   bool
+	literal int
+}
+
+func init() {
+	english := en.New()
+	universal = ut.New(english, english, es.New(), pt_BR.New())
+
+	keys := map[string]bool{}
+	for locale, catalog := range catalogs {
+		translator, _ := universal.GetTranslator(locale)
+		for key, text := range catalog {
+			if err := translator.Add(key, text, false); err != nil {
+				panic(fmt.Sprintf("invalid %s translation of %q: %s", locale, key, err))
+			}
+			keys[key] = true
+		}
+	}
+	for key := range keys {
+		templates = append(templates, newTemplate(key))
+	}
+	// Fixed messages win over templates that could also match them, then the
+	// template with the most literal text does.
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].fixed != templates[j].fixed {
+			return templates[i].fixed
+		}
+		if templates[i].literal != templates[j].literal {
+			return templates[i].literal > templates[j].literal
+		}
+		return templates[i].key < templates[j].key
+	})
+}
+
+var placeholder = regexp.MustCompile(`\{\d+\}`)
+
+func newTemplate(key string) template {
+	parts := placeholder.Split(key, -1)
+	quoted := make([]string, len(parts))
+	for i, part := range parts {
+		quoted[i] = regexp.QuoteMeta(part)
+	}
+	return template{
+		key:     key,
+		pattern: regexp.MustCompile("^" + strings.Join(quoted, "(.+?)") + "$"),
+		fixed:   len(parts) == 1,
+		literal: len(strings.Join(parts, "")),
+	}
+}
+
+// Negotiate returns the translator of the first supported language of the
+// Accept-Language header, or the English one.
+func Negotiate(acceptLanguage string) ut.Translator {
+	for _, tag := range languageTags(acceptLanguage) {
+		for _, locale := range candidates(tag) {
+			if translator, found := universal.GetTranslator(locale); found {
+				return translator
+			}
+		}
+	}
+	return universal.GetFallback()
+}
+
+// Translate returns message in the language of translator.
+func Translate(translator ut.Translator, message string) string {
+	for _, t := range templates {
+		match := t.pattern.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		translated, err := translator.T(t.key, match[1:]...)
+		if err != nil {
+			return message
+		}
+		return translated
+	}
+	return message
+}
+
+// LanguageTag formats the locale of translator for Content-Language.
+func LanguageTag(translator ut.Translator) string {
+	return strings.ReplaceAll(translator.Locale(), "_", "-")
+}
+
+type weightedTag struct {
+	tag     string
+	quality float64
+}
+
+// languageTags returns the tags of an Accept-Language header by preference.
+func languageTags(acceptLanguage string) []string {
+	var weighted []weightedTag
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				parsed, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					parsed = 0
+				}
+				quality = parsed
+			}
+		}
+		if quality > 0 {
+			weighted = append(weighted, weightedTag{tag: tag, quality: quality})
+		}
+	}
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].quality > weighted[j].quality
+	})
+
+	tags := make([]string, 0, len(weighted))
+	for _, w := range weighted {
+		tags = append(tags, w.tag)
+	}
+	return tags
+}
+
+// candidates lists the locales that can serve tag, e.g. pt_BR, pt for pt-BR.
+func candidates(tag string) []string {
+	subtags := strings.Split(strings.ReplaceAll(tag, "_", "-"), "-")
+	language := strings.ToLower(subtags[0])
+
+	var locales []string
+	if len(subtags) > 1 {
+		locales = append(locales, language+"_"+strings.ToUpper(subtags[len(subtags)-1]))
+	}
+	locales = append(locales, language)
+	if locale, ok := languageLocales[language]; ok {
+		locales = append(locales, locale)
+	}
+	return locales
+}
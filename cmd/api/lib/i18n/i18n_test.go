@@ -0,0 +1,51 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "empty", acceptLanguage: "", want: LocaleEnglish},
+		{name: "brazilian portuguese", acceptLanguage: "pt-BR", want: LocalePortuguese},
+		{name: "other portuguese", acceptLanguage: "pt-PT", want: LocalePortuguese},
+		{name: "spanish region", acceptLanguage: "es-MX", want: LocaleSpanish},
+		{name: "english region", acceptLanguage: "en-US", want: LocaleEnglish},
+		{name: "unsupported", acceptLanguage: "fr-FR, de", want: LocaleEnglish},
+		{name: "first supported", acceptLanguage: "fr-FR, es;q=0.5", want: LocaleSpanish},
+		{name: "quality order", acceptLanguage: "en;q=0.3, pt-BR;q=0.8, es;q=0.5", want: LocalePortuguese},
+		{name: "zero quality", acceptLanguage: "es;q=0, pt", want: LocalePortuguese},
+		{name: "wildcard", acceptLanguage: "*", want: LocaleEnglish},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Negotiate(tt.acceptLanguage).Locale())
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	portuguese := Negotiate("pt-BR")
+	spanish := Negotiate("es")
+	english := Negotiate("en")
+
+	assert.Equal(t, "produto com ID 7 não encontrado", Translate(portuguese, "product with ID 7 not found"))
+	assert.Equal(t, "producto con ID 7 no encontrado", Translate(spanish, "product with ID 7 not found"))
+	assert.Equal(t, "product with ID 7 not found", Translate(english, "product with ID 7 not found"))
+	assert.Equal(t, "o nome da categoria é obrigatório", Translate(portuguese, "category name is required"))
+	assert.Equal(t, "price deve ser maior ou igual a 0", Translate(portuguese, "price must be greater than or equal to 0"))
+	assert.Equal(t, "price debe ser mayor que 0", Translate(spanish, "price must be greater than 0"))
+	assert.Equal(t, "status deve ser um de [active revoked]", Translate(portuguese, "status must be one of [active revoked]"))
+	assert.Equal(t, "something else happened", Translate(portuguese, "something else happened"))
+}
+
+func TestLanguageTag(t *testing.T) {
+	assert.Equal(t, "pt-BR", LanguageTag(Negotiate("pt")))
+	assert.Equal(t, "en", LanguageTag(Negotiate("")))
+}
@@ -0,0 +1,78 @@
+// Package imaging resizes images with the standard library only.
+package imaging
+
+import (
+	"image"
+	"image/draw"
+)
+
+// Fit returns the size of a width by height image scaled down, keeping its
+// aspect ratio, so its longest side is at most max pixels. Images that
+// already fit keep their size.
+func Fit(width int, height int, max int) (int, int) {
+	if width <= max && height <= max {
+		return width, height
+	}
+	if width >= height {
+		return max, atLeastOne(height * max / width)
+	}
+	return atLeastOne(width * max / height), max
+}
+
+// Resize scales src down to width by height pixels. Every destination pixel
+// is the average of the source pixels it covers, which keeps thumbnails free
+// of the aliasing of nearest neighbour sampling. It is not meant to enlarge
+// images.
+func Resize(src image.Image, width int, height int) *image.RGBA {
+	bounds := src.Bounds()
+	rgba, ok := src.(*image.RGBA)
+	if !ok || bounds.Min != (image.Point{}) {
+		rgba = image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+	}
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := span(y, height, srcHeight)
+		for x := 0; x < width; x++ {
+			x0, x1 := span(x, width, srcWidth)
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				i := rgba.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(rgba.Pix[i])
+					g += uint64(rgba.Pix[i+1])
+					b += uint64(rgba.Pix[i+2])
+					a += uint64(rgba.Pix[i+3])
+					n++
+					i += 4
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// span returns the source pixels [start, end) covered by destination pixel i
+// out of dst, always at least one.
+func span(i int, dst int, src int) (int, int) {
+	start := i * src / dst
+	end := (i + 1) * src / dst
+	if end <= start {
+		end = start + 1
+	}
+	return start, end
+}
+
+func atLeastOne(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
@@ -0,0 +1,66 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		max           int
+		wantWidth     int
+		wantHeight    int
+	}{
+		{name: "already fits", width: 100, height: 50, max: 160, wantWidth: 100, wantHeight: 50},
+		{name: "landscape", width: 1200, height: 900, max: 160, wantWidth: 160, wantHeight: 120},
+		{name: "portrait", width: 900, height: 1200, max: 160, wantWidth: 120, wantHeight: 160},
+		{name: "square", width: 500, height: 500, max: 160, wantWidth: 160, wantHeight: 160},
+		{name: "thin", width: 4000, height: 10, max: 160, wantWidth: 160, wantHeight: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := Fit(tt.width, tt.height, tt.max)
+			assert.Equal(t, tt.wantWidth, width)
+			assert.Equal(t, tt.wantHeight, height)
+		})
+	}
+}
+
+func TestResize_AveragesCoveredPixels(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x < 2 {
+				src.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				src.Set(x, y, color.RGBA{B: 200, A: 255})
+			}
+		}
+	}
+	src.Set(3, 1, color.RGBA{B: 0, A: 255})
+
+	dst := Resize(src, 2, 1)
+
+	assert.Equal(t, image.Rect(0, 0, 2, 1), dst.Bounds())
+	assert.Equal(t, color.RGBA{R: 255, A: 255}, dst.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{B: 150, A: 255}, dst.RGBAAt(1, 0))
+}
+
+func TestResize_ConvertsOtherImageTypes(t *testing.T) {
+	src := image.NewGray(image.Rect(10, 10, 30, 30))
+	for y := 10; y < 30; y++ {
+		for x := 10; x < 30; x++ {
+			src.SetGray(x, y, color.Gray{Y: 80})
+		}
+	}
+
+	dst := Resize(src, 5, 5)
+
+	assert.Equal(t, image.Rect(0, 0, 5, 5), dst.Bounds())
+	assert.Equal(t, color.RGBA{R: 80, G: 80, B: 80, A: 255}, dst.RGBAAt(4, 4))
+}
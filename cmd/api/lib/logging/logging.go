@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/requestid"
+	"github.com/mercadolibre/fury_go-core/pkg/log"
+)
+
+const requestIDField = "request_id"
+
+func Debug(ctx context.Context, msg string, fields ...log.Field) {
+	log.Debug(ctx, msg, withRequestID(ctx, fields)...)
+}
+
+func Info(ctx context.Context, msg string, fields ...log.Field) {
+	log.Info(ctx, msg, withRequestID(ctx, fields)...)
+}
+
+func Warn(ctx context.Context, msg string, fields ...log.Field) {
+	log.Warn(ctx, msg, withRequestID(ctx, fields)...)
+}
+
+func Error(ctx context.Context, msg string, fields ...log.Field) {
+	log.Error(ctx, msg, withRequestID(ctx, fields)...)
+}
+
+// withRequestID prepends the request id found in ctx, so every line logged
+// while serving a request can be correlated.
+func withRequestID(ctx context.Context, fields []log.Field) []log.Field {
+	id := requestid.FromContext(ctx)
+	if id == "" {
+		return fields
+	}
+	return append([]log.Field{log.String(requestIDField, id)}, fields...)
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	counter := NewCounterVec("test_requests_total", "Test requests.", "method", "status")
+	histogram := NewHistogramVec("test_request_duration_seconds", "Test latency.", []float64{0.1, 1}, "method")
+	NewGaugeFunc("test_open_connections", "Test connections.", func() float64 { return 3 })
+
+	counter.Inc("GET", "200")
+	counter.Add(2, "GET", "200")
+	histogram.Observe(0.05, "GET")
+	histogram.Observe(0.5, "GET")
+
+	var out bytes.Buffer
+	WritePrometheus(&out)
+
+	assert.Contains(t, out.String(), "# TYPE test_requests_total counter\ntest_requests_total{method=\"GET\",status=\"200\"} 3\n")
+	assert.Contains(t, out.String(), "test_request_duration_seconds_bucket{method=\"GET\",le=\"0.1\"} 1\n")
+	assert.Contains(t, out.String(), "test_request_duration_seconds_bucket{method=\"GET\",le=\"1\"} 2\n")
+	assert.Contains(t, out.String(), "test_request_duration_seconds_bucket{method=\"GET\",le=\"+Inf\"} 2\n")
+	assert.Contains(t, out.String(), "test_request_duration_seconds_count{method=\"GET\"} 2\n")
+	assert.Contains(t, out.String(), "# TYPE test_open_connections gauge\ntest_open_connections 3\n")
+}
@@ -0,0 +1,38 @@
+package money
+
+import "strings"
+
+// Currency is an ISO 4217 currency and the number of digits of its minor
+// unit, e.g. 2 for BRL (centavos) and 0 for CLP.
+type Currency struct {
+	Code   string
+	Digits int32
+}
+
+// currencies lists the supported ISO 4217 codes by minor unit digits.
+var currencies = map[string]int32{
+	"ARS": 2, "AUD": 2, "BHD": 3, "BOB": 2, "BRL": 2, "CAD": 2, "CHF": 2,
+	"CLF": 4, "CLP": 0, "CNY": 2, "COP": 2, "CRC": 2, "CZK": 2, "DKK": 2,
+	"DOP": 2, "EUR": 2, "GBP": 2, "GTQ": 2, "HKD": 2, "HNL": 2, "HUF": 2,
+	"IDR": 2, "ILS": 2, "INR": 2, "IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0,
+	"KRW": 0, "KWD": 3, "LYD": 3, "MXN": 2, "NIO": 2, "NOK": 2, "NZD": 2,
+	"OMR": 3, "PAB": 2, "PEN": 2, "PLN": 2, "PYG": 0, "SEK": 2, "SGD": 2,
+	"TND": 3, "TRY": 2, "TWD": 2, "USD": 2, "UYI": 0, "UYU": 2, "UYW": 4,
+	"VES": 2, "VND": 0, "XAF": 0, "XOF": 0, "ZAR": 2,
+}
+
+// LookupCurrency returns the currency of an ISO 4217 code, in any case.
+func LookupCurrency(code string) (Currency, bool) {
+	code = strings.ToUpper(code)
+	digits, ok := currencies[code]
+	if !ok {
+		return Currency{}, false
+	}
+	return Currency{Code: code, Digits: digits}, true
+}
+
+// Fits reports whether amount has no more decimal places than the minor unit
+// of c. Trailing zeros do not count: 10.500 fits BRL.
+func (c Currency) Fits(amount Decimal) bool {
+	return amount.Round(c.Digits).Equal(amount)
+}
@@ -0,0 +1,266 @@
+// Package money models amounts as exact decimals and their ISO 4217
+// currencies, so prices never go through binary floating point.
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ParseDecimal rejects input beyond these bounds, far larger than any price,
+// so that values such as "1e999999999" cannot pin the CPU while scaling.
+const (
+	maxDecimalDigits   = 64
+	maxDecimalExponent = 32
+)
+
+var (
+	ten = big.NewInt(10)
+
+	decimalPattern = regexp.MustCompile(`^([+-]?)(\d+)(?:\.(\d+))?(?:[eE]([+-]?\d+))?$`)
+)
+
+// Decimal is the exact number unscaled * 10^-scale. The zero value is 0.
+// Decimals are immutable: operations return new values.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal returns unscaled * 10^-scale, e.g. NewDecimal(1050, 2) is 10.50.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// ParseDecimal parses a decimal number such as "10", "-0.5" or "1.25e2". The
+// scale of the result is the number of digits after the point.
+func ParseDecimal(s string) (Decimal, error) {
+	match := decimalPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	sign, integer, fraction, exponent := match[1], match[2], match[3], match[4]
+	if len(integer)+len(fraction) > maxDecimalDigits {
+		return Decimal{}, fmt.Errorf("invalid decimal %q: more than %d digits", s, maxDecimalDigits)
+	}
+
+	scale := int64(len(fraction))
+	if exponent != "" {
+		exp, err := strconv.ParseInt(exponent, 10, 32)
+		if err != nil || exp < -maxDecimalExponent || exp > maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("invalid decimal %q: exponent out of range", s)
+		}
+		scale -= exp
+	}
+
+	unscaled, _ := new(big.Int).SetString(integer+fraction, 10)
+	if sign == "-" {
+		unscaled.Neg(unscaled)
+	}
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Decimal{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on invalid input. It is
+// meant for constants.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// Scale is the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return int(d.scale)
+}
+
+// Sign returns -1, 0 or 1 for negative, zero and positive values.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Unscaled returns the digits of d without the decimal point, e.g. 1050 for
+// 10.50, and false when they do not fit in an int64.
+func (d Decimal) Unscaled() (int64, bool) {
+	unscaled := d.int()
+	return unscaled.Int64(), unscaled.IsInt64()
+}
+
+// rescale returns d with scale digits after the point. It must only be used
+// to add digits.
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+// Cmp returns -1, 0 or 1 when d is lower than, equal to or greater than o.
+// Scale does not matter: 10.5 and 10.50 are equal.
+func (d Decimal) Cmp(o Decimal) int {
+	x, y, _ := align(d, o)
+	return x.Cmp(y)
+}
+
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return Decimal{unscaled: new(big.Int).Add(x, y), scale: scale}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	x, y, scale := align(d, o)
+	return Decimal{unscaled: new(big.Int).Sub(x, y), scale: scale}
+}
+
+// Mul returns the exact product, whose scale is the sum of both scales.
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.int(), o.int()), scale: d.scale + o.scale}
+}
+
+// Quo returns d / o with scale digits after the point, rounding half away
+// from zero. o must not be zero.
+func (d Decimal) Quo(o Decimal, scale int32) Decimal {
+	// d / o = (d.unscaled * 10^(scale + o.scale - d.scale) / o.unscaled) * 10^-scale,
+	// computed with one extra digit to round.
+	shift := scale + 1 + o.scale - d.scale
+	numerator := new(big.Int).Set(d.int())
+	denominator := new(big.Int).Set(o.int())
+	if shift >= 0 {
+		numerator.Mul(numerator, pow10(shift))
+	} else {
+		denominator.Mul(denominator, pow10(-shift))
+	}
+	quotient := new(big.Int).Quo(numerator, denominator)
+	return Decimal{unscaled: quotient, scale: scale + 1}.Round(scale)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Round returns d with exactly scale digits after the point, rounding half
+// away from zero when digits are dropped.
+func (d Decimal) Round(scale int32) Decimal {
+	if scale >= d.scale {
+		return Decimal{unscaled: d.rescale(scale), scale: scale}
+	}
+	divisor := pow10(d.scale - scale)
+	quotient, remainder := new(big.Int).QuoRem(d.int(), divisor, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(divisor) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(d.Sign())))
+	}
+	return Decimal{unscaled: quotient, scale: scale}
+}
+
+// Float64 returns the nearest float64 to d, for clients that cannot handle
+// anything else.
+func (d Decimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.int(), pow10(d.scale)).Float64()
+	return f
+}
+
+// String formats d with all its digits after the point, e.g. "10.50".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		point := len(digits) - int(d.scale)
+		digits = digits[:point] + "." + digits[point:]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalJSON formats d as a JSON string so no precision is lost by clients
+// that parse numbers as floats.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts both JSON strings and numbers. Numbers are read from
+// their text, never through float64.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseDecimal(text)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan reads DECIMAL columns, which the MySQL driver returns as text.
+func (d *Decimal) Scan(src interface{}) error {
+	var text string
+	switch v := src.(type) {
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	case int64:
+		*d = NewDecimal(v, 0)
+		return nil
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("cannot scan %T into money.Decimal", src)
+	}
+	parsed, err := ParseDecimal(text)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value sends d as text so MySQL stores it without going through a double.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
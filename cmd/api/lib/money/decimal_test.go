@@ -0,0 +1,108 @@
+package money
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		scale int
+	}{
+		{input: "10", want: "10", scale: 0},
+		{input: "10.50", want: "10.50", scale: 2},
+		{input: "-0.05", want: "-0.05", scale: 2},
+		{input: "+3.1", want: "3.1", scale: 1},
+		{input: "1.25e2", want: "125", scale: 0},
+		{input: "1.5E-2", want: "0.015", scale: 3},
+		{input: "0.1", want: "0.1", scale: 1},
+		{input: "1e32", want: "1" + strings.Repeat("0", 32), scale: 0},
+		{input: "1e-32", want: "0." + strings.Repeat("0", 31) + "1", scale: 32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseDecimal(tt.input)
+			assert.NoError(t, err, "Error should not be returned")
+			assert.Equal(t, tt.want, d.String())
+			assert.Equal(t, tt.scale, d.Scale())
+		})
+	}
+
+	for _, input := range []string{"", "abc", "1.", ".5", "1,5", "NaN", "1e", "1e999999999", "1e-999999999", "1e33", "0." + strings.Repeat("1", 64)} {
+		_, err := ParseDecimal(input)
+		assert.Error(t, err, "Error should be returned for %q", input)
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	a := MustParseDecimal("0.1")
+	b := MustParseDecimal("0.2")
+
+	assert.Equal(t, "0.3", a.Add(b).String())
+	assert.True(t, a.Add(b).Equal(MustParseDecimal("0.30")))
+	assert.Equal(t, "-0.1", a.Sub(b).String())
+	assert.Equal(t, "0.02", a.Mul(b).String())
+	assert.Equal(t, "-0.1", a.Neg().String())
+	assert.Equal(t, "0.50", a.Quo(b, 2).String())
+	assert.Equal(t, "0.0056", MustParseDecimal("1").Quo(MustParseDecimal("180"), 4).String())
+	assert.Equal(t, "-3.33", MustParseDecimal("-10").Quo(MustParseDecimal("3"), 2).String())
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, 0, MustParseDecimal("10.5").Cmp(MustParseDecimal("10.500")))
+	assert.Equal(t, "0", Decimal{}.String())
+	assert.True(t, Decimal{}.IsZero())
+}
+
+func TestDecimal_Round(t *testing.T) {
+	tests := []struct {
+		input string
+		scale int32
+		want  string
+	}{
+		{input: "10.125", scale: 2, want: "10.13"},
+		{input: "10.124", scale: 2, want: "10.12"},
+		{input: "-10.125", scale: 2, want: "-10.13"},
+		{input: "10.5", scale: 0, want: "11"},
+		{input: "10", scale: 2, want: "10.00"},
+		{input: "0.004", scale: 2, want: "0.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, MustParseDecimal(tt.input).Round(tt.scale).String())
+		})
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var payload struct {
+		Amount Decimal `json:"amount"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": 19.99}`), &payload))
+	assert.Equal(t, "19.99", payload.Amount.String())
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount": "0.10"}`), &payload))
+	assert.Equal(t, "0.10", payload.Amount.String())
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": "ten"}`), &payload))
+
+	data, err := json.Marshal(payload)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.JSONEq(t, `{"amount": "0.10"}`, string(data))
+}
+
+func TestDecimal_ScanAndValue(t *testing.T) {
+	var d Decimal
+
+	assert.NoError(t, d.Scan([]byte("1234.5600")))
+	assert.Equal(t, "1234.5600", d.String())
+	assert.NoError(t, d.Scan(int64(7)))
+	assert.Equal(t, "7", d.String())
+	assert.Error(t, d.Scan(true))
+
+	value, err := MustParseDecimal("10.10").Value()
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, "10.10", value)
+}
@@ -0,0 +1,65 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Format is how amounts are written in JSON payloads.
+type Format string
+
+const (
+	// FormatNumber writes amounts as JSON numbers, e.g. 10.50. It keeps the
+	// payload of clients written when prices were floats.
+	FormatNumber Format = "number"
+	// FormatString writes amounts as decimal strings, e.g. "10.50".
+	FormatString Format = "string"
+	// FormatMinorUnits writes amounts as integers in the minor unit of their
+	// currency, e.g. 1050 for BRL 10.50.
+	FormatMinorUnits Format = "minor_units"
+)
+
+func (f Format) Valid() bool {
+	switch f {
+	case FormatNumber, FormatString, FormatMinorUnits:
+		return true
+	}
+	return false
+}
+
+// Encode writes amount in currency with exactly its minor unit digits.
+func (f Format) Encode(amount Decimal, currency Currency) (json.RawMessage, error) {
+	rounded := amount.Round(currency.Digits)
+	switch f {
+	case FormatString:
+		return json.Marshal(rounded.String())
+	case FormatMinorUnits:
+		return json.RawMessage(rounded.int().String()), nil
+	}
+	return json.RawMessage(rounded.String()), nil
+}
+
+// Decode reads an amount in currency. Strings are always decimal amounts;
+// numbers are minor units with FormatMinorUnits and decimal amounts
+// otherwise.
+func (f Format) Decode(raw json.RawMessage, currency Currency) (Decimal, error) {
+	text := strings.TrimSpace(string(raw))
+	if text == "" || text == "null" {
+		return Decimal{}, nil
+	}
+	if strings.HasPrefix(text, `"`) {
+		var amount Decimal
+		err := json.Unmarshal(raw, &amount)
+		return amount, err
+	}
+	if f == FormatMinorUnits {
+		minorUnits, ok := new(big.Int).SetString(text, 10)
+		if !ok || len(strings.TrimLeft(text, "+-")) > maxDecimalDigits {
+			return Decimal{}, fmt.Errorf("invalid amount in minor units %q", text)
+		}
+		return Decimal{unscaled: minorUnits, scale: currency.Digits}, nil
+	}
+	return ParseDecimal(text)
+}
@@ -0,0 +1,87 @@
+package money
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupCurrency(t *testing.T) {
+	brl, ok := LookupCurrency("brl")
+	assert.True(t, ok)
+	assert.Equal(t, Currency{Code: "BRL", Digits: 2}, brl)
+
+	_, ok = LookupCurrency("XYZ")
+	assert.False(t, ok)
+}
+
+func TestCurrency_Fits(t *testing.T) {
+	brl, _ := LookupCurrency("BRL")
+	clp, _ := LookupCurrency("CLP")
+	kwd, _ := LookupCurrency("KWD")
+
+	assert.True(t, brl.Fits(MustParseDecimal("10.50")))
+	assert.True(t, brl.Fits(MustParseDecimal("10.500")))
+	assert.False(t, brl.Fits(MustParseDecimal("10.505")))
+	assert.True(t, clp.Fits(MustParseDecimal("1500")))
+	assert.False(t, clp.Fits(MustParseDecimal("1500.5")))
+	assert.True(t, kwd.Fits(MustParseDecimal("1.125")))
+}
+
+func TestFormat_Encode(t *testing.T) {
+	brl, _ := LookupCurrency("BRL")
+	clp, _ := LookupCurrency("CLP")
+	amount := MustParseDecimal("10.5")
+
+	tests := []struct {
+		format   Format
+		currency Currency
+		want     string
+	}{
+		{format: FormatNumber, currency: brl, want: `10.50`},
+		{format: FormatString, currency: brl, want: `"10.50"`},
+		{format: FormatMinorUnits, currency: brl, want: `1050`},
+		{format: FormatMinorUnits, currency: clp, want: `11`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format)+" "+tt.currency.Code, func(t *testing.T) {
+			raw, err := tt.format.Encode(amount, tt.currency)
+			assert.NoError(t, err, "Error should not be returned")
+			assert.Equal(t, tt.want, string(raw))
+		})
+	}
+}
+
+func TestFormat_Decode(t *testing.T) {
+	brl, _ := LookupCurrency("BRL")
+
+	tests := []struct {
+		format Format
+		raw    string
+		want   string
+	}{
+		{format: FormatNumber, raw: `10.5`, want: "10.5"},
+		{format: FormatNumber, raw: `"10.50"`, want: "10.50"},
+		{format: FormatString, raw: `0.1`, want: "0.1"},
+		{format: FormatMinorUnits, raw: `1050`, want: "10.50"},
+		{format: FormatMinorUnits, raw: `"10.50"`, want: "10.50"},
+		{format: FormatNumber, raw: `null`, want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format)+" "+tt.raw, func(t *testing.T) {
+			amount, err := tt.format.Decode(json.RawMessage(tt.raw), brl)
+			assert.NoError(t, err, "Error should not be returned")
+			assert.Equal(t, tt.want, amount.String())
+		})
+	}
+
+	_, err := FormatMinorUnits.Decode(json.RawMessage(`10.5`), brl)
+	assert.Error(t, err, "Error should be returned")
+	_, err = FormatMinorUnits.Decode(json.RawMessage(strings.Repeat("9", 65)), brl)
+	assert.Error(t, err, "Error should be returned")
+	_, err = FormatNumber.Decode(json.RawMessage(`1e999999999`), brl)
+	assert.Error(t, err, "Error should be returned")
+	assert.False(t, Format("float").Valid())
+}
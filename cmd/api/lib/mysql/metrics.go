@@ -0,0 +1,36 @@
+package mysql
+
+import (
+	"database/sql"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/metrics"
+)
+
+const (
+	transactionCommit       = "commit"
+	transactionCommitFailed = "commit_failed"
+	transactionRollback     = "rollback"
+)
+
+var transactionsCounter = metrics.NewCounterVec("mysql_transactions_total", "MySQL transactions by result.", "result")
+
+func registerPoolMetrics(db *sql.DB) {
+	metrics.NewGaugeFunc("mysql_pool_max_open_connections", "Maximum number of open connections to the database.", func() float64 {
+		return float64(db.Stats().MaxOpenConnections)
+	})
+	metrics.NewGaugeFunc("mysql_pool_open_connections", "Established connections, both in use and idle.", func() float64 {
+		return float64(db.Stats().OpenConnections)
+	})
+	metrics.NewGaugeFunc("mysql_pool_in_use_connections", "Connections currently in use.", func() float64 {
+		return float64(db.Stats().InUse)
+	})
+	metrics.NewGaugeFunc("mysql_pool_idle_connections", "Idle connections.", func() float64 {
+		return float64(db.Stats().Idle)
+	})
+	metrics.NewGaugeFunc("mysql_pool_wait_count", "Total number of connections waited for.", func() float64 {
+		return float64(db.Stats().WaitCount)
+	})
+	metrics.NewGaugeFunc("mysql_pool_wait_duration_seconds", "Total time blocked waiting for a new connection.", func() float64 {
+		return db.Stats().WaitDuration.Seconds()
+	})
+}
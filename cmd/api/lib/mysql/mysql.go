@@ -16,7 +16,10 @@ import (
 
 type DB interface {
 	WithoutTransaction(ctx context.Context, exec func(helperdb.Tx) error) (err error)
+	WithoutRetry(ctx context.Context, exec func(helperdb.Tx) error) (err error)
 	WithTransaction(ctx context.Context, txFunc func(*sql.Tx) error) (err error)
+	PingContext(ctx context.Context) error
+	Stats() sql.DBStats
 	Close() error
 }
 
@@ -24,6 +27,7 @@ const maxConnLifetimeMinutes = 10
 
 type MySQL struct {
 	*sql.DB
+	connection config.ConnectionConfig
 }
 
 func NewMySQL(config config.Environment) (DB, error) {
@@ -49,8 +53,9 @@ func NewMySQL(config config.Environment) (DB, error) {
 	db.SetMaxOpenConns(config.MySQLConfig.PoolSizeMax)
 	db.SetMaxIdleConns(config.MySQLConfig.PoolSizeIddle)
 	db.SetConnMaxLifetime(maxConnLifetimeMinutes * time.Minute)
+	registerPoolMetrics(db)
 
-	return &MySQL{db}, nil
+	return &MySQL{DB: db, connection: config.ConnectionConfig}, nil
 }
 
 func buildConnectionString(user string, password string, host string, database string) string {
@@ -61,19 +66,42 @@ func (db MySQL) QueryContext(ctx context.Context, query string, args ...interfac
 	return db.DB.QueryContext(ctx, query, args...)
 }
 
+// WithoutTransaction runs txFunc as an idempotent read, so the whole function
+// is retried when it fails with a transient error.
 func (db MySQL) WithoutTransaction(ctx context.Context, txFunc func(helperdb.Tx) error) error {
 	ctx, span := telemetry.StartSpan(ctx, "mysql_without_transaction")
 	defer span.Finish()
 
-	return txFunc(db)
+	return db.withRetry(ctx, operationRead, func(ctx context.Context) error {
+		return txFunc(deadlineTx{db: db.DB, ctx: ctx})
+	})
 }
 
-func (db MySQL) WithTransaction(ctx context.Context, txFunc func(*sql.Tx) error) (err error) {
+// WithoutRetry runs txFunc once, bounded only by ctx, for callers such as
+// health checks that own their deadline and must not wait for retries.
+func (db MySQL) WithoutRetry(ctx context.Context, txFunc func(helperdb.Tx) error) error {
+	ctx, span := telemetry.StartSpan(ctx, "mysql_without_retry")
+	defer span.Finish()
+
+	return txFunc(deadlineTx{db: db.DB, ctx: ctx})
+}
+
+// WithTransaction retries the whole transaction when it is rolled back due to
+// a transient error, so txFunc must not have side effects outside of tx.
+func (db MySQL) WithTransaction(ctx context.Context, txFunc func(*sql.Tx) error) error {
 	spanTransaction := "mysql_with_transaction"
 	ctx, span := telemetry.StartSpan(ctx, spanTransaction)
+	defer span.Finish()
+
+	return db.withRetry(ctx, operationTransaction, func(ctx context.Context) error {
+		return db.runTransaction(ctx, span, spanTransaction, txFunc)
+	})
+}
+
+func (db MySQL) runTransaction(ctx context.Context, span telemetry.Span, spanTransaction string, txFunc func(*sql.Tx) error) (err error) {
 	span.SetLabel(spanTransaction, "begin")
 
-	tx, err := db.Begin()
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -81,17 +109,24 @@ func (db MySQL) WithTransaction(ctx context.Context, txFunc func(*sql.Tx) error)
 	defer func() {
 		if p := recover(); p != nil {
 			span.SetLabel(spanTransaction, "rollback")
+			transactionsCounter.Inc(transactionRollback)
 			err = tx.Rollback()
 			panic(p) // re-throw panic after Rollback
 		} else if err != nil {
 			logger.Debugf("Error preventing transaction commit %+v", err)
 			span.SetLabel(spanTransaction, "rollback")
+			transactionsCounter.Inc(transactionRollback)
 			_ = tx.Rollback()
 		} else {
 			span.SetLabel(spanTransaction, "commit")
 			err = tx.Commit() // err is nil; if Commit returns error update err
+			if err != nil {
+				transactionsCounter.Inc(transactionCommitFailed)
+				err = commitError{err: err}
+			} else {
+				transactionsCounter.Inc(transactionCommit)
+			}
 		}
-		span.Finish()
 	}()
 
 	err = txFunc(tx)
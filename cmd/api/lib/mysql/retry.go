@@ -0,0 +1,169 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/logging"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/metrics"
+	"github.com/mercadolibre/fury_go-core/pkg/log"
+)
+
+const (
+	operationRead        = "read"
+	operationTransaction = "transaction"
+
+	defaultTimeoutSeconds         = 10
+	defaultRetryDelayMilliSeconds = 50
+	maxRetryDelay                 = 5 * time.Second
+)
+
+// MySQL server error numbers that are worth retrying.
+const (
+	errTooManyConnections = 1040
+	errLockWaitTimeout    = 1205
+	errDeadlock           = 1213
+	errDuplicateEntry     = 1062
+	errNoReferencedRow    = 1452
+)
+
+var (
+	retriesCounter  = metrics.NewCounterVec("mysql_retries_total", "MySQL operations retried after a transient error.", "operation")
+	timeoutsCounter = metrics.NewCounterVec("mysql_timeouts_total", "MySQL operations that exceeded their deadline.", "operation")
+)
+
+func (db MySQL) withRetry(ctx context.Context, operation string, exec func(context.Context) error) error {
+	delay := db.retryDelay()
+
+	for attempt := 0; ; attempt++ {
+		err := db.withTimeout(ctx, operation, exec)
+		if err == nil || attempt >= db.connection.MaxRetries || !IsTransient(err) || isCommitError(err) {
+			return err
+		}
+
+		retriesCounter.Inc(operation)
+		logging.Warn(ctx, "mysql retry", log.String("operation", operation), log.Int("attempt", attempt+1), log.Err(err))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+func (db MySQL) withTimeout(ctx context.Context, operation string, exec func(context.Context) error) error {
+	if db.connection.DisableTimeout {
+		return exec(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, db.timeout())
+	defer cancel()
+
+	err := exec(ctx)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		timeoutsCounter.Inc(operation)
+	}
+	return err
+}
+
+func (db MySQL) timeout() time.Duration {
+	if db.connection.TimeoutSeconds <= 0 {
+		return defaultTimeoutSeconds * time.Second
+	}
+	return db.connection.TimeoutSeconds * time.Second
+}
+
+func (db MySQL) retryDelay() time.Duration {
+	if db.connection.RetryDelayMilliSeconds <= 0 {
+		return defaultRetryDelayMilliSeconds * time.Millisecond
+	}
+	return db.connection.RetryDelayMilliSeconds * time.Millisecond
+}
+
+// IsTransient reports whether err is a deadlock, a lost connection or a
+// saturated server, all of which may succeed when tried again.
+func IsTransient(err error) bool {
+	var mysqlErr *mysqlDriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case errTooManyConnections, errLockWaitTimeout, errDeadlock:
+			return true
+		}
+		return false
+	}
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysqlDriver.ErrInvalidConn)
+}
+
+// commitError is a failed commit. Its outcome is unknown, since the server may
+// have applied the transaction before the connection was lost, so it is never
+// retried.
+type commitError struct {
+	err error
+}
+
+func (c commitError) Error() string {
+	return c.err.Error()
+}
+
+func (c commitError) Unwrap() error {
+	return c.err
+}
+
+func isCommitError(err error) bool {
+	var commitErr commitError
+	return errors.As(err, &commitErr)
+}
+
+// IsDuplicateEntry reports whether err is a unique key violation.
+func IsDuplicateEntry(err error) bool {
+	var mysqlErr *mysqlDriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry
+}
+
+// IsForeignKeyViolation reports whether err is an insert or update of a row
+// that references a missing one.
+func IsForeignKeyViolation(err error) bool {
+	var mysqlErr *mysqlDriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errNoReferencedRow
+}
+
+// deadlineTx runs every statement under the context of the surrounding
+// WithoutTransaction call, so the per-call deadline also applies to
+// repository methods that do not take a context.
+type deadlineTx struct {
+	db  *sql.DB
+	ctx context.Context
+}
+
+func (t deadlineTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return t.db.QueryContext(t.ctx, query, args...)
+}
+
+func (t deadlineTx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return t.db.QueryRowContext(t.ctx, query, args...)
+}
+
+func (t deadlineTx) QueryContext(_ context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return t.db.QueryContext(t.ctx, query, args...)
+}
+
+func (t deadlineTx) QueryRowContext(_ context.Context, query string, args ...interface{}) *sql.Row {
+	return t.db.QueryRowContext(t.ctx, query, args...)
+}
+
+func (t deadlineTx) ExecContext(_ context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return t.db.ExecContext(t.ctx, query, args...)
+}
+
+func (t deadlineTx) Prepare(query string) (*sql.Stmt, error) {
+	return t.db.PrepareContext(t.ctx, query)
+}
@@ -0,0 +1,164 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/stretchr/testify/assert"
+)
+
+const updateQuery = "UPDATE products SET title = \\? WHERE id = \\?"
+
+func InitialMockMySQL(t *testing.T, maxRetries int) (*MySQL, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err, "Error should not be returned")
+	t.Cleanup(func() { _ = db.Close() })
+
+	return &MySQL{
+		DB: db,
+		connection: config.ConnectionConfig{
+			TimeoutSeconds:         1,
+			MaxRetries:             maxRetries,
+			RetryDelayMilliSeconds: 1,
+		},
+	}, mock
+}
+
+func TestWithTransaction_RetriesDeadlock(t *testing.T) {
+	db, mock := InitialMockMySQL(t, 2)
+	deadlock := &mysqlDriver.MySQLError{Number: errDeadlock, Message: "Deadlock found"}
+	before := retriesCounter.Value(operationTransaction)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(updateQuery).WithArgs("title", 1).WillReturnError(deadlock)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(updateQuery).WithArgs("title", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := db.WithTransaction(context.Background(), func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(context.Background(), "UPDATE products SET title = ? WHERE id = ?", "title", 1)
+		return err
+	})
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, before+1, retriesCounter.Value(operationTransaction))
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestWithTransaction_WithoutRetryOnPermanentError(t *testing.T) {
+	db, mock := InitialMockMySQL(t, 2)
+	duplicated := &mysqlDriver.MySQLError{Number: 1062, Message: "Duplicate entry"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(updateQuery).WithArgs("title", 1).WillReturnError(duplicated)
+	mock.ExpectRollback()
+
+	err := db.WithTransaction(context.Background(), func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(context.Background(), "UPDATE products SET title = ? WHERE id = ?", "title", 1)
+		return err
+	})
+
+	assert.ErrorIs(t, err, duplicated)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestWithTransaction_StopsAfterMaxRetries(t *testing.T) {
+	db, mock := InitialMockMySQL(t, 1)
+	lockWait := &mysqlDriver.MySQLError{Number: errLockWaitTimeout, Message: "Lock wait timeout exceeded"}
+
+	for i := 0; i < 2; i++ {
+		mock.ExpectBegin()
+		mock.ExpectExec(updateQuery).WithArgs("title", 1).WillReturnError(lockWait)
+		mock.ExpectRollback()
+	}
+
+	err := db.WithTransaction(context.Background(), func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(context.Background(), "UPDATE products SET title = ? WHERE id = ?", "title", 1)
+		return err
+	})
+
+	assert.ErrorIs(t, err, lockWait)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestWithoutTransaction_RetriesLostConnection(t *testing.T) {
+	db, mock := InitialMockMySQL(t, 1)
+	before := retriesCounter.Value(operationRead)
+
+	mock.ExpectQuery("SELECT name FROM categories WHERE id = \\?").WithArgs(1).WillReturnError(mysqlDriver.ErrInvalidConn)
+	mock.ExpectQuery("SELECT name FROM categories WHERE id = \\?").WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("test"))
+
+	var name string
+	err := db.WithoutTransaction(context.Background(), func(tx helperdb.Tx) error {
+		return tx.QueryRow("SELECT name FROM categories WHERE id = ?", 1).Scan(&name)
+	})
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, "test", name)
+	assert.Equal(t, before+1, retriesCounter.Value(operationRead))
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestWithTransaction_WithoutRetryOnCommitFailure(t *testing.T) {
+	db, mock := InitialMockMySQL(t, 2)
+	before := retriesCounter.Value(operationTransaction)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(updateQuery).WithArgs("title", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit().WillReturnError(mysqlDriver.ErrInvalidConn)
+
+	err := db.WithTransaction(context.Background(), func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(context.Background(), "UPDATE products SET title = ? WHERE id = ?", "title", 1)
+		return err
+	})
+
+	assert.ErrorIs(t, err, mysqlDriver.ErrInvalidConn)
+	assert.Equal(t, before, retriesCounter.Value(operationTransaction))
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "deadlock", err: &mysqlDriver.MySQLError{Number: errDeadlock}, want: true},
+		{name: "too many connections", err: &mysqlDriver.MySQLError{Number: errTooManyConnections}, want: true},
+		{name: "invalid connection", err: mysqlDriver.ErrInvalidConn, want: true},
+		{name: "bad connection", err: driver.ErrBadConn, want: true},
+		{name: "wrapped by domain", err: domain.NewInternalError("fail", &mysqlDriver.MySQLError{Number: errDeadlock}), want: true},
+		{name: "duplicate entry", err: &mysqlDriver.MySQLError{Number: errDuplicateEntry}, want: false},
+		{name: "no rows", err: sql.ErrNoRows, want: false},
+		{name: "deadline", err: context.DeadlineExceeded, want: false},
+		{name: "generic", err: errors.New("generic"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTransient(tt.err))
+		})
+	}
+}
+
+func TestIsDuplicateEntry(t *testing.T) {
+	assert.True(t, IsDuplicateEntry(&mysqlDriver.MySQLError{Number: errDuplicateEntry}))
+	assert.True(t, IsDuplicateEntry(domain.NewInternalError("fail", &mysqlDriver.MySQLError{Number: errDuplicateEntry})))
+	assert.False(t, IsDuplicateEntry(&mysqlDriver.MySQLError{Number: errDeadlock}))
+	assert.False(t, IsDuplicateEntry(errors.New("generic")))
+}
+
+func TestIsForeignKeyViolation(t *testing.T) {
+	assert.True(t, IsForeignKeyViolation(&mysqlDriver.MySQLError{Number: errNoReferencedRow}))
+	assert.False(t, IsForeignKeyViolation(&mysqlDriver.MySQLError{Number: errDuplicateEntry}))
+	assert.False(t, IsForeignKeyViolation(errors.New("generic")))
+}
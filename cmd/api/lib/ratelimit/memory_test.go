@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStore() (*MemoryStore, *time.Time) {
+	now := time.Date(2023, 9, 8, 10, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	store, now := newTestStore()
+	limit := Limit{Rate: 1, Burst: 2}
+
+	first, err := store.Take(context.Background(), "client", limit)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 2, first.Limit)
+	assert.Equal(t, 1, first.Remaining)
+	assert.Equal(t, time.Second, first.Reset)
+
+	second, _ := store.Take(context.Background(), "client", limit)
+	assert.True(t, second.Allowed)
+	assert.Equal(t, 0, second.Remaining)
+
+	third, _ := store.Take(context.Background(), "client", limit)
+	assert.False(t, third.Allowed)
+	assert.Equal(t, time.Second, third.RetryAfter)
+
+	*now = now.Add(500 * time.Millisecond)
+	fourth, _ := store.Take(context.Background(), "client", limit)
+	assert.False(t, fourth.Allowed)
+	assert.Equal(t, 500*time.Millisecond, fourth.RetryAfter)
+
+	*now = now.Add(500 * time.Millisecond)
+	fifth, _ := store.Take(context.Background(), "client", limit)
+	assert.True(t, fifth.Allowed)
+}
+
+func TestMemoryStore_TakeKeepsClientsApart(t *testing.T) {
+	store, _ := newTestStore()
+	limit := Limit{Rate: 1, Burst: 1}
+
+	first, _ := store.Take(context.Background(), "first", limit)
+	second, _ := store.Take(context.Background(), "second", limit)
+
+	assert.True(t, first.Allowed)
+	assert.True(t, second.Allowed)
+}
+
+func TestMemoryStore_DropsFullBuckets(t *testing.T) {
+	store, now := newTestStore()
+	limit := Limit{Rate: 10, Burst: 10}
+
+	_, _ = store.Take(context.Background(), "idle", limit)
+	*now = now.Add(2 * sweepInterval)
+	_, _ = store.Take(context.Background(), "active", limit)
+
+	assert.Equal(t, 1, store.Len())
+}
@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit describes a token bucket: it holds up to Burst tokens and refills at
+// Rate tokens per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Enabled reports whether the limit allows a finite amount of requests.
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// Result is the state of a bucket after a Take.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long the caller has to wait for the next token. It is
+	// zero when the request was allowed.
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+}
+
+// Store keeps one token bucket per key. MemoryStore serves a single instance;
+// a shared backend, e.g. Redis, can implement Store to limit across instances.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
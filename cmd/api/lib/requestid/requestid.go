@@ -0,0 +1,48 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+)
+
+const (
+	Header = "X-Request-ID"
+
+	generatedLength = 16
+)
+
+type contextKey struct{}
+
+// validID bounds what is accepted from callers, since the id ends up in
+// headers and log lines.
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(contextKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// Resolve returns the id received from the caller when it is well formed, or
+// a new random one.
+func Resolve(received string) string {
+	if validID.MatchString(received) {
+		return received
+	}
+	return Generate()
+}
+
+func Generate() string {
+	b := make([]byte, generatedLength)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,40 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		received string
+		keep     bool
+	}{
+		{name: "keeps valid id", received: "7f1c2a9e-8d41-4b4e-9d3a-1c6f5e2b8a70", keep: true},
+		{name: "generates when empty", received: "", keep: false},
+		{name: "generates on invalid characters", received: "id\nwith newline", keep: false},
+		{name: "generates when too long", received: strings.Repeat("a", 129), keep: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Resolve(tt.received)
+			if tt.keep {
+				assert.Equal(t, tt.received, got)
+			} else {
+				assert.NotEqual(t, tt.received, got)
+				assert.Len(t, got, generatedLength*2)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := NewContext(context.Background(), "abc")
+
+	assert.Equal(t, "abc", FromContext(ctx))
+	assert.Equal(t, "", FromContext(context.Background()))
+}
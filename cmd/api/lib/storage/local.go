@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type local struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocal returns a Storage that writes files to dir, creating it when it
+// does not exist, and serves them under baseURL.
+func NewLocal(dir string, baseURL string) (Storage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &local{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+// Put writes to a temporary file first and renames it, so readers never see
+// a partially written object.
+func (l *local) Put(ctx context.Context, key string, r io.Reader) (string, error) {
+	if !ValidKey(key) {
+		return "", ErrInvalidKey
+	}
+	tmp, err := os.CreateTemp(l.dir, ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err = tmp.Close(); err != nil {
+		return "", err
+	}
+	if err = os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", err
+	}
+	if err = os.Rename(tmp.Name(), filepath.Join(l.dir, key)); err != nil {
+		return "", err
+	}
+	return l.URL(key), nil
+}
+
+func (l *local) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if !ValidKey(key) {
+		return nil, ErrInvalidKey
+	}
+	f, err := os.Open(filepath.Join(l.dir, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (l *local) Delete(ctx context.Context, key string) error {
+	if !ValidKey(key) {
+		return ErrInvalidKey
+	}
+	err := os.Remove(filepath.Join(l.dir, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (l *local) URL(key string) string {
+	return l.baseURL + "/" + key
+}
+
+func (l *local) Key(url string) (string, bool) {
+	key, ok := strings.CutPrefix(url, l.baseURL+"/")
+	if !ok || !ValidKey(key) {
+		return "", false
+	}
+	return key, true
+}
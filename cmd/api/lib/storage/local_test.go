@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal_PutAndOpen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := NewLocal(dir, "http://localhost:8080/uploads/")
+	assert.NoError(t, err, "Error should not be returned")
+
+	url, err := s.Put(ctx, "a.png", strings.NewReader("content"))
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, "http://localhost:8080/uploads/a.png", url)
+
+	r, err := s.Open(ctx, "a.png")
+	assert.NoError(t, err, "Error should not be returned")
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, "content", string(content))
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Len(t, entries, 1, "temporary files should be removed")
+}
+
+func TestLocal_CreatesDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "uploads")
+
+	_, err := NewLocal(dir, "http://localhost:8080/uploads")
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.DirExists(t, dir)
+}
+
+func TestLocal_OpenMissing(t *testing.T) {
+	s, err := NewLocal(t.TempDir(), "http://localhost:8080/uploads")
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = s.Open(context.Background(), "missing.png")
+
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocal_Delete(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocal(t.TempDir(), "http://localhost:8080/uploads")
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = s.Put(ctx, "a.png", strings.NewReader("content"))
+	assert.NoError(t, err, "Error should not be returned")
+
+	assert.NoError(t, s.Delete(ctx, "a.png"), "Error should not be returned")
+	assert.NoError(t, s.Delete(ctx, "a.png"), "deleting a missing object should not fail")
+	_, err = s.Open(ctx, "a.png")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocal_RejectsInvalidKeys(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocal(t.TempDir(), "http://localhost:8080/uploads")
+	assert.NoError(t, err, "Error should not be returned")
+
+	for _, key := range []string{"", "../a.png", "a/b.png", `a\b.png`, ".hidden"} {
+		_, err = s.Put(ctx, key, strings.NewReader("content"))
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+		_, err = s.Open(ctx, key)
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+		assert.ErrorIs(t, s.Delete(ctx, key), ErrInvalidKey, key)
+	}
+}
+
+func TestLocal_Key(t *testing.T) {
+	s, err := NewLocal(t.TempDir(), "http://localhost:8080/uploads")
+	assert.NoError(t, err, "Error should not be returned")
+
+	key, ok := s.Key("http://localhost:8080/uploads/a.png")
+	assert.True(t, ok)
+	assert.Equal(t, "a.png", key)
+
+	_, ok = s.Key("https://cdn.example.com/a.png")
+	assert.False(t, ok)
+	_, ok = s.Key("http://localhost:8080/uploads/a/../b.png")
+	assert.False(t, ok)
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when no object is stored under a key.
+	ErrNotFound = errors.New("storage: object not found")
+	// ErrInvalidKey is returned for keys that are empty or could escape the
+	// storage, such as keys with path separators.
+	ErrInvalidKey = errors.New("storage: invalid key")
+)
+
+// Storage keeps uploaded files under flat keys. It is the seam between the
+// service and where files live, so the local disk can be replaced by an
+// object store without touching the callers.
+type Storage interface {
+	// Put stores the content of r under key, replacing any previous object,
+	// and returns its public URL.
+	Put(ctx context.Context, key string, r io.Reader) (string, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL of key.
+	URL(key string) string
+	// Key returns the key of a public URL, and false when the URL does not
+	// belong to the storage.
+	Key(url string) (string, bool)
+}
+
+// ValidKey reports whether key is a single, non hidden path element.
+func ValidKey(key string) bool {
+	return key != "" && !strings.HasPrefix(key, ".") && !strings.ContainsAny(key, `/\`)
+}
@@ -1,10 +1,17 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/controller"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/auth"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/runtime"
 
 	"github.com/mercadolibre/fury_go-core/pkg/web"
@@ -26,26 +33,105 @@ import (
 
 // @externalDocs.description  OpenAPI
 // @externalDocs.url          https://swagger.io/resources/open-api/
+
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 API key granted the scopes listed in x-required-scopes.
+
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 "Bearer <jwt>" whose scope claim has the scopes listed in x-required-scopes.
 const (
 	timeOutGeneral = 60
+	serverAddress  = ":8080"
+
+	rateLimitRead  = "read"
+	rateLimitWrite = "write"
+	rateLimitAdmin = "admin"
+	rateLimitAuth  = "auth"
 )
 
 func routes(app *fury.Application, run *runtime.Runtime) {
+	app.Use(config.RequestID())
 	app.Use(config.JSONResponse())
+	app.Use(config.Metrics())
+	app.Use(config.AccessLog())
+
+	app.Get("/metrics", controller.HandleMetrics)
+
+	//Health
+	app.Get("/health/live", handle(run.HealthController.HandleLiveness))
+	app.Get("/health/ready", handle(run.HealthController.HandleReadiness))
+
+	limit := func(group string) config.HandlerMiddleware {
+		return config.RateLimit(run.RateLimitStore, group, run.Environment.RateLimit)
+	}
+	// Credentials are checked behind a limit by IP, so requests that fail to
+	// authenticate are throttled too; the group limit then applies per principal.
+	authenticated := config.Authenticate(run.AuthService)
+	reader := []config.HandlerMiddleware{limit(rateLimitRead)}
+	writer := []config.HandlerMiddleware{limit(rateLimitAuth), authenticated, limit(rateLimitWrite), config.RequireScopes(auth.ScopeCatalogWrite)}
+	creator := []config.HandlerMiddleware{limit(rateLimitAuth), authenticated, limit(rateLimitWrite), config.RequireScopes(auth.ScopeCatalogWrite), config.Idempotency(run.IdempotencyService, run.Environment.Idempotency)}
+	admin := []config.HandlerMiddleware{limit(rateLimitAuth), authenticated, limit(rateLimitAdmin), config.RequireScopes(auth.ScopeCatalogAdmin)}
 
 	//Product
-	app.Get("/products", run.ProductController.HandleGetProducts)
-	app.Get("/product/{id}", run.ProductController.HandleGetProductByID)
-	app.Get("/products/category/{category}", run.ProductController.HandleFindProductByCategory)
-	app.Post("/product", run.ProductController.HandleCreateProduct)
-	app.Put("/product/{id}", run.ProductController.HandleUpdateProduct)
-	app.Delete("/product/{id}", run.ProductController.HandleDeleteProduct)
+	app.Get("/products", handle(run.ProductController.HandleGetProducts, reader...))
+	app.Get("/product/{id}", handle(run.ProductController.HandleGetProductByID, reader...))
+	app.Get("/products/category/{category}", handle(run.ProductController.HandleFindProductByCategory, reader...))
+	app.Post("/product", handle(run.ProductController.HandleCreateProduct, creator...))
+	app.Put("/product/{id}", handle(run.ProductController.HandleUpdateProduct, writer...))
+	app.Delete("/product/{id}", handle(run.ProductController.HandleDeleteProduct, writer...))
+	app.Post("/product/{id}/publish", handle(run.ProductController.HandlePublishProduct, writer...))
+	app.Post("/product/{id}/archive", handle(run.ProductController.HandleArchiveProduct, writer...))
+	app.Get("/product/{id}/images", handle(run.ProductImageController.HandleGetProductImages, reader...))
+	app.Post("/product/{id}/images", handle(run.ProductImageController.HandleAddProductImage, creator...))
+	app.Put("/product/{id}/images/order", handle(run.ProductImageController.HandleReorderProductImages, writer...))
+	app.Put("/product/{id}/images/{imageId}", handle(run.ProductImageController.HandleUpdateProductImage, writer...))
+	app.Delete("/product/{id}/images/{imageId}", handle(run.ProductImageController.HandleDeleteProductImage, writer...))
+	app.Post("/uploads/images", handle(run.UploadController.HandleUploadImage, writer...))
+	app.Get("/uploads/{key}", handle(run.UploadController.HandleGetUpload, reader...))
+
+	//Prices
+	app.Get("/product/{id}/prices", handle(run.PriceListController.HandleGetProductPrices, reader...))
+	app.Get("/product/{id}/price-history", handle(run.PriceListController.HandleGetPriceHistory, reader...))
+	app.Put("/product/{id}/prices/{currency}", handle(run.PriceListController.HandleSetProductPrice, writer...))
+	app.Delete("/product/{id}/prices/{currency}", handle(run.PriceListController.HandleDeleteProductPrice, writer...))
+	app.Get("/product/{id}/scheduled-prices", handle(run.ScheduledPriceController.HandleGetScheduledPrices, writer...))
+	app.Post("/product/{id}/scheduled-prices", handle(run.ScheduledPriceController.HandleSchedulePrice, creator...))
+	app.Post("/product/{id}/scheduled-prices/{changeId}/cancel", handle(run.ScheduledPriceController.HandleCancelScheduledPrice, writer...))
+	app.Get("/exchange-rates", handle(run.PriceListController.HandleGetExchangeRates, reader...))
+
+	//Promotions
+	app.Get("/promotions", handle(run.PromotionController.HandleGetPromotions, reader...))
+	app.Get("/promotion/{id}", handle(run.PromotionController.HandleGetPromotion, reader...))
+	app.Post("/promotion", handle(run.PromotionController.HandleCreatePromotion, creator...))
+	app.Put("/promotion/{id}", handle(run.PromotionController.HandleUpdatePromotion, writer...))
+	app.Delete("/promotion/{id}", handle(run.PromotionController.HandleDeletePromotion, writer...))
 
 	//Category
-	app.Get("/products/categories", run.CategoryController.HandleGetCategories)
-	app.Post("/category", run.CategoryController.HandleCreateCategory)
-	app.Put("/category/{id}", run.CategoryController.HandleUpdateCategory)
-	app.Delete("/category/{id}", run.CategoryController.HandleDeleteCategory)
+	app.Get("/products/categories", handle(run.CategoryController.HandleGetCategories, reader...))
+	app.Post("/category", handle(run.CategoryController.HandleCreateCategory, creator...))
+	app.Put("/category/{id}", handle(run.CategoryController.HandleUpdateCategory, writer...))
+	app.Delete("/category/{id}", handle(run.CategoryController.HandleDeleteCategory, writer...))
+	app.Get("/category/{id}/attributes", handle(run.CategoryAttributeController.HandleGetCategoryAttributes, reader...))
+	app.Post("/category/{id}/attributes", handle(run.CategoryAttributeController.HandleCreateCategoryAttribute, creator...))
+	app.Put("/category/{id}/attributes/{attributeId}", handle(run.CategoryAttributeController.HandleUpdateCategoryAttribute, writer...))
+	app.Delete("/category/{id}/attributes/{attributeId}", handle(run.CategoryAttributeController.HandleDeleteCategoryAttribute, writer...))
+
+	//Admin
+	app.Post("/admin/api-keys", handle(run.APIKeyController.HandleCreateAPIKey, admin...))
+	app.Get("/admin/api-keys", handle(run.APIKeyController.HandleGetAPIKeys, admin...))
+	app.Delete("/admin/api-keys/{id}", handle(run.APIKeyController.HandleRevokeAPIKey, admin...))
+	app.Get("/admin/products", handle(run.ProductController.HandleGetAdminProducts, admin...))
+	app.Get("/admin/products/{id}", handle(run.ProductController.HandleGetAdminProductByID, admin...))
+}
+
+// handle decorates a route handler with the middlewares every route shares,
+// followed by the route specific ones.
+func handle(handler web.Handler, middlewares ...config.HandlerMiddleware) web.Handler {
+	return config.Chain(handler, append([]config.HandlerMiddleware{config.ErrorResponse()}, middlewares...)...)
 }
 
 func main() {
@@ -56,24 +142,29 @@ func main() {
 
 func run() error {
 	run := runtime.InstanceRuntime()
-	app, err := fury.NewWebApplication(
-		fury.WithTimeouts(
-			web.Timeouts{
-				WriteTimeout:      timeOutGeneral * time.Minute,
-				ReadTimeout:       timeOutGeneral * time.Minute,
-				ReadHeaderTimeout: timeOutGeneral * time.Minute,
-				IdleTimeout:       timeOutGeneral * time.Minute,
-				ShutdownTimeout:   timeOutGeneral * time.Minute,
-			},
-		),
-		fury.WithLogLevel(run.Environment.LogLevel),
-	)
-
+	app, err := fury.NewWebApplication(fury.WithLogLevel(run.Environment.LogLevel))
 	if err != nil {
 		return err
 	}
 
 	routes(app, run)
-	return app.Run()
 
+	if err = run.Start(context.Background()); err != nil {
+		return err
+	}
+
+	// The server is run here instead of by the application so that the drain
+	// waits for readiness to fail first; see Runtime.Serve.
+	server := &http.Server{
+		Addr:              serverAddress,
+		Handler:           app,
+		WriteTimeout:      timeOutGeneral * time.Minute,
+		ReadTimeout:       timeOutGeneral * time.Minute,
+		ReadHeaderTimeout: timeOutGeneral * time.Minute,
+		IdleTimeout:       timeOutGeneral * time.Minute,
+	}
+
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stopSignals()
+	return run.Serve(signalCtx, server)
 }
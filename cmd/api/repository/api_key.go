@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, apiKey domain.APIKey) (int64, error)
+	FindAll(ctx context.Context, tx helperdb.Tx) ([]domain.APIKey, error)
+	FindByID(ctx context.Context, tx helperdb.Tx, id int64) (domain.APIKey, error)
+	FindActiveByHash(ctx context.Context, tx helperdb.Tx, hash string) (domain.APIKey, error)
+	Revoke(ctx context.Context, tx *sql.Tx, id int64) (int64, error)
+}
+
+type apiKeyRepository struct {
+}
+
+func NewAPIKeyRepository() APIKeyRepository {
+	return &apiKeyRepository{}
+}
+
+const (
+	createAPIKeyQuery           = "INSERT INTO api_keys (name, prefix, key_hash, scopes, created_at) VALUES (?, ?, ?, ?, NOW())"
+	findAllAPIKeysQuery         = "SELECT id, name, prefix, key_hash, scopes, created_at, revoked_at FROM api_keys ORDER BY id"
+	findByIDAPIKeyQuery         = "SELECT id, name, prefix, key_hash, scopes, created_at, revoked_at FROM api_keys WHERE id = ?"
+	findActiveByHashAPIKeyQuery = "SELECT id, name, prefix, key_hash, scopes, created_at, revoked_at FROM api_keys WHERE key_hash = ? AND revoked_at IS NULL"
+	revokeAPIKeyQuery           = "UPDATE api_keys SET revoked_at = NOW() WHERE id = ? AND revoked_at IS NULL"
+
+	scopesSeparator = " "
+)
+
+func (a *apiKeyRepository) Create(ctx context.Context, tx *sql.Tx, apiKey domain.APIKey) (int64, error) {
+	res, err := tx.ExecContext(
+		ctx,
+		createAPIKeyQuery,
+		apiKey.Name,
+		apiKey.Prefix,
+		apiKey.Hash,
+		strings.Join(apiKey.Scopes, scopesSeparator),
+	)
+	if err != nil {
+		return 0, domain.NewInternalError("fail to create api key", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, domain.NewInternalError("fail to get last insert id", err)
+	}
+	return id, nil
+}
+
+func (a *apiKeyRepository) FindAll(ctx context.Context, tx helperdb.Tx) ([]domain.APIKey, error) {
+	rows, err := tx.QueryContext(ctx, findAllAPIKeysQuery)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get api keys from db", err)
+	}
+	defer rows.Close()
+
+	var apiKeys []domain.APIKey
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, domain.NewInternalError("fail to scan api key", err)
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	return apiKeys, nil
+}
+
+func (a *apiKeyRepository) FindByID(ctx context.Context, tx helperdb.Tx, id int64) (domain.APIKey, error) {
+	apiKey, err := scanAPIKey(tx.QueryRowContext(ctx, findByIDAPIKeyQuery, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.APIKey{}, domain.NewNotFoundError(fmt.Sprintf("api key with id %d not found", id), err)
+		}
+		return domain.APIKey{}, domain.NewInternalError("fail to get api key", err)
+	}
+	return apiKey, nil
+}
+
+func (a *apiKeyRepository) FindActiveByHash(ctx context.Context, tx helperdb.Tx, hash string) (domain.APIKey, error) {
+	apiKey, err := scanAPIKey(tx.QueryRowContext(ctx, findActiveByHashAPIKeyQuery, hash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.APIKey{}, domain.NewNotFoundError("api key not found", err)
+		}
+		return domain.APIKey{}, domain.NewInternalError("fail to get api key", err)
+	}
+	return apiKey, nil
+}
+
+func (a *apiKeyRepository) Revoke(ctx context.Context, tx *sql.Tx, id int64) (int64, error) {
+	res, err := tx.ExecContext(ctx, revokeAPIKeyQuery, id)
+	if err != nil {
+		return 0, domain.NewInternalError("fail to revoke api key", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, domain.NewInternalError("fail to get rows affected", err)
+	}
+	if rowsAffected == 0 {
+		return 0, domain.NewNotFoundError(fmt.Sprintf("active api key with id %d not found", id), nil)
+	}
+	return rowsAffected, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAPIKey(row scanner) (domain.APIKey, error) {
+	var apiKey domain.APIKey
+	var scopes string
+	var revokedAt sql.NullTime
+	err := row.Scan(
+		&apiKey.ID,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.Hash,
+		&scopes,
+		&apiKey.CreatedAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+	apiKey.Scopes = strings.Fields(scopes)
+	if revokedAt.Valid {
+		apiKey.RevokedAt = &revokedAt.Time
+	}
+	return apiKey, nil
+}
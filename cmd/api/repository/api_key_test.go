@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var apiKeyRows = []string{
+	"id",
+	"name",
+	"prefix",
+	"key_hash",
+	"scopes",
+	"created_at",
+	"revoked_at",
+}
+
+func InitialMockDBAPIKey() domain.APIKey {
+	return domain.APIKey{
+		ID:        1,
+		Name:      "storefront",
+		Prefix:    "ck_0123abcd",
+		Hash:      "5d41402abc4b2a76b9719d911017c592",
+		Scopes:    []string{"catalog:read", "catalog:write"},
+		CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCreateAPIKey_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	apiKey := InitialMockDBAPIKey()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createAPIKeyQuery)).WithArgs(
+		apiKey.Name,
+		apiKey.Prefix,
+		apiKey.Hash,
+		"catalog:read catalog:write",
+	).WillReturnResult(sqlmock.NewResult(apiKey.ID, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	id, err := NewAPIKeyRepository().Create(context.Background(), tx, apiKey)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, apiKey.ID, id)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindActiveByHash_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	apiKey := InitialMockDBAPIKey()
+
+	mock.ExpectQuery(QueryReplace(findActiveByHashAPIKeyQuery)).WithArgs(apiKey.Hash).
+		WillReturnRows(sqlmock.NewRows(apiKeyRows).AddRow(
+			apiKey.ID, apiKey.Name, apiKey.Prefix, apiKey.Hash, "catalog:read catalog:write", apiKey.CreatedAt, nil,
+		))
+
+	got, err := NewAPIKeyRepository().FindActiveByHash(context.Background(), db, apiKey.Hash)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, apiKey, got)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindActiveByHash_WithErrorErrNoRows(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findActiveByHashAPIKeyQuery)).WithArgs("unknown").WillReturnError(sql.ErrNoRows)
+
+	_, err := NewAPIKeyRepository().FindActiveByHash(context.Background(), db, "unknown")
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindAllAPIKeys_WithRevokedKey(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	apiKey := InitialMockDBAPIKey()
+	revokedAt := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(QueryReplace(findAllAPIKeysQuery)).
+		WillReturnRows(sqlmock.NewRows(apiKeyRows).AddRow(
+			apiKey.ID, apiKey.Name, apiKey.Prefix, apiKey.Hash, "", apiKey.CreatedAt, revokedAt,
+		))
+
+	got, err := NewAPIKeyRepository().FindAll(context.Background(), db)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Len(t, got, 1)
+	assert.Equal(t, &revokedAt, got[0].RevokedAt)
+	assert.Empty(t, got[0].Scopes)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestRevokeAPIKey_WithZeroRowsAffected(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(revokeAPIKeyQuery)).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = NewAPIKeyRepository().Revoke(context.Background(), tx, 1)
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
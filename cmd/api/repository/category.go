@@ -44,7 +44,7 @@ func (c *categoryRepository) Create(ctx context.Context, tx *sql.Tx, category do
 		category.Name,
 	)
 	if err != nil {
-		return 0, domain.NewInternalError(err.Error(), err)
+		return 0, domain.NewInternalError("fail to create category", err)
 	}
 	id, err := res.LastInsertId()
 	if err != nil {
@@ -59,7 +59,7 @@ func (c *categoryRepository) FindAll(ctx context.Context, tx helperdb.Tx, params
 	var total int64
 	err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM categories").Scan(&total)
 	if err != nil {
-		return nil, 0, domain.NewInternalError(err.Error(), err)
+		return nil, 0, domain.NewInternalError("fail to count categories", err)
 	}
 	rows, err := tx.QueryContext(ctx, query.String(), queryParams...)
 	if err != nil {
@@ -93,7 +93,7 @@ func (c *categoryRepository) FindByID(ctx context.Context, tx helperdb.Tx, id in
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Category{}, domain.NewNotFoundError(fmt.Sprintf("category with id %d not found", id), err)
 		}
-		return domain.Category{}, domain.NewInternalError(err.Error(), err)
+		return domain.Category{}, domain.NewInternalError("fail to get category", err)
 	}
 
 	return categories, nil
@@ -111,7 +111,7 @@ func (c *categoryRepository) FindByName(ctx context.Context, tx helperdb.Tx, nam
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Category{}, domain.NewNotFoundError(fmt.Sprintf("category with name %s not found", name), err)
 		}
-		return domain.Category{}, domain.NewInternalError(err.Error(), err)
+		return domain.Category{}, domain.NewInternalError("fail to get category", err)
 	}
 
 	return categories, nil
@@ -125,7 +125,7 @@ func (c *categoryRepository) Update(ctx context.Context, tx *sql.Tx, category do
 		category.ID,
 	)
 	if err != nil {
-		return 0, domain.NewInternalError(err.Error(), err)
+		return 0, domain.NewInternalError("fail to update category", err)
 	}
 	rowsAffected, err := res.RowsAffected()
 	if err != nil {
@@ -146,7 +146,7 @@ func (c *categoryRepository) Delete(ctx context.Context, tx *sql.Tx, id int64) (
 		id,
 	)
 	if err != nil {
-		return 0, domain.NewInternalError(err.Error(), err)
+		return 0, domain.NewInternalError("fail to delete category", err)
 	}
 	id, err = res.LastInsertId()
 	if err != nil {
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+)
+
+type CategoryAttributeRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, attribute domain.AttributeDefinition) (int64, error)
+	FindByCategory(ctx context.Context, tx helperdb.Tx, categoryID int64) ([]domain.AttributeDefinition, error)
+	FindByNames(ctx context.Context, tx helperdb.Tx, names []string) ([]domain.AttributeDefinition, error)
+	Lock(ctx context.Context, tx *sql.Tx, categoryID int64, id int64) (domain.AttributeDefinition, error)
+	Update(ctx context.Context, tx *sql.Tx, attribute domain.AttributeDefinition) error
+	Delete(ctx context.Context, tx *sql.Tx, categoryID int64, id int64) error
+}
+
+type categoryAttributeRepository struct {
+}
+
+func NewCategoryAttributeRepository() CategoryAttributeRepository {
+	return &categoryAttributeRepository{}
+}
+
+const (
+	categoryAttributeColumns = "id, category_id, name, type, required, created_at"
+
+	createCategoryAttributeQuery         = "INSERT INTO category_attributes (category_id, name, type, required, created_at) VALUES (?, ?, ?, ?, NOW())"
+	findByCategoryCategoryAttributeQuery = "SELECT " + categoryAttributeColumns + " FROM category_attributes WHERE category_id = ? ORDER BY name"
+	findByNamesCategoryAttributeQuery    = "SELECT " + categoryAttributeColumns + " FROM category_attributes WHERE name IN (%s) ORDER BY name, id"
+	lockCategoryAttributeQuery           = "SELECT " + categoryAttributeColumns + " FROM category_attributes WHERE category_id = ? AND id = ? FOR UPDATE"
+	updateCategoryAttributeQuery         = "UPDATE category_attributes SET required = ? WHERE id = ?"
+	deleteCategoryAttributeQuery         = "DELETE FROM category_attributes WHERE category_id = ? AND id = ?"
+	findAllowedValuesByCategoryQuery     = "SELECT v.attribute_id, v.value FROM category_attribute_values v JOIN category_attributes a ON a.id = v.attribute_id WHERE a.category_id = ? ORDER BY v.attribute_id, v.position"
+	createCategoryAttributeValueQuery    = "INSERT INTO category_attribute_values (attribute_id, position, value) VALUES (?, ?, ?)"
+	deleteCategoryAttributeValuesQuery   = "DELETE FROM category_attribute_values WHERE attribute_id = ?"
+)
+
+// Create adds an attribute to a category, along with its allowed values.
+func (c *categoryAttributeRepository) Create(ctx context.Context, tx *sql.Tx, attribute domain.AttributeDefinition) (int64, error) {
+	res, err := tx.ExecContext(
+		ctx,
+		createCategoryAttributeQuery,
+		attribute.CategoryID,
+		attribute.Name,
+		attribute.Type,
+		attribute.Required,
+	)
+	if err != nil {
+		if mysql.IsDuplicateEntry(err) {
+			return 0, domain.NewConflictError(fmt.Sprintf("attribute %s already exists in category with id %d", attribute.Name, attribute.CategoryID), err)
+		}
+		return 0, domain.NewInternalError("fail to create category attribute", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, domain.NewInternalError("fail to get last insert id", err)
+	}
+	if err = c.createAllowedValues(ctx, tx, id, attribute.AllowedValues); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// FindByCategory returns the attributes of a category by name.
+func (c *categoryAttributeRepository) FindByCategory(ctx context.Context, tx helperdb.Tx, categoryID int64) ([]domain.AttributeDefinition, error) {
+	rows, err := tx.QueryContext(ctx, findByCategoryCategoryAttributeQuery, categoryID)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get category attributes", err)
+	}
+	defer rows.Close()
+
+	attributes := []domain.AttributeDefinition{}
+	for rows.Next() {
+		attribute, err := scanCategoryAttribute(rows)
+		if err != nil {
+			return nil, domain.NewInternalError("fail to scan category attribute", err)
+		}
+		attributes = append(attributes, attribute)
+	}
+	if len(attributes) == 0 {
+		return attributes, nil
+	}
+	if err = c.findAllowedValues(ctx, tx, categoryID, attributes); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+// FindByNames returns the attributes of any category with one of names, by
+// name, without their allowed values.
+func (c *categoryAttributeRepository) FindByNames(ctx context.Context, tx helperdb.Tx, names []string) ([]domain.AttributeDefinition, error) {
+	attributes := []domain.AttributeDefinition{}
+	if len(names) == 0 {
+		return attributes, nil
+	}
+	args := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		args = append(args, name)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(names)), ", ")
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(findByNamesCategoryAttributeQuery, placeholders), args...)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get category attributes", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		attribute, err := scanCategoryAttribute(rows)
+		if err != nil {
+			return nil, domain.NewInternalError("fail to scan category attribute", err)
+		}
+		attributes = append(attributes, attribute)
+	}
+	return attributes, nil
+}
+
+// Lock reads an attribute of a category, without its allowed values, and
+// locks it until tx ends.
+func (c *categoryAttributeRepository) Lock(ctx context.Context, tx *sql.Tx, categoryID int64, id int64) (domain.AttributeDefinition, error) {
+	attribute, err := scanCategoryAttribute(tx.QueryRowContext(ctx, lockCategoryAttributeQuery, categoryID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.AttributeDefinition{}, domain.NewNotFoundError(fmt.Sprintf("attribute with ID %d of category with id %d not found", id, categoryID), err)
+		}
+		return domain.AttributeDefinition{}, domain.NewInternalError("fail to lock category attribute", err)
+	}
+	return attribute, nil
+}
+
+// Update replaces whether an attribute is required and its allowed values.
+func (c *categoryAttributeRepository) Update(ctx context.Context, tx *sql.Tx, attribute domain.AttributeDefinition) error {
+	if _, err := tx.ExecContext(ctx, updateCategoryAttributeQuery, attribute.Required, attribute.ID); err != nil {
+		return domain.NewInternalError("fail to update category attribute", err)
+	}
+	if _, err := tx.ExecContext(ctx, deleteCategoryAttributeValuesQuery, attribute.ID); err != nil {
+		return domain.NewInternalError("fail to update category attribute", err)
+	}
+	return c.createAllowedValues(ctx, tx, attribute.ID, attribute.AllowedValues)
+}
+
+// Delete removes an attribute of a category. The values products have for it
+// are removed along with it.
+func (c *categoryAttributeRepository) Delete(ctx context.Context, tx *sql.Tx, categoryID int64, id int64) error {
+	res, err := tx.ExecContext(ctx, deleteCategoryAttributeQuery, categoryID, id)
+	if err != nil {
+		return domain.NewInternalError("fail to delete category attribute", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return domain.NewInternalError("fail to get rows affected", err)
+	}
+	if rowsAffected == 0 {
+		return domain.NewNotFoundError(fmt.Sprintf("attribute with ID %d of category with id %d not found", id, categoryID), nil)
+	}
+	return nil
+}
+
+func (c *categoryAttributeRepository) createAllowedValues(ctx context.Context, tx *sql.Tx, id int64, values []string) error {
+	for position, value := range values {
+		if _, err := tx.ExecContext(ctx, createCategoryAttributeValueQuery, id, position, value); err != nil {
+			return domain.NewInternalError("fail to save category attribute allowed values", err)
+		}
+	}
+	return nil
+}
+
+func (c *categoryAttributeRepository) findAllowedValues(ctx context.Context, tx helperdb.Tx, categoryID int64, attributes []domain.AttributeDefinition) error {
+	byID := make(map[int64]*domain.AttributeDefinition, len(attributes))
+	for i := range attributes {
+		byID[attributes[i].ID] = &attributes[i]
+	}
+
+	rows, err := tx.QueryContext(ctx, findAllowedValuesByCategoryQuery, categoryID)
+	if err != nil {
+		return domain.NewInternalError("fail to get category attribute allowed values", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var value string
+		if err = rows.Scan(&id, &value); err != nil {
+			return domain.NewInternalError("fail to scan category attribute allowed value", err)
+		}
+		if attribute, ok := byID[id]; ok {
+			attribute.AllowedValues = append(attribute.AllowedValues, value)
+		}
+	}
+	return nil
+}
+
+func scanCategoryAttribute(row scanner) (domain.AttributeDefinition, error) {
+	var attribute domain.AttributeDefinition
+	err := row.Scan(
+		&attribute.ID,
+		&attribute.CategoryID,
+		&attribute.Name,
+		&attribute.Type,
+		&attribute.Required,
+		&attribute.CreatedAt,
+	)
+	return attribute, err
+}
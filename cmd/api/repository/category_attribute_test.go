@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var categoryAttributeRows = []string{
+	"id",
+	"category_id",
+	"name",
+	"type",
+	"required",
+	"created_at",
+}
+
+func InitialMockDBCategoryAttribute() domain.AttributeDefinition {
+	return domain.AttributeDefinition{
+		ID:            1,
+		CategoryID:    1,
+		Name:          "screen_size",
+		Type:          domain.AttributeNumber,
+		Required:      true,
+		AllowedValues: []string{"43", "55"},
+		CreatedAt:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func categoryAttributeRow(rows *sqlmock.Rows, attribute domain.AttributeDefinition) *sqlmock.Rows {
+	return rows.AddRow(
+		attribute.ID,
+		attribute.CategoryID,
+		attribute.Name,
+		attribute.Type,
+		attribute.Required,
+		attribute.CreatedAt,
+	)
+}
+
+func TestCreateCategoryAttribute_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	attribute := InitialMockDBCategoryAttribute()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createCategoryAttributeQuery)).
+		WithArgs(attribute.CategoryID, attribute.Name, attribute.Type, attribute.Required).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(QueryReplace(createCategoryAttributeValueQuery)).
+		WithArgs(attribute.ID, 0, "43").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(QueryReplace(createCategoryAttributeValueQuery)).
+		WithArgs(attribute.ID, 1, "55").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	id, err := NewCategoryAttributeRepository().Create(context.Background(), tx, attribute)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, int64(1), id)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestCreateCategoryAttribute_WithDuplicateName(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	attribute := InitialMockDBCategoryAttribute()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createCategoryAttributeQuery)).
+		WithArgs(attribute.CategoryID, attribute.Name, attribute.Type, attribute.Required).
+		WillReturnError(&mysqlDriver.MySQLError{Number: 1062, Message: "Duplicate entry"})
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = NewCategoryAttributeRepository().Create(context.Background(), tx, attribute)
+
+	var conflictErr *domain.ConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+	assert.EqualError(t, err, "attribute screen_size already exists in category with id 1")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindCategoryAttributesByCategory_WithResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	attribute := InitialMockDBCategoryAttribute()
+	smart := domain.AttributeDefinition{
+		ID:         2,
+		CategoryID: 1,
+		Name:       "smart",
+		Type:       domain.AttributeBoolean,
+		CreatedAt:  attribute.CreatedAt,
+	}
+
+	mock.ExpectQuery(QueryReplace(findByCategoryCategoryAttributeQuery)).
+		WithArgs(attribute.CategoryID).
+		WillReturnRows(categoryAttributeRow(categoryAttributeRow(sqlmock.NewRows(categoryAttributeRows), attribute), smart))
+	mock.ExpectQuery(regexp.QuoteMeta(findAllowedValuesByCategoryQuery)).
+		WithArgs(attribute.CategoryID).
+		WillReturnRows(sqlmock.NewRows([]string{"attribute_id", "value"}).
+			AddRow(attribute.ID, "43").
+			AddRow(attribute.ID, "55"))
+
+	attributes, err := NewCategoryAttributeRepository().FindByCategory(context.Background(), db, attribute.CategoryID)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []domain.AttributeDefinition{attribute, smart}, attributes)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindCategoryAttributesByCategory_WithoutResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findByCategoryCategoryAttributeQuery)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(categoryAttributeRows))
+
+	attributes, err := NewCategoryAttributeRepository().FindByCategory(context.Background(), db, 1)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Empty(t, attributes)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindCategoryAttributesByCategory_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findByCategoryCategoryAttributeQuery)).
+		WithArgs(int64(1)).
+		WillReturnError(sql.ErrConnDone)
+
+	_, err := NewCategoryAttributeRepository().FindByCategory(context.Background(), db, 1)
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindCategoryAttributesByNames_WithResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	attribute := InitialMockDBCategoryAttribute()
+	attribute.AllowedValues = nil
+	other := attribute
+	other.ID, other.CategoryID, other.Type = 4, 2, domain.AttributeString
+
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(findByNamesCategoryAttributeQuery, "?, ?"))).
+		WithArgs("screen_size", "smart").
+		WillReturnRows(categoryAttributeRow(categoryAttributeRow(sqlmock.NewRows(categoryAttributeRows), attribute), other))
+
+	attributes, err := NewCategoryAttributeRepository().FindByNames(context.Background(), db, []string{"screen_size", "smart"})
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []domain.AttributeDefinition{attribute, other}, attributes)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindCategoryAttributesByNames_WithoutNames(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	attributes, err := NewCategoryAttributeRepository().FindByNames(context.Background(), db, nil)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Empty(t, attributes)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestLockCategoryAttribute_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	attribute := InitialMockDBCategoryAttribute()
+	attribute.AllowedValues = nil
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(QueryReplace(lockCategoryAttributeQuery)).
+		WithArgs(attribute.CategoryID, attribute.ID).
+		WillReturnRows(categoryAttributeRow(sqlmock.NewRows(categoryAttributeRows), attribute))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	locked, err := NewCategoryAttributeRepository().Lock(context.Background(), tx, attribute.CategoryID, attribute.ID)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, attribute, locked)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestLockCategoryAttribute_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(QueryReplace(lockCategoryAttributeQuery)).
+		WithArgs(int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows(categoryAttributeRows))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = NewCategoryAttributeRepository().Lock(context.Background(), tx, 1, 2)
+
+	var notFoundErr *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+	assert.EqualError(t, err, "attribute with ID 2 of category with id 1 not found")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestUpdateCategoryAttribute_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	attribute := InitialMockDBCategoryAttribute()
+	attribute.Required = false
+	attribute.AllowedValues = []string{"65"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(updateCategoryAttributeQuery)).
+		WithArgs(false, attribute.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(QueryReplace(deleteCategoryAttributeValuesQuery)).
+		WithArgs(attribute.ID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(QueryReplace(createCategoryAttributeValueQuery)).
+		WithArgs(attribute.ID, 0, "65").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewCategoryAttributeRepository().Update(context.Background(), tx, attribute)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestDeleteCategoryAttribute_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(deleteCategoryAttributeQuery)).
+		WithArgs(int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewCategoryAttributeRepository().Delete(context.Background(), tx, 1, 2)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestDeleteCategoryAttribute_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(deleteCategoryAttributeQuery)).
+		WithArgs(int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewCategoryAttributeRepository().Delete(context.Background(), tx, 1, 2)
+
+	var notFoundErr *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
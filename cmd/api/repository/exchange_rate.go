@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+)
+
+type ExchangeRateRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, rate domain.ExchangeRate) error
+	FindAll(ctx context.Context, tx helperdb.Tx) ([]domain.ExchangeRate, error)
+	Find(ctx context.Context, tx helperdb.Tx, base string, quote string) (domain.ExchangeRate, error)
+}
+
+type exchangeRateRepository struct {
+}
+
+func NewExchangeRateRepository() ExchangeRateRepository {
+	return &exchangeRateRepository{}
+}
+
+const (
+	saveExchangeRateQuery     = "INSERT INTO exchange_rates (base_currency, quote_currency, rate, updated_at) VALUES (?, ?, ?, NOW()) ON DUPLICATE KEY UPDATE rate = VALUES(rate), updated_at = NOW()"
+	findAllExchangeRatesQuery = "SELECT base_currency, quote_currency, rate, updated_at FROM exchange_rates ORDER BY base_currency, quote_currency"
+	findExchangeRateQuery     = "SELECT base_currency, quote_currency, rate, updated_at FROM exchange_rates WHERE base_currency = ? AND quote_currency = ?"
+)
+
+func (e *exchangeRateRepository) Save(ctx context.Context, tx *sql.Tx, rate domain.ExchangeRate) error {
+	_, err := tx.ExecContext(ctx, saveExchangeRateQuery, rate.Base, rate.Quote, rate.Rate)
+	if err != nil {
+		return domain.NewInternalError("fail to save exchange rate", err)
+	}
+	return nil
+}
+
+func (e *exchangeRateRepository) FindAll(ctx context.Context, tx helperdb.Tx) ([]domain.ExchangeRate, error) {
+	rows, err := tx.QueryContext(ctx, findAllExchangeRatesQuery)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get exchange rates", err)
+	}
+	defer rows.Close()
+
+	rates := []domain.ExchangeRate{}
+	for rows.Next() {
+		rate, err := scanExchangeRate(rows)
+		if err != nil {
+			return nil, domain.NewInternalError("fail to scan exchange rate", err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+func (e *exchangeRateRepository) Find(ctx context.Context, tx helperdb.Tx, base string, quote string) (domain.ExchangeRate, error) {
+	rate, err := scanExchangeRate(tx.QueryRowContext(ctx, findExchangeRateQuery, base, quote))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ExchangeRate{}, domain.NewNotFoundError(fmt.Sprintf("exchange rate from %s to %s not found", base, quote), err)
+		}
+		return domain.ExchangeRate{}, domain.NewInternalError("fail to get exchange rate", err)
+	}
+	return rate, nil
+}
+
+func scanExchangeRate(row scanner) (domain.ExchangeRate, error) {
+	var rate domain.ExchangeRate
+	err := row.Scan(
+		&rate.Base,
+		&rate.Quote,
+		&rate.Rate,
+		&rate.UpdatedAt,
+	)
+	return rate, err
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var exchangeRateRows = []string{
+	"base_currency",
+	"quote_currency",
+	"rate",
+	"updated_at",
+}
+
+func InitialMockDBExchangeRate() domain.ExchangeRate {
+	return domain.ExchangeRate{
+		Base:      "BRL",
+		Quote:     "ARS",
+		Rate:      money.MustParseDecimal("180.25000000"),
+		UpdatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestSaveExchangeRate_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	rate := InitialMockDBExchangeRate()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(saveExchangeRateQuery)).
+		WithArgs(rate.Base, rate.Quote, rate.Rate).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewExchangeRateRepository().Save(context.Background(), tx, rate)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindAllExchangeRates_WithResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	rate := InitialMockDBExchangeRate()
+
+	mock.ExpectQuery(QueryReplace(findAllExchangeRatesQuery)).
+		WillReturnRows(sqlmock.NewRows(exchangeRateRows).
+			AddRow(rate.Base, rate.Quote, rate.Rate.String(), rate.UpdatedAt))
+
+	rates, err := NewExchangeRateRepository().FindAll(context.Background(), db)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []domain.ExchangeRate{rate}, rates)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindExchangeRate_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findExchangeRateQuery)).
+		WithArgs("BRL", "MXN").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := NewExchangeRateRepository().Find(context.Background(), db, "BRL", "MXN")
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "exchange rate from BRL to MXN not found", notFound.Message)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindExchangeRate_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findExchangeRateQuery)).
+		WithArgs("BRL", "ARS").
+		WillReturnError(sql.ErrConnDone)
+
+	_, err := NewExchangeRateRepository().Find(context.Background(), db, "BRL", "ARS")
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
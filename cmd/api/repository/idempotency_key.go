@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+)
+
+type IdempotencyKeyRepository interface {
+	// Create reserves the key for lease. It returns a *domain.ConflictError
+	// when the key is already reserved.
+	Create(ctx context.Context, tx *sql.Tx, key domain.IdempotencyKey, lease time.Duration) error
+	// FindByKey returns the key unless it is expired.
+	FindByKey(ctx context.Context, tx helperdb.Tx, scope string, key string) (domain.IdempotencyKey, error)
+	// SaveResponse stores the response of the key and keeps it for ttl.
+	SaveResponse(ctx context.Context, tx *sql.Tx, key domain.IdempotencyKey, ttl time.Duration) error
+	Delete(ctx context.Context, tx *sql.Tx, scope string, key string) error
+	DeleteExpired(ctx context.Context, tx *sql.Tx, scope string, key string) error
+	PurgeExpired(ctx context.Context, tx *sql.Tx, limit int) (int64, error)
+}
+
+type idempotencyKeyRepository struct {
+}
+
+func NewIdempotencyKeyRepository() IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{}
+}
+
+const (
+	createIdempotencyKeyQuery        = "INSERT INTO idempotency_keys (scope, idempotency_key, request_hash, created_at, expires_at) VALUES (?, ?, ?, NOW(), DATE_ADD(NOW(), INTERVAL ? SECOND))"
+	findByKeyIdempotencyKeyQuery     = "SELECT scope, idempotency_key, request_hash, status_code, response_body, response_headers, created_at, expires_at FROM idempotency_keys WHERE scope = ? AND idempotency_key = ? AND expires_at > NOW()"
+	saveResponseIdempotencyKeyQuery  = "UPDATE idempotency_keys SET status_code = ?, response_body = ?, response_headers = ?, expires_at = DATE_ADD(NOW(), INTERVAL ? SECOND) WHERE scope = ? AND idempotency_key = ?"
+	deleteIdempotencyKeyQuery        = "DELETE FROM idempotency_keys WHERE scope = ? AND idempotency_key = ?"
+	deleteExpiredIdempotencyKeyQuery = "DELETE FROM idempotency_keys WHERE scope = ? AND idempotency_key = ? AND expires_at <= NOW()"
+	purgeExpiredIdempotencyKeysQuery = "DELETE FROM idempotency_keys WHERE expires_at <= NOW() LIMIT ?"
+)
+
+func (i *idempotencyKeyRepository) Create(ctx context.Context, tx *sql.Tx, key domain.IdempotencyKey, lease time.Duration) error {
+	_, err := tx.ExecContext(ctx, createIdempotencyKeyQuery, key.Scope, key.Key, key.RequestHash, int64(lease.Seconds()))
+	if err != nil {
+		if mysql.IsDuplicateEntry(err) {
+			return domain.NewConflictError("idempotency key already in use", err)
+		}
+		return domain.NewInternalError("fail to create idempotency key", err)
+	}
+	return nil
+}
+
+func (i *idempotencyKeyRepository) FindByKey(ctx context.Context, tx helperdb.Tx, scope string, key string) (domain.IdempotencyKey, error) {
+	var idempotencyKey domain.IdempotencyKey
+	var statusCode sql.NullInt64
+	var responseHeaders []byte
+	err := tx.QueryRowContext(ctx, findByKeyIdempotencyKeyQuery, scope, key).Scan(
+		&idempotencyKey.Scope,
+		&idempotencyKey.Key,
+		&idempotencyKey.RequestHash,
+		&statusCode,
+		&idempotencyKey.ResponseBody,
+		&responseHeaders,
+		&idempotencyKey.CreatedAt,
+		&idempotencyKey.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.IdempotencyKey{}, domain.NewNotFoundError("idempotency key not found", err)
+		}
+		return domain.IdempotencyKey{}, domain.NewInternalError("fail to get idempotency key", err)
+	}
+	idempotencyKey.StatusCode = int(statusCode.Int64)
+	if len(responseHeaders) > 0 {
+		if err = json.Unmarshal(responseHeaders, &idempotencyKey.ResponseHeaders); err != nil {
+			return domain.IdempotencyKey{}, domain.NewInternalError("fail to decode idempotent response headers", err)
+		}
+	}
+	return idempotencyKey, nil
+}
+
+func (i *idempotencyKeyRepository) SaveResponse(ctx context.Context, tx *sql.Tx, key domain.IdempotencyKey, ttl time.Duration) error {
+	responseHeaders, err := json.Marshal(key.ResponseHeaders)
+	if err != nil {
+		return domain.NewInternalError("fail to encode idempotent response headers", err)
+	}
+	_, err = tx.ExecContext(ctx, saveResponseIdempotencyKeyQuery, key.StatusCode, key.ResponseBody, responseHeaders, int64(ttl.Seconds()), key.Scope, key.Key)
+	if err != nil {
+		return domain.NewInternalError("fail to save idempotent response", err)
+	}
+	return nil
+}
+
+func (i *idempotencyKeyRepository) Delete(ctx context.Context, tx *sql.Tx, scope string, key string) error {
+	_, err := tx.ExecContext(ctx, deleteIdempotencyKeyQuery, scope, key)
+	if err != nil {
+		return domain.NewInternalError("fail to delete idempotency key", err)
+	}
+	return nil
+}
+
+func (i *idempotencyKeyRepository) DeleteExpired(ctx context.Context, tx *sql.Tx, scope string, key string) error {
+	_, err := tx.ExecContext(ctx, deleteExpiredIdempotencyKeyQuery, scope, key)
+	if err != nil {
+		return domain.NewInternalError("fail to delete expired idempotency key", err)
+	}
+	return nil
+}
+
+func (i *idempotencyKeyRepository) PurgeExpired(ctx context.Context, tx *sql.Tx, limit int) (int64, error) {
+	res, err := tx.ExecContext(ctx, purgeExpiredIdempotencyKeysQuery, limit)
+	if err != nil {
+		return 0, domain.NewInternalError("fail to purge expired idempotency keys", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, domain.NewInternalError("fail to get rows affected", err)
+	}
+	return rowsAffected, nil
+}
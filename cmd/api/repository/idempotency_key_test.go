@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var idempotencyKeyRows = []string{
+	"scope",
+	"idempotency_key",
+	"request_hash",
+	"status_code",
+	"response_body",
+	"response_headers",
+	"created_at",
+	"expires_at",
+}
+
+func InitialMockDBIdempotencyKey() domain.IdempotencyKey {
+	return domain.IdempotencyKey{
+		Scope:       "ck_0123abcd POST /product",
+		Key:         "3f1c2a9e-6d4b-4c1e-9a57-0c7b2d1e8f90",
+		RequestHash: "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		CreatedAt:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		ExpiresAt:   time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCreateIdempotencyKey_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	key := InitialMockDBIdempotencyKey()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createIdempotencyKeyQuery)).
+		WithArgs(key.Scope, key.Key, key.RequestHash, int64(86400)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewIdempotencyKeyRepository().Create(context.Background(), tx, key, 24*time.Hour)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestCreateIdempotencyKey_AlreadyReserved(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	key := InitialMockDBIdempotencyKey()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createIdempotencyKeyQuery)).
+		WithArgs(key.Scope, key.Key, key.RequestHash, int64(86400)).
+		WillReturnError(&mysqlDriver.MySQLError{Number: 1062, Message: "Duplicate entry"})
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewIdempotencyKeyRepository().Create(context.Background(), tx, key, 24*time.Hour)
+
+	var conflictErr *domain.ConflictError
+	assert.ErrorAs(t, err, &conflictErr)
+}
+
+func TestFindIdempotencyKeyByKey_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	key := InitialMockDBIdempotencyKey()
+	key.StatusCode = 201
+	key.ResponseBody = []byte(`{"id":1}`)
+	key.ResponseHeaders = map[string]string{"Content-Type": "application/json", "Location": "/product/1"}
+
+	mock.ExpectQuery(QueryReplace(findByKeyIdempotencyKeyQuery)).WithArgs(key.Scope, key.Key).
+		WillReturnRows(sqlmock.NewRows(idempotencyKeyRows).AddRow(
+			key.Scope, key.Key, key.RequestHash, key.StatusCode, key.ResponseBody,
+			[]byte(`{"Content-Type":"application/json","Location":"/product/1"}`), key.CreatedAt, key.ExpiresAt,
+		))
+
+	got, err := NewIdempotencyKeyRepository().FindByKey(context.Background(), db, key.Scope, key.Key)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, key, got)
+	assert.True(t, got.Completed())
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindIdempotencyKeyByKey_InProgress(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	key := InitialMockDBIdempotencyKey()
+
+	mock.ExpectQuery(QueryReplace(findByKeyIdempotencyKeyQuery)).WithArgs(key.Scope, key.Key).
+		WillReturnRows(sqlmock.NewRows(idempotencyKeyRows).AddRow(
+			key.Scope, key.Key, key.RequestHash, nil, nil, nil, key.CreatedAt, key.ExpiresAt,
+		))
+
+	got, err := NewIdempotencyKeyRepository().FindByKey(context.Background(), db, key.Scope, key.Key)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.False(t, got.Completed())
+}
+
+func TestFindIdempotencyKeyByKey_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	key := InitialMockDBIdempotencyKey()
+
+	mock.ExpectQuery(QueryReplace(findByKeyIdempotencyKeyQuery)).WithArgs(key.Scope, key.Key).
+		WillReturnRows(sqlmock.NewRows(idempotencyKeyRows))
+
+	_, err := NewIdempotencyKeyRepository().FindByKey(context.Background(), db, key.Scope, key.Key)
+
+	var notFoundErr *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFoundErr)
+}
+
+func TestSaveIdempotentResponse_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	key := InitialMockDBIdempotencyKey()
+	key.StatusCode = 201
+	key.ResponseBody = []byte(`{"id":1}`)
+	key.ResponseHeaders = map[string]string{"Content-Type": "application/problem+json"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(saveResponseIdempotencyKeyQuery)).
+		WithArgs(key.StatusCode, key.ResponseBody, []byte(`{"Content-Type":"application/problem+json"}`), int64(86400), key.Scope, key.Key).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewIdempotencyKeyRepository().SaveResponse(context.Background(), tx, key, 24*time.Hour)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestPurgeExpiredIdempotencyKeys_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(purgeExpiredIdempotencyKeysQuery)).WithArgs(1000).
+		WillReturnError(errors.New("timeout"))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = NewIdempotencyKeyRepository().PurgeExpired(context.Background(), tx, 1000)
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+)
+
+type MigrationRepository interface {
+	FindAppliedVersions(ctx context.Context, tx helperdb.Tx) (map[string]bool, error)
+}
+
+type migrationRepository struct {
+}
+
+func NewMigrationRepository() MigrationRepository {
+	return &migrationRepository{}
+}
+
+const (
+	findAppliedMigrationsQuery = "SELECT version FROM schema_migrations"
+)
+
+func (m *migrationRepository) FindAppliedVersions(ctx context.Context, tx helperdb.Tx) (map[string]bool, error) {
+	rows, err := tx.QueryContext(ctx, findAppliedMigrationsQuery)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get applied migrations", err)
+	}
+	defer rows.Close()
+
+	versions := map[string]bool{}
+	for rows.Next() {
+		var version string
+		if err = rows.Scan(&version); err != nil {
+			return nil, domain.NewInternalError("fail to scan migration version", err)
+		}
+		versions[version] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil, domain.NewInternalError("fail to read migration versions", err)
+	}
+	return versions, nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAppliedVersions_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findAppliedMigrationsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("20230908185135527").AddRow("20230911193823332"))
+
+	repo := NewMigrationRepository()
+	versions, err := repo.FindAppliedVersions(context.Background(), db)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, map[string]bool{"20230908185135527": true, "20230911193823332": true}, versions)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindAppliedVersions_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findAppliedMigrationsQuery)).WillReturnError(sql.ErrConnDone)
+
+	repo := NewMigrationRepository()
+	_, err := repo.FindAppliedVersions(context.Background(), db)
+
+	assert.Error(t, err, "Error should be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindAppliedVersions_WithRowError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findAppliedMigrationsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("20230908185135527").RowError(0, sql.ErrConnDone))
+
+	repo := NewMigrationRepository()
+	_, err := repo.FindAppliedVersions(context.Background(), db)
+
+	assert.Error(t, err, "Error should be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
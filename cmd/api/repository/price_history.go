@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+)
+
+type PriceHistoryRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, change domain.PriceChange) (int64, error)
+	FindByProduct(ctx context.Context, tx helperdb.Tx, productID int64, filter domain.PriceHistoryFilter) ([]domain.PriceChange, error)
+	LowestPriceSince(ctx context.Context, tx helperdb.Tx, product domain.Product, days int) (money.Decimal, error)
+}
+
+type priceHistoryRepository struct {
+}
+
+func NewPriceHistoryRepository() PriceHistoryRepository {
+	return &priceHistoryRepository{}
+}
+
+const (
+	createPriceChangeQuery         = "INSERT INTO price_history (product_id, old_price, old_currency, new_price, new_currency, price_list, changed_by, reason, changed_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW())"
+	findByProductPriceHistoryQuery = "SELECT id, product_id, old_price, old_currency, new_price, new_currency, price_list, changed_by, reason, changed_at FROM price_history WHERE product_id = ?"
+	lowestOldPriceQuery            = "SELECT MIN(old_price) FROM price_history WHERE product_id = ? AND old_currency = ? AND price_list = FALSE AND changed_at >= DATE_SUB(NOW(), INTERVAL ? DAY)"
+)
+
+func (p *priceHistoryRepository) Create(ctx context.Context, tx *sql.Tx, change domain.PriceChange) (int64, error) {
+	res, err := tx.ExecContext(
+		ctx,
+		createPriceChangeQuery,
+		change.ProductID,
+		change.OldPrice,
+		change.OldCurrency,
+		change.NewPrice,
+		change.NewCurrency,
+		change.PriceList,
+		change.ChangedBy,
+		change.Reason,
+	)
+	if err != nil {
+		return 0, domain.NewInternalError("fail to create price change", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, domain.NewInternalError("fail to get last insert id", err)
+	}
+	return id, nil
+}
+
+// FindByProduct returns the price changes of a product within filter, most
+// recent first.
+func (p *priceHistoryRepository) FindByProduct(ctx context.Context, tx helperdb.Tx, productID int64, filter domain.PriceHistoryFilter) ([]domain.PriceChange, error) {
+	var query bytes.Buffer
+	query.WriteString(findByProductPriceHistoryQuery)
+	queryParams := []interface{}{productID}
+	if filter.From != nil {
+		query.WriteString(" AND changed_at >= ?")
+		queryParams = append(queryParams, *filter.From)
+	}
+	if filter.To != nil {
+		query.WriteString(" AND changed_at < ?")
+		queryParams = append(queryParams, *filter.To)
+	}
+	query.WriteString(" ORDER BY changed_at DESC, id DESC")
+
+	rows, err := tx.QueryContext(ctx, query.String(), queryParams...)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get price history", err)
+	}
+	defer rows.Close()
+
+	changes := []domain.PriceChange{}
+	for rows.Next() {
+		var change domain.PriceChange
+		err = rows.Scan(
+			&change.ID,
+			&change.ProductID,
+			&change.OldPrice,
+			&change.OldCurrency,
+			&change.NewPrice,
+			&change.NewCurrency,
+			&change.PriceList,
+			&change.ChangedBy,
+			&change.Reason,
+			&change.ChangedAt,
+		)
+		if err != nil {
+			return nil, domain.NewInternalError("fail to scan price change", err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// LowestPriceSince returns the lowest base price product had in its current
+// currency over the last days. Every price in effect in that window is either
+// the current one or the old price of a change made within it.
+func (p *priceHistoryRepository) LowestPriceSince(ctx context.Context, tx helperdb.Tx, product domain.Product, days int) (money.Decimal, error) {
+	var lowest sql.NullString
+	err := tx.QueryRowContext(ctx, lowestOldPriceQuery, product.ID, product.Currency, days).Scan(&lowest)
+	if err != nil {
+		return money.Decimal{}, domain.NewInternalError("fail to get lowest price", err)
+	}
+	if !lowest.Valid {
+		return product.Price, nil
+	}
+	oldPrice, err := money.ParseDecimal(lowest.String)
+	if err != nil {
+		return money.Decimal{}, domain.NewInternalError("fail to get lowest price", err)
+	}
+	if oldPrice.Cmp(product.Price) < 0 {
+		return oldPrice, nil
+	}
+	return product.Price, nil
+}
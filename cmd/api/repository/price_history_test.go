@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var priceHistoryRows = []string{
+	"id",
+	"product_id",
+	"old_price",
+	"old_currency",
+	"new_price",
+	"new_currency",
+	"price_list",
+	"changed_by",
+	"reason",
+	"changed_at",
+}
+
+func InitialMockDBPriceChange() domain.PriceChange {
+	oldPrice := money.MustParseDecimal("12.90")
+	newPrice := money.MustParseDecimal("10.50")
+	return domain.PriceChange{
+		ID:          1,
+		ProductID:   1,
+		OldPrice:    &oldPrice,
+		OldCurrency: "BRL",
+		NewPrice:    &newPrice,
+		NewCurrency: "BRL",
+		ChangedBy:   "integration-1",
+		Reason:      "black friday",
+		ChangedAt:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCreatePriceChange_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	change := InitialMockDBPriceChange()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createPriceChangeQuery)).
+		WithArgs(change.ProductID, change.OldPrice, change.OldCurrency, change.NewPrice, change.NewCurrency, change.PriceList, change.ChangedBy, change.Reason).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	id, err := NewPriceHistoryRepository().Create(context.Background(), tx, change)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, int64(1), id)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestCreatePriceChange_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	change := InitialMockDBPriceChange()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createPriceChangeQuery)).
+		WithArgs(change.ProductID, change.OldPrice, change.OldCurrency, change.NewPrice, change.NewCurrency, change.PriceList, change.ChangedBy, change.Reason).
+		WillReturnError(sql.ErrConnDone)
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = NewPriceHistoryRepository().Create(context.Background(), tx, change)
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindPriceHistoryByProduct_WithResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	change := InitialMockDBPriceChange()
+
+	mock.ExpectQuery(QueryReplace(findByProductPriceHistoryQuery + " ORDER BY changed_at DESC, id DESC")).
+		WithArgs(change.ProductID).
+		WillReturnRows(sqlmock.NewRows(priceHistoryRows).
+			AddRow(change.ID, change.ProductID, change.OldPrice.String(), change.OldCurrency, change.NewPrice.String(), change.NewCurrency, change.PriceList, change.ChangedBy, change.Reason, change.ChangedAt))
+
+	changes, err := NewPriceHistoryRepository().FindByProduct(context.Background(), db, change.ProductID, domain.PriceHistoryFilter{})
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []domain.PriceChange{change}, changes)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindPriceHistoryByProduct_WithNewListPrice(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	change := InitialMockDBPriceChange()
+	change.OldPrice = nil
+	change.OldCurrency = "USD"
+	change.NewCurrency = "USD"
+	change.PriceList = true
+
+	mock.ExpectQuery(QueryReplace(findByProductPriceHistoryQuery + " ORDER BY changed_at DESC, id DESC")).
+		WithArgs(change.ProductID).
+		WillReturnRows(sqlmock.NewRows(priceHistoryRows).
+			AddRow(change.ID, change.ProductID, nil, change.OldCurrency, change.NewPrice.String(), change.NewCurrency, change.PriceList, change.ChangedBy, change.Reason, change.ChangedAt))
+
+	changes, err := NewPriceHistoryRepository().FindByProduct(context.Background(), db, change.ProductID, domain.PriceHistoryFilter{})
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []domain.PriceChange{change}, changes)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindPriceHistoryByProduct_WithDateFilter(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(QueryReplace(findByProductPriceHistoryQuery+" AND changed_at >= ? AND changed_at < ? ORDER BY changed_at DESC, id DESC")).
+		WithArgs(int64(1), from, to).
+		WillReturnRows(sqlmock.NewRows(priceHistoryRows))
+
+	changes, err := NewPriceHistoryRepository().FindByProduct(context.Background(), db, 1, domain.PriceHistoryFilter{From: &from, To: &to})
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Empty(t, changes)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindPriceHistoryByProduct_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findByProductPriceHistoryQuery + " ORDER BY changed_at DESC, id DESC")).
+		WithArgs(int64(1)).
+		WillReturnError(sql.ErrConnDone)
+
+	_, err := NewPriceHistoryRepository().FindByProduct(context.Background(), db, 1, domain.PriceHistoryFilter{})
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestLowestPriceSince_WithLowerOldPrice(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	product := domain.Product{ID: 1, Price: money.MustParseDecimal("10.50"), Currency: "BRL"}
+
+	mock.ExpectQuery(QueryReplace(lowestOldPriceQuery)).
+		WithArgs(product.ID, product.Currency, 30).
+		WillReturnRows(sqlmock.NewRows([]string{"MIN(old_price)"}).AddRow("9.9000"))
+
+	lowest, err := NewPriceHistoryRepository().LowestPriceSince(context.Background(), db, product, 30)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, "9.9000", lowest.String())
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestLowestPriceSince_WithoutChanges(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	product := domain.Product{ID: 1, Price: money.MustParseDecimal("10.50"), Currency: "BRL"}
+
+	mock.ExpectQuery(QueryReplace(lowestOldPriceQuery)).
+		WithArgs(product.ID, product.Currency, 30).
+		WillReturnRows(sqlmock.NewRows([]string{"MIN(old_price)"}).AddRow(nil))
+
+	lowest, err := NewPriceHistoryRepository().LowestPriceSince(context.Background(), db, product, 30)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, product.Price, lowest)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestLowestPriceSince_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	product := domain.Product{ID: 1, Price: money.MustParseDecimal("10.50"), Currency: "BRL"}
+
+	mock.ExpectQuery(QueryReplace(lowestOldPriceQuery)).
+		WithArgs(product.ID, product.Currency, 30).
+		WillReturnError(sql.ErrConnDone)
+
+	_, err := NewPriceHistoryRepository().LowestPriceSince(context.Background(), db, product, 30)
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
@@ -16,8 +17,12 @@ type ProductRepository interface {
 	Create(ctx context.Context, tx *sql.Tx, product domain.Product) (int64, error)
 	FindAll(ctx context.Context, tx helperdb.Tx, params dto.SearchParams) ([]domain.Product, int64, error)
 	FindByID(ctx context.Context, tx helperdb.Tx, id int64) (domain.Product, error)
-	FindByCategory(ctx context.Context, tx helperdb.Tx, categoryID int64) ([]domain.Product, error)
+	FindAnyByID(ctx context.Context, tx helperdb.Tx, id int64) (domain.Product, error)
+	Lock(ctx context.Context, tx *sql.Tx, id int64) (domain.Product, error)
+	FindByCategory(ctx context.Context, tx helperdb.Tx, categoryID int64, status string) ([]domain.Product, error)
 	Update(ctx context.Context, tx *sql.Tx, product domain.Product) (int64, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, id int64, status string) error
+	SetImage(ctx context.Context, tx *sql.Tx, id int64, image string) error
 	Delete(ctx context.Context, tx *sql.Tx, id int64) (int64, error)
 }
 
@@ -29,12 +34,26 @@ func NewProductRepository() ProductRepository {
 }
 
 const (
-	createProductQuery   = "INSERT INTO products (title, description, price, image, created_at, category_id) VALUES ( ?, ?, ?, ?, NOW(), ?)"
-	findByIDProductQuery = "SELECT id, title, description, price, image, created_at, category_id FROM products WHERE id = ?"
-	findAllProductsQuery = "SELECT id, title, description, price, image, created_at, category_id FROM products"
-	findByCategoryQuery  = "SELECT id, title, description, price, image, created_at, category_id FROM products WHERE category_id = ?"
-	updateProductQuery   = "UPDATE products SET title = ?, description = ?, price = ?, image = ?, category_id = ? WHERE id = ?"
-	deleteProductQuery   = "DELETE FROM products WHERE id = ?"
+	productColumns = "id, title, description, price, currency, image, created_at, category_id, status, publish_at, unpublish_at"
+	// productWindowCondition keeps reads to the products whose publication
+	// window contains the current time.
+	productWindowCondition = "(publish_at IS NULL OR publish_at <= NOW()) AND (unpublish_at IS NULL OR unpublish_at > NOW())"
+
+	createProductQuery       = "INSERT INTO products (title, description, price, currency, image, created_at, category_id, status, publish_at, unpublish_at) VALUES ( ?, ?, ?, ?, ?, NOW(), ?, ?, ?, ?)"
+	findByIDProductQuery     = "SELECT " + productColumns + " FROM products WHERE id = ? AND status = 'published' AND " + productWindowCondition
+	findAnyByIDProductQuery  = "SELECT " + productColumns + " FROM products WHERE id = ?"
+	lockProductQuery         = "SELECT " + productColumns + " FROM products WHERE id = ? FOR UPDATE"
+	findAllProductsQuery     = "SELECT " + productColumns + " FROM products"
+	countProductsQuery       = "SELECT COUNT(*) FROM products WHERE 1=1"
+	findByCategoryQuery      = "SELECT " + productColumns + " FROM products WHERE category_id = ? AND " + productWindowCondition
+	updateProductQuery       = "UPDATE products SET title = ?, description = ?, price = ?, currency = ?, image = ?, category_id = ?, publish_at = ?, unpublish_at = ? WHERE id = ?"
+	updateProductStatusQuery = "UPDATE products SET status = ? WHERE id = ?"
+	setProductImageQuery     = "UPDATE products SET image = ? WHERE id = ?"
+	deleteProductQuery       = "DELETE FROM products WHERE id = ?"
+
+	// productAttributeCondition keeps the products with one of a list of
+	// attribute values, given as (attribute_id, value) pairs.
+	productAttributeCondition = "EXISTS (SELECT 1 FROM product_attributes pa WHERE pa.product_id = products.id AND (pa.attribute_id, pa.value) IN (%s))"
 )
 
 func (p *productRepository) Create(ctx context.Context, tx *sql.Tx, product domain.Product) (int64, error) {
@@ -44,11 +63,15 @@ func (p *productRepository) Create(ctx context.Context, tx *sql.Tx, product doma
 		product.Title,
 		product.Description,
 		product.Price,
+		product.Currency,
 		product.Image,
 		product.CategoryID,
+		product.Status,
+		product.PublishAt,
+		product.UnpublishAt,
 	)
 	if err != nil {
-		return 0, domain.NewInternalError(err.Error(), err)
+		return 0, domain.NewInternalError("fail to create product", err)
 	}
 	id, err := res.LastInsertId()
 	if err != nil {
@@ -61,9 +84,22 @@ func (p *productRepository) FindAll(ctx context.Context, tx helperdb.Tx, params
 	query, queryParams := GetSearchQuery(params, findAllProductsQuery)
 	var products []domain.Product
 	var total int64
-	err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM products").Scan(&total)
+	countQuery, countParams := countProductsQuery, []interface{}{}
+	if params.Public {
+		countQuery += " AND " + productWindowCondition
+	}
+	if params.Status != nil {
+		countQuery += " AND status = ?"
+		countParams = append(countParams, *params.Status)
+	}
+	for _, name := range params.AttributeNames() {
+		condition, args := attributeCondition(params, name)
+		countQuery += " AND " + condition
+		countParams = append(countParams, args...)
+	}
+	err := tx.QueryRowContext(ctx, countQuery, countParams...).Scan(&total)
 	if err != nil {
-		return nil, 0, domain.NewInternalError(err.Error(), err)
+		return nil, 0, domain.NewInternalError("fail to count products", err)
 	}
 	rows, err := tx.QueryContext(ctx, query.String(), queryParams...)
 	if err != nil {
@@ -71,16 +107,7 @@ func (p *productRepository) FindAll(ctx context.Context, tx helperdb.Tx, params
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var product domain.Product
-		err = rows.Scan(
-			&product.ID,
-			&product.Title,
-			&product.Description,
-			&product.Price,
-			&product.Image,
-			&product.CreatedAt,
-			&product.CategoryID,
-		)
+		product, err := scanProduct(rows)
 		if err != nil {
 			return nil, 0, domain.NewInternalError("fail to scan row", err)
 		}
@@ -92,31 +119,54 @@ func (p *productRepository) FindAll(ctx context.Context, tx helperdb.Tx, params
 	return products, total, nil
 }
 
+// FindByID returns a published product whose publication window contains the
+// current time.
 func (p *productRepository) FindByID(ctx context.Context, tx helperdb.Tx, id int64) (domain.Product, error) {
-	row := tx.QueryRowContext(ctx, findByIDProductQuery, id)
-	var product domain.Product
-	err := row.Scan(
-		&product.ID,
-		&product.Title,
-		&product.Description,
-		&product.Price,
-		&product.Image,
-		&product.CreatedAt,
-		&product.CategoryID,
-	)
+	return p.findByID(ctx, tx, findByIDProductQuery, id)
+}
+
+// FindAnyByID returns a product whatever its status and publication window,
+// for admin reads.
+func (p *productRepository) FindAnyByID(ctx context.Context, tx helperdb.Tx, id int64) (domain.Product, error) {
+	return p.findByID(ctx, tx, findAnyByIDProductQuery, id)
+}
+
+func (p *productRepository) findByID(ctx context.Context, tx helperdb.Tx, query string, id int64) (domain.Product, error) {
+	product, err := scanProduct(tx.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return product, domain.NewNotFoundError(fmt.Sprintf("product with ID %d not found", id), err)
+		}
+		return product, domain.NewInternalError("fail to get product", err)
+	}
+	return product, nil
+}
+
+// Lock reads a product whatever its publication window and locks it until tx
+// ends. Writes use it, so products can be changed before they go live and
+// after they are taken down.
+func (p *productRepository) Lock(ctx context.Context, tx *sql.Tx, id int64) (domain.Product, error) {
+	product, err := scanProduct(tx.QueryRowContext(ctx, lockProductQuery, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return product, domain.NewNotFoundError(fmt.Sprintf("product with ID %d not found", id), err)
 		}
-		return product, domain.NewInternalError(err.Error(), err)
+		return product, domain.NewInternalError("fail to get product", err)
 	}
 	return product, nil
 }
 
-func (p *productRepository) FindByCategory(ctx context.Context, tx helperdb.Tx, categoryID int64) ([]domain.Product, error) {
-	rows, err := tx.QueryContext(ctx, findByCategoryQuery, categoryID)
+// FindByCategory returns the products of a category. An empty status returns
+// them whatever their status.
+func (p *productRepository) FindByCategory(ctx context.Context, tx helperdb.Tx, categoryID int64, status string) ([]domain.Product, error) {
+	query, queryParams := findByCategoryQuery, []interface{}{categoryID}
+	if status != "" {
+		query += " AND status = ?"
+		queryParams = append(queryParams, status)
+	}
+	rows, err := tx.QueryContext(ctx, query, queryParams...)
 	if err != nil {
-		return nil, domain.NewInternalError(err.Error(), err)
+		return nil, domain.NewInternalError("fail to get products by category", err)
 	}
 	defer rows.Close()
 	var products []domain.Product
@@ -124,16 +174,7 @@ func (p *productRepository) FindByCategory(ctx context.Context, tx helperdb.Tx,
 	isNotFound := true
 
 	for rows.Next() {
-		var product domain.Product
-		err = rows.Scan(
-			&product.ID,
-			&product.Title,
-			&product.Description,
-			&product.Price,
-			&product.Image,
-			&product.CreatedAt,
-			&product.CategoryID,
-		)
+		product, err := scanProduct(rows)
 		if err != nil {
 			return nil, domain.NewInternalError("fail to scan row", err)
 		}
@@ -155,12 +196,15 @@ func (p *productRepository) Update(ctx context.Context, tx *sql.Tx, product doma
 		product.Title,
 		product.Description,
 		product.Price,
+		product.Currency,
 		product.Image,
 		product.CategoryID,
+		product.PublishAt,
+		product.UnpublishAt,
 		product.ID,
 	)
 	if err != nil {
-		return 0, domain.NewInternalError(err.Error(), err)
+		return 0, domain.NewInternalError("fail to update product", err)
 	}
 	rowsAffected, err := res.RowsAffected()
 	if err != nil {
@@ -172,10 +216,35 @@ func (p *productRepository) Update(ctx context.Context, tx *sql.Tx, product doma
 	return rowsAffected, nil
 }
 
+// UpdateStatus moves a product to status. Transitions are checked by the
+// caller.
+func (p *productRepository) UpdateStatus(ctx context.Context, tx *sql.Tx, id int64, status string) error {
+	res, err := tx.ExecContext(ctx, updateProductStatusQuery, status, id)
+	if err != nil {
+		return domain.NewInternalError("fail to update product status", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return domain.NewInternalError("fail to get rows affected", err)
+	}
+	if rowsAffected == 0 {
+		return domain.NewNotFoundError(fmt.Sprintf("product with ID %d not found", id), nil)
+	}
+	return nil
+}
+
+// SetImage sets the image of a product to the URL of its primary image.
+func (p *productRepository) SetImage(ctx context.Context, tx *sql.Tx, id int64, image string) error {
+	if _, err := tx.ExecContext(ctx, setProductImageQuery, image, id); err != nil {
+		return domain.NewInternalError("fail to update product", err)
+	}
+	return nil
+}
+
 func (p *productRepository) Delete(ctx context.Context, tx *sql.Tx, id int64) (int64, error) {
 	res, err := tx.ExecContext(ctx, deleteProductQuery, id)
 	if err != nil {
-		return 0, domain.NewInternalError(err.Error(), err)
+		return 0, domain.NewInternalError("fail to delete product", err)
 	}
 	rowsAffected, err := res.RowsAffected()
 	if err != nil {
@@ -193,11 +262,30 @@ func GetSearchQuery(params dto.SearchParams, querySQL string) (bytes.Buffer, []i
 	query.WriteString(" WHERE 1=1")
 	queryParams := make([]interface{}, 0)
 
+	if querySQL == findAllProductsQuery && params.Public {
+		query.WriteString(" AND " + productWindowCondition)
+	}
+
 	if params.Min != nil && params.Max != nil {
-		query.WriteString(" AND price BETWEEN ? AND ?")
+		// Bounds are sent as text; casting them keeps the comparison in
+		// DECIMAL instead of DOUBLE.
+		query.WriteString(" AND price BETWEEN CAST(? AS DECIMAL(19, 4)) AND CAST(? AS DECIMAL(19, 4))")
 		queryParams = append(queryParams, *params.Min, *params.Max)
 	}
 
+	if params.Status != nil {
+		query.WriteString(" AND status = ?")
+		queryParams = append(queryParams, *params.Status)
+	}
+
+	if querySQL == findAllProductsQuery {
+		for _, name := range params.AttributeNames() {
+			condition, args := attributeCondition(params, name)
+			query.WriteString(" AND " + condition)
+			queryParams = append(queryParams, args...)
+		}
+	}
+
 	if params.Sort == nil {
 		query.WriteString(" ORDER BY created_at DESC")
 	}
@@ -247,3 +335,33 @@ func GetSearchQuery(params dto.SearchParams, querySQL string) (bytes.Buffer, []i
 
 	return query, queryParams
 }
+
+// attributeCondition returns the condition that keeps the products with the
+// values of the attribute name in params, and its arguments.
+func attributeCondition(params dto.SearchParams, name string) (string, []interface{}) {
+	ids := params.AttributeIDs(name)
+	args := make([]interface{}, 0, 2*len(ids))
+	for _, id := range ids {
+		args = append(args, id, params.AttributeValues[name][id])
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("(?, ?), ", len(ids)), ", ")
+	return fmt.Sprintf(productAttributeCondition, placeholders), args
+}
+
+func scanProduct(row scanner) (domain.Product, error) {
+	var product domain.Product
+	err := row.Scan(
+		&product.ID,
+		&product.Title,
+		&product.Description,
+		&product.Price,
+		&product.Currency,
+		&product.Image,
+		&product.CreatedAt,
+		&product.CategoryID,
+		&product.Status,
+		&product.PublishAt,
+		&product.UnpublishAt,
+	)
+	return product, err
+}
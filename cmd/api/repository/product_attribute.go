@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+)
+
+type ProductAttributeRepository interface {
+	FindByProduct(ctx context.Context, tx helperdb.Tx, productID int64) (map[string]string, error)
+	FindByProducts(ctx context.Context, tx helperdb.Tx, productIDs []int64) (map[int64]map[string]string, error)
+	SetValues(ctx context.Context, tx *sql.Tx, productID int64, attributes []domain.AttributeDefinition, values map[string]string) error
+}
+
+type productAttributeRepository struct {
+}
+
+func NewProductAttributeRepository() ProductAttributeRepository {
+	return &productAttributeRepository{}
+}
+
+const (
+	findByProductsProductAttributesQuery = "SELECT pa.product_id, ca.name, pa.value FROM product_attributes pa JOIN category_attributes ca ON ca.id = pa.attribute_id WHERE pa.product_id IN (%s)"
+	createProductAttributeQuery          = "INSERT INTO product_attributes (product_id, attribute_id, value) VALUES (?, ?, ?)"
+	deleteProductAttributesQuery         = "DELETE FROM product_attributes WHERE product_id = ?"
+)
+
+// FindByProduct returns the attribute values of a product by attribute name.
+func (p *productAttributeRepository) FindByProduct(ctx context.Context, tx helperdb.Tx, productID int64) (map[string]string, error) {
+	values, err := p.FindByProducts(ctx, tx, []int64{productID})
+	if err != nil {
+		return nil, err
+	}
+	if values[productID] == nil {
+		return map[string]string{}, nil
+	}
+	return values[productID], nil
+}
+
+// FindByProducts returns the attribute values of productIDs by product id,
+// then by attribute name. Products without values are left out.
+func (p *productAttributeRepository) FindByProducts(ctx context.Context, tx helperdb.Tx, productIDs []int64) (map[int64]map[string]string, error) {
+	values := map[int64]map[string]string{}
+	if len(productIDs) == 0 {
+		return values, nil
+	}
+
+	args := make([]interface{}, 0, len(productIDs))
+	for _, id := range productIDs {
+		args = append(args, id)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(productIDs)), ", ")
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(findByProductsProductAttributesQuery, placeholders), args...)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get product attributes", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID int64
+		var name, value string
+		if err = rows.Scan(&productID, &name, &value); err != nil {
+			return nil, domain.NewInternalError("fail to scan product attribute", err)
+		}
+		if values[productID] == nil {
+			values[productID] = map[string]string{}
+		}
+		values[productID][name] = value
+	}
+	return values, nil
+}
+
+// SetValues replaces the attribute values of a product with values, by
+// attribute name. Values are saved for the attributes among attributes.
+func (p *productAttributeRepository) SetValues(ctx context.Context, tx *sql.Tx, productID int64, attributes []domain.AttributeDefinition, values map[string]string) error {
+	if _, err := tx.ExecContext(ctx, deleteProductAttributesQuery, productID); err != nil {
+		return domain.NewInternalError("fail to update product attributes", err)
+	}
+	for _, attribute := range attributes {
+		value, ok := values[attribute.Name]
+		if !ok {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, createProductAttributeQuery, productID, attribute.ID, value); err != nil {
+			return domain.NewInternalError("fail to update product attributes", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var productAttributeRows = []string{
+	"product_id",
+	"name",
+	"value",
+}
+
+func TestFindProductAttributesByProducts_WithResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(findByProductsProductAttributesQuery, "?, ?"))).
+		WithArgs(int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows(productAttributeRows).
+			AddRow(int64(1), "screen_size", "55").
+			AddRow(int64(1), "smart", "true").
+			AddRow(int64(2), "screen_size", "65"))
+
+	values, err := NewProductAttributeRepository().FindByProducts(context.Background(), db, []int64{1, 2})
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, map[int64]map[string]string{
+		1: {"screen_size": "55", "smart": "true"},
+		2: {"screen_size": "65"},
+	}, values)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindProductAttributesByProducts_WithoutProducts(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	values, err := NewProductAttributeRepository().FindByProducts(context.Background(), db, nil)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Empty(t, values)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindProductAttributesByProduct_WithoutResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(findByProductsProductAttributesQuery, "?"))).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(productAttributeRows))
+
+	values, err := NewProductAttributeRepository().FindByProduct(context.Background(), db, 1)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, map[string]string{}, values)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindProductAttributesByProduct_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(findByProductsProductAttributesQuery, "?"))).
+		WithArgs(int64(1)).
+		WillReturnError(sql.ErrConnDone)
+
+	_, err := NewProductAttributeRepository().FindByProduct(context.Background(), db, 1)
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestSetProductAttributeValues_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	attributes := []domain.AttributeDefinition{
+		{ID: 3, Name: "color"},
+		{ID: 1, Name: "screen_size"},
+		{ID: 2, Name: "smart"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(deleteProductAttributesQuery)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(QueryReplace(createProductAttributeQuery)).
+		WithArgs(int64(1), int64(1), "55").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(QueryReplace(createProductAttributeQuery)).
+		WithArgs(int64(1), int64(2), "true").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewProductAttributeRepository().SetValues(context.Background(), tx, 1, attributes, map[string]string{"screen_size": "55", "smart": "true"})
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestSetProductAttributeValues_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(deleteProductAttributesQuery)).
+		WithArgs(int64(1)).
+		WillReturnError(sql.ErrConnDone)
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewProductAttributeRepository().SetValues(context.Background(), tx, 1, nil, nil)
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
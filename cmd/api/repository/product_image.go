@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+)
+
+type ProductImageRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, image domain.ProductImage) (int64, error)
+	FindByProduct(ctx context.Context, tx helperdb.Tx, productID int64) ([]domain.ProductImage, error)
+	FindPendingThumbnails(ctx context.Context, tx helperdb.Tx, limit int) ([]int64, error)
+	Lock(ctx context.Context, tx *sql.Tx, id int64) (domain.ProductImage, error)
+	Update(ctx context.Context, tx *sql.Tx, image domain.ProductImage) error
+	SetRenditions(ctx context.Context, tx *sql.Tx, id int64, renditions []domain.Rendition) error
+	Delete(ctx context.Context, tx *sql.Tx, productID int64, id int64) error
+}
+
+type productImageRepository struct {
+}
+
+func NewProductImageRepository() ProductImageRepository {
+	return &productImageRepository{}
+}
+
+const (
+	productImageColumns = "id, product_id, url, position, alt_text, width, height, is_primary, created_at, thumbnail_status, thumbnail_attempts"
+
+	createProductImageQuery           = "INSERT INTO product_images (product_id, url, position, alt_text, width, height, is_primary, created_at, thumbnail_status, thumbnail_attempts) VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), 'pending', 0)"
+	findByProductProductImageQuery    = "SELECT " + productImageColumns + " FROM product_images WHERE product_id = ? ORDER BY position, id"
+	findPendingThumbnailsQuery        = "SELECT id FROM product_images WHERE thumbnail_status = 'pending' ORDER BY id LIMIT ?"
+	lockProductImageQuery             = "SELECT " + productImageColumns + " FROM product_images WHERE id = ? FOR UPDATE"
+	updateProductImageQuery           = "UPDATE product_images SET url = ?, position = ?, alt_text = ?, width = ?, height = ?, is_primary = ?, thumbnail_status = ?, thumbnail_attempts = ? WHERE id = ?"
+	deleteProductImageQuery           = "DELETE FROM product_images WHERE product_id = ? AND id = ?"
+	findRenditionsByProductQuery      = "SELECT r.image_id, r.size, r.url, r.width, r.height FROM product_image_renditions r JOIN product_images i ON i.id = r.image_id WHERE i.product_id = ? ORDER BY r.image_id, r.width"
+	createProductImageRenditionQuery  = "INSERT INTO product_image_renditions (image_id, size, url, width, height) VALUES (?, ?, ?, ?, ?)"
+	deleteProductImageRenditionsQuery = "DELETE FROM product_image_renditions WHERE image_id = ?"
+)
+
+func (p *productImageRepository) Create(ctx context.Context, tx *sql.Tx, image domain.ProductImage) (int64, error) {
+	res, err := tx.ExecContext(
+		ctx,
+		createProductImageQuery,
+		image.ProductID,
+		image.URL,
+		image.Position,
+		image.AltText,
+		image.Width,
+		image.Height,
+		image.Primary,
+	)
+	if err != nil {
+		return 0, domain.NewInternalError("fail to create product image", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, domain.NewInternalError("fail to get last insert id", err)
+	}
+	return id, nil
+}
+
+// FindByProduct returns the images of a product by ascending position, with
+// their renditions from the smallest to the largest.
+func (p *productImageRepository) FindByProduct(ctx context.Context, tx helperdb.Tx, productID int64) ([]domain.ProductImage, error) {
+	images, err := p.findImages(ctx, tx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(images) == 0 {
+		return images, nil
+	}
+	if err = p.findRenditions(ctx, tx, productID, images); err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// FindPendingThumbnails returns the IDs of up to limit images whose
+// renditions are still to be generated.
+func (p *productImageRepository) FindPendingThumbnails(ctx context.Context, tx helperdb.Tx, limit int) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, findPendingThumbnailsQuery, limit)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get pending thumbnails", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			return nil, domain.NewInternalError("fail to scan product image", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Lock reads an image, without its renditions, and locks it until tx ends.
+func (p *productImageRepository) Lock(ctx context.Context, tx *sql.Tx, id int64) (domain.ProductImage, error) {
+	image, err := scanProductImage(tx.QueryRowContext(ctx, lockProductImageQuery, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ProductImage{}, domain.NewNotFoundError(fmt.Sprintf("image with ID %d not found", id), err)
+		}
+		return domain.ProductImage{}, domain.NewInternalError("fail to lock product image", err)
+	}
+	return image, nil
+}
+
+func (p *productImageRepository) Update(ctx context.Context, tx *sql.Tx, image domain.ProductImage) error {
+	_, err := tx.ExecContext(
+		ctx,
+		updateProductImageQuery,
+		image.URL,
+		image.Position,
+		image.AltText,
+		image.Width,
+		image.Height,
+		image.Primary,
+		image.ThumbnailStatus,
+		image.ThumbnailAttempts,
+		image.ID,
+	)
+	if err != nil {
+		return domain.NewInternalError("fail to update product image", err)
+	}
+	return nil
+}
+
+// SetRenditions replaces the renditions of an image.
+func (p *productImageRepository) SetRenditions(ctx context.Context, tx *sql.Tx, id int64, renditions []domain.Rendition) error {
+	if _, err := tx.ExecContext(ctx, deleteProductImageRenditionsQuery, id); err != nil {
+		return domain.NewInternalError("fail to update product image renditions", err)
+	}
+	for _, rendition := range renditions {
+		_, err := tx.ExecContext(ctx, createProductImageRenditionQuery, id, rendition.Size, rendition.URL, rendition.Width, rendition.Height)
+		if err != nil {
+			return domain.NewInternalError("fail to update product image renditions", err)
+		}
+	}
+	return nil
+}
+
+func (p *productImageRepository) Delete(ctx context.Context, tx *sql.Tx, productID int64, id int64) error {
+	res, err := tx.ExecContext(ctx, deleteProductImageQuery, productID, id)
+	if err != nil {
+		return domain.NewInternalError("fail to delete product image", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return domain.NewInternalError("fail to get rows affected", err)
+	}
+	if rowsAffected == 0 {
+		return domain.NewNotFoundError(fmt.Sprintf("image with ID %d of product with ID %d not found", id, productID), nil)
+	}
+	return nil
+}
+
+func (p *productImageRepository) findImages(ctx context.Context, tx helperdb.Tx, productID int64) ([]domain.ProductImage, error) {
+	rows, err := tx.QueryContext(ctx, findByProductProductImageQuery, productID)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get product images", err)
+	}
+	defer rows.Close()
+
+	images := []domain.ProductImage{}
+	for rows.Next() {
+		image, err := scanProductImage(rows)
+		if err != nil {
+			return nil, domain.NewInternalError("fail to scan product image", err)
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// findRenditions fills the renditions of the images of a product, once the
+// rows of the images are closed so both queries can share the connection of
+// a transaction.
+func (p *productImageRepository) findRenditions(ctx context.Context, tx helperdb.Tx, productID int64, images []domain.ProductImage) error {
+	rows, err := tx.QueryContext(ctx, findRenditionsByProductQuery, productID)
+	if err != nil {
+		return domain.NewInternalError("fail to get product image renditions", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[int64]*domain.ProductImage, len(images))
+	for i := range images {
+		byID[images[i].ID] = &images[i]
+	}
+	for rows.Next() {
+		var imageID int64
+		var rendition domain.Rendition
+		if err = rows.Scan(&imageID, &rendition.Size, &rendition.URL, &rendition.Width, &rendition.Height); err != nil {
+			return domain.NewInternalError("fail to scan product image rendition", err)
+		}
+		if image, ok := byID[imageID]; ok {
+			image.Renditions = append(image.Renditions, rendition)
+		}
+	}
+	return nil
+}
+
+func scanProductImage(row scanner) (domain.ProductImage, error) {
+	var image domain.ProductImage
+	err := row.Scan(
+		&image.ID,
+		&image.ProductID,
+		&image.URL,
+		&image.Position,
+		&image.AltText,
+		&image.Width,
+		&image.Height,
+		&image.Primary,
+		&image.CreatedAt,
+		&image.ThumbnailStatus,
+		&image.ThumbnailAttempts,
+	)
+	return image, err
+}
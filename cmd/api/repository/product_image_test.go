@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var productImageRows = []string{
+	"id",
+	"product_id",
+	"url",
+	"position",
+	"alt_text",
+	"width",
+	"height",
+	"is_primary",
+	"created_at",
+	"thumbnail_status",
+	"thumbnail_attempts",
+}
+
+var productImageRenditionRows = []string{
+	"image_id",
+	"size",
+	"url",
+	"width",
+	"height",
+}
+
+func InitialMockDBProductImage() domain.ProductImage {
+	return domain.ProductImage{
+		ID:        1,
+		ProductID: 1,
+		URL:       "https://cdn.example.com/products/1/front.jpg",
+		Position:  0,
+		AltText:   "Front view",
+		Width:     1200,
+		Height:    900,
+		Primary:   true,
+		CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+
+		ThumbnailStatus: domain.ThumbnailPending,
+	}
+}
+
+func productImageRow(rows *sqlmock.Rows, image domain.ProductImage) *sqlmock.Rows {
+	return rows.AddRow(
+		image.ID,
+		image.ProductID,
+		image.URL,
+		image.Position,
+		image.AltText,
+		image.Width,
+		image.Height,
+		image.Primary,
+		image.CreatedAt,
+		image.ThumbnailStatus,
+		image.ThumbnailAttempts,
+	)
+}
+
+func TestCreateProductImage_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	image := InitialMockDBProductImage()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createProductImageQuery)).
+		WithArgs(image.ProductID, image.URL, image.Position, image.AltText, image.Width, image.Height, image.Primary).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	id, err := NewProductImageRepository().Create(context.Background(), tx, image)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, int64(1), id)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestCreateProductImage_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	image := InitialMockDBProductImage()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createProductImageQuery)).
+		WithArgs(image.ProductID, image.URL, image.Position, image.AltText, image.Width, image.Height, image.Primary).
+		WillReturnError(sql.ErrConnDone)
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = NewProductImageRepository().Create(context.Background(), tx, image)
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindProductImagesByProduct_WithResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	image := InitialMockDBProductImage()
+	image.ThumbnailStatus = domain.ThumbnailGenerated
+	image.ThumbnailAttempts = 1
+	image.Renditions = []domain.Rendition{
+		{Size: "small", URL: "http://localhost:8080/uploads/a_small.jpg", Width: 160, Height: 120},
+		{Size: "medium", URL: "http://localhost:8080/uploads/a_medium.jpg", Width: 480, Height: 360},
+	}
+
+	mock.ExpectQuery(QueryReplace(findByProductProductImageQuery)).
+		WithArgs(image.ProductID).
+		WillReturnRows(productImageRow(sqlmock.NewRows(productImageRows), image))
+	mock.ExpectQuery(regexp.QuoteMeta(findRenditionsByProductQuery)).
+		WithArgs(image.ProductID).
+		WillReturnRows(sqlmock.NewRows(productImageRenditionRows).
+			AddRow(image.ID, "small", image.Renditions[0].URL, 160, 120).
+			AddRow(image.ID, "medium", image.Renditions[1].URL, 480, 360).
+			AddRow(int64(99), "small", "http://localhost:8080/uploads/b_small.jpg", 160, 120))
+
+	images, err := NewProductImageRepository().FindByProduct(context.Background(), db, image.ProductID)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []domain.ProductImage{image}, images)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindProductImagesByProduct_WithoutResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findByProductProductImageQuery)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(productImageRows))
+
+	images, err := NewProductImageRepository().FindByProduct(context.Background(), db, 1)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Empty(t, images)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestUpdateProductImage_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	image := InitialMockDBProductImage()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(updateProductImageQuery)).
+		WithArgs(image.URL, image.Position, image.AltText, image.Width, image.Height, image.Primary, image.ThumbnailStatus, image.ThumbnailAttempts, image.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewProductImageRepository().Update(context.Background(), tx, image)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindPendingThumbnails_WithResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findPendingThumbnailsQuery)).
+		WithArgs(20).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)).AddRow(int64(4)))
+
+	ids, err := NewProductImageRepository().FindPendingThumbnails(context.Background(), db, 20)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []int64{1, 4}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestLockProductImage_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	image := InitialMockDBProductImage()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(QueryReplace(lockProductImageQuery)).
+		WithArgs(image.ID).
+		WillReturnRows(productImageRow(sqlmock.NewRows(productImageRows), image))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	locked, err := NewProductImageRepository().Lock(context.Background(), tx, image.ID)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, image, locked)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestLockProductImage_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(QueryReplace(lockProductImageQuery)).
+		WithArgs(int64(1)).
+		WillReturnError(sql.ErrNoRows)
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = NewProductImageRepository().Lock(context.Background(), tx, 1)
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestSetProductImageRenditions_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	renditions := []domain.Rendition{
+		{Size: "small", URL: "http://localhost:8080/uploads/a_small.jpg", Width: 160, Height: 120},
+		{Size: "large", URL: "http://localhost:8080/uploads/a_large.jpg", Width: 1024, Height: 768},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(deleteProductImageRenditionsQuery)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	for _, rendition := range renditions {
+		mock.ExpectExec(QueryReplace(createProductImageRenditionQuery)).
+			WithArgs(int64(1), rendition.Size, rendition.URL, rendition.Width, rendition.Height).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewProductImageRepository().SetRenditions(context.Background(), tx, 1, renditions)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestDeleteProductImage_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(deleteProductImageQuery)).
+		WithArgs(int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewProductImageRepository().Delete(context.Background(), tx, 1, 2)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestDeleteProductImage_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(deleteProductImageQuery)).
+		WithArgs(int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewProductImageRepository().Delete(context.Background(), tx, 1, 2)
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+)
+
+type ProductPriceRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, price domain.ProductPrice) error
+	FindByProduct(ctx context.Context, tx helperdb.Tx, productID int64) ([]domain.ProductPrice, error)
+	FindByProducts(ctx context.Context, tx helperdb.Tx, productIDs []int64, currency string) (map[int64]domain.ProductPrice, error)
+	Delete(ctx context.Context, tx *sql.Tx, productID int64, currency string) (int64, error)
+}
+
+type productPriceRepository struct {
+}
+
+func NewProductPriceRepository() ProductPriceRepository {
+	return &productPriceRepository{}
+}
+
+const (
+	saveProductPriceQuery            = "INSERT INTO product_prices (product_id, currency, price, updated_at) VALUES (?, ?, ?, NOW()) ON DUPLICATE KEY UPDATE price = VALUES(price), updated_at = NOW()"
+	findByProductProductPriceQuery   = "SELECT product_id, currency, price, updated_at FROM product_prices WHERE product_id = ? ORDER BY currency"
+	findByProductsProductPricesQuery = "SELECT product_id, currency, price, updated_at FROM product_prices WHERE currency = ? AND product_id IN (%s)"
+	deleteProductPriceQuery          = "DELETE FROM product_prices WHERE product_id = ? AND currency = ?"
+)
+
+func (p *productPriceRepository) Save(ctx context.Context, tx *sql.Tx, price domain.ProductPrice) error {
+	_, err := tx.ExecContext(ctx, saveProductPriceQuery, price.ProductID, price.Currency, price.Price)
+	if err != nil {
+		return domain.NewInternalError("fail to save product price", err)
+	}
+	return nil
+}
+
+func (p *productPriceRepository) FindByProduct(ctx context.Context, tx helperdb.Tx, productID int64) ([]domain.ProductPrice, error) {
+	rows, err := tx.QueryContext(ctx, findByProductProductPriceQuery, productID)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get product prices", err)
+	}
+	defer rows.Close()
+
+	prices := []domain.ProductPrice{}
+	for rows.Next() {
+		price, err := scanProductPrice(rows)
+		if err != nil {
+			return nil, domain.NewInternalError("fail to scan product price", err)
+		}
+		prices = append(prices, price)
+	}
+	return prices, nil
+}
+
+// FindByProducts returns the prices of productIDs in the price list of
+// currency, by product id. Products without one are left out.
+func (p *productPriceRepository) FindByProducts(ctx context.Context, tx helperdb.Tx, productIDs []int64, currency string) (map[int64]domain.ProductPrice, error) {
+	prices := map[int64]domain.ProductPrice{}
+	if len(productIDs) == 0 {
+		return prices, nil
+	}
+
+	args := []interface{}{currency}
+	for _, id := range productIDs {
+		args = append(args, id)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(productIDs)), ", ")
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(findByProductsProductPricesQuery, placeholders), args...)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get product prices", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		price, err := scanProductPrice(rows)
+		if err != nil {
+			return nil, domain.NewInternalError("fail to scan product price", err)
+		}
+		prices[price.ProductID] = price
+	}
+	return prices, nil
+}
+
+func (p *productPriceRepository) Delete(ctx context.Context, tx *sql.Tx, productID int64, currency string) (int64, error) {
+	res, err := tx.ExecContext(ctx, deleteProductPriceQuery, productID, currency)
+	if err != nil {
+		return 0, domain.NewInternalError("fail to delete product price", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, domain.NewInternalError("fail to get rows affected", err)
+	}
+	if rowsAffected == 0 {
+		return 0, domain.NewNotFoundError(fmt.Sprintf("price in %s of product with ID %d not found", currency, productID), nil)
+	}
+	return rowsAffected, nil
+}
+
+func scanProductPrice(row scanner) (domain.ProductPrice, error) {
+	var price domain.ProductPrice
+	err := row.Scan(
+		&price.ProductID,
+		&price.Currency,
+		&price.Price,
+		&price.UpdatedAt,
+	)
+	return price, err
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var productPriceRows = []string{
+	"product_id",
+	"currency",
+	"price",
+	"updated_at",
+}
+
+func InitialMockDBProductPrice() domain.ProductPrice {
+	return domain.ProductPrice{
+		ProductID: 1,
+		Currency:  "ARS",
+		Price:     money.MustParseDecimal("15999.90"),
+		UpdatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestSaveProductPrice_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	price := InitialMockDBProductPrice()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(saveProductPriceQuery)).
+		WithArgs(price.ProductID, price.Currency, price.Price).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewProductPriceRepository().Save(context.Background(), tx, price)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestSaveProductPrice_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	price := InitialMockDBProductPrice()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(saveProductPriceQuery)).
+		WithArgs(price.ProductID, price.Currency, price.Price).
+		WillReturnError(sql.ErrConnDone)
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewProductPriceRepository().Save(context.Background(), tx, price)
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindProductPricesByProduct_WithResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	price := InitialMockDBProductPrice()
+
+	mock.ExpectQuery(QueryReplace(findByProductProductPriceQuery)).
+		WithArgs(price.ProductID).
+		WillReturnRows(sqlmock.NewRows(productPriceRows).
+			AddRow(price.ProductID, price.Currency, price.Price.String(), price.UpdatedAt).
+			AddRow(price.ProductID, "MXN", "1599.00", price.UpdatedAt))
+
+	prices, err := NewProductPriceRepository().FindByProduct(context.Background(), db, price.ProductID)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Len(t, prices, 2)
+	assert.Equal(t, price, prices[0])
+	assert.Equal(t, "1599.00", prices[1].Price.String())
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindProductPricesByProducts_WithResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	price := InitialMockDBProductPrice()
+
+	mock.ExpectQuery(QueryReplace(fmt.Sprintf(findByProductsProductPricesQuery, "?, ?"))).
+		WithArgs("ARS", int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows(productPriceRows).
+			AddRow(price.ProductID, price.Currency, price.Price.String(), price.UpdatedAt))
+
+	prices, err := NewProductPriceRepository().FindByProducts(context.Background(), db, []int64{1, 2}, "ARS")
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, map[int64]domain.ProductPrice{1: price}, prices)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindProductPricesByProducts_WithoutProducts(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	prices, err := NewProductPriceRepository().FindByProducts(context.Background(), db, nil, "ARS")
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Empty(t, prices)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestDeleteProductPrice_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(deleteProductPriceQuery)).
+		WithArgs(int64(1), "ARS").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	rowsAffected, err := NewProductPriceRepository().Delete(context.Background(), tx, 1, "ARS")
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "price in ARS of product with ID 1 not found", notFound.Message)
+	assert.Equal(t, int64(0), rowsAffected)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -11,6 +13,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,8 +22,8 @@ var (
 	// test   *dbtest.Test
 	limit  = int64(10)
 	offset = int64(1)
-	min    = float64(0)
-	max    = float64(100)
+	min    = money.NewDecimal(0, 0)
+	max    = money.NewDecimal(100, 0)
 	sort   = "ASC"
 	title  = "test"
 	name   = "test"
@@ -30,9 +33,13 @@ var productRows = []string{
 	"title",
 	"description",
 	"price",
+	"currency",
 	"image",
 	"created_at",
 	"category_id",
+	"status",
+	"publish_at",
+	"unpublish_at",
 }
 
 func InitialCommonMocks() (*sql.DB, sqlmock.Sqlmock) {
@@ -48,9 +55,11 @@ func InitialMockDBProduct() domain.Product {
 		ID:          1,
 		Title:       "Test Product",
 		Description: "Test Description",
-		Price:       10.00,
+		Price:       money.MustParseDecimal("10.00"),
+		Currency:    "BRL",
 		Image:       "test.jpg",
 		CategoryID:  categoryID,
+		Status:      domain.ProductPublished,
 		CreatedAt:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
 	}
 }
@@ -67,8 +76,12 @@ func TestCreateProduct_IntoTx_WithoutError(t *testing.T) {
 		product.Title,
 		product.Description,
 		product.Price,
+		product.Currency,
 		product.Image,
 		product.CategoryID,
+		product.Status,
+		product.PublishAt,
+		product.UnpublishAt,
 	).WillReturnResult(sqlmock.NewResult(product.ID, 1))
 
 	repo := NewProductRepository()
@@ -98,8 +111,12 @@ func TestCreateProduct_IntoTx_WithError(t *testing.T) {
 		product.Title,
 		product.Description,
 		product.Price,
+		product.Currency,
 		product.Image,
 		product.CategoryID,
+		product.Status,
+		product.PublishAt,
+		product.UnpublishAt,
 	).WillReturnError(sql.ErrConnDone)
 
 	repo := NewProductRepository()
@@ -129,8 +146,12 @@ func TestCreateProduct_IntoTx_WithErrorInLastInsertId(t *testing.T) {
 		product.Title,
 		product.Description,
 		product.Price,
+		product.Currency,
 		product.Image,
 		product.CategoryID,
+		product.Status,
+		product.PublishAt,
+		product.UnpublishAt,
 	).WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
 
 	repo := NewProductRepository()
@@ -160,9 +181,9 @@ func TestFindAllProducts(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
 	mock.ExpectQuery(query).
 		WillReturnRows(mock.NewRows(productRows).
-			AddRow(products[0].ID, products[0].Title, products[0].Description, products[0].Price, products[0].Image, products[0].CreatedAt, products[0].CategoryID).
-			AddRow(products[1].ID, products[1].Title, products[1].Description, products[1].Price, products[1].Image, products[1].CreatedAt, products[1].CategoryID).
-			AddRow(products[2].ID, products[2].Title, products[2].Description, products[2].Price, products[2].Image, products[2].CreatedAt, products[2].CategoryID))
+			AddRow(products[0].ID, products[0].Title, products[0].Description, products[0].Price.String(), products[0].Currency, products[0].Image, products[0].CreatedAt, products[0].CategoryID, products[0].Status, products[0].PublishAt, products[0].UnpublishAt).
+			AddRow(products[1].ID, products[1].Title, products[1].Description, products[1].Price.String(), products[1].Currency, products[1].Image, products[1].CreatedAt, products[1].CategoryID, products[1].Status, products[1].PublishAt, products[1].UnpublishAt).
+			AddRow(products[2].ID, products[2].Title, products[2].Description, products[2].Price.String(), products[2].Currency, products[2].Image, products[2].CreatedAt, products[2].CategoryID, products[2].Status, products[2].PublishAt, products[2].UnpublishAt))
 
 	repo := NewProductRepository()
 
@@ -204,9 +225,9 @@ func TestFindAllProducts_WithoutError_WithName(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
 	mock.ExpectQuery(query).
 		WillReturnRows(mock.NewRows(productRows).
-			AddRow(products[0].ID, products[0].Title, products[0].Description, products[0].Price, products[0].Image, products[0].CreatedAt, products[0].CategoryID).
-			AddRow(products[1].ID, products[1].Title, products[1].Description, products[1].Price, products[1].Image, products[1].CreatedAt, products[1].CategoryID).
-			AddRow(products[2].ID, products[2].Title, products[2].Description, products[2].Price, products[2].Image, products[2].CreatedAt, products[2].CategoryID))
+			AddRow(products[0].ID, products[0].Title, products[0].Description, products[0].Price.String(), products[0].Currency, products[0].Image, products[0].CreatedAt, products[0].CategoryID, products[0].Status, products[0].PublishAt, products[0].UnpublishAt).
+			AddRow(products[1].ID, products[1].Title, products[1].Description, products[1].Price.String(), products[1].Currency, products[1].Image, products[1].CreatedAt, products[1].CategoryID, products[1].Status, products[1].PublishAt, products[1].UnpublishAt).
+			AddRow(products[2].ID, products[2].Title, products[2].Description, products[2].Price.String(), products[2].Currency, products[2].Image, products[2].CreatedAt, products[2].CategoryID, products[2].Status, products[2].PublishAt, products[2].UnpublishAt))
 
 	repo := NewProductRepository()
 
@@ -295,7 +316,8 @@ func TestFindAllProducts_WithErrorInCount(t *testing.T) {
 	_, _, err = repo.FindAll(context.Background(), tx, params)
 
 	assert.Error(t, err, "Error should be returned during scanning")
-	assert.Contains(t, err.Error(), "fail to execute query", "Error message should contain expected text")
+	assert.Equal(t, "fail to count products", err.Error(), "Error message should not contain the driver error")
+	assert.Contains(t, errors.Unwrap(err).Error(), "fail to execute query", "Error cause should contain expected text")
 
 }
 
@@ -377,7 +399,7 @@ func TestFindByID_WithoutError(t *testing.T) {
 	mock.ExpectQuery(query).
 		WithArgs(product.ID).
 		WillReturnRows(mock.NewRows(productRows).
-			AddRow(product.ID, product.Title, product.Description, product.Price, product.Image, product.CreatedAt, product.CategoryID))
+			AddRow(product.ID, product.Title, product.Description, product.Price.String(), product.Currency, product.Image, product.CreatedAt, product.CategoryID, product.Status, product.PublishAt, product.UnpublishAt))
 
 	repo := NewProductRepository()
 
@@ -439,6 +461,57 @@ func TestFindByID_WithErrorErrNoRows(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
 }
 
+func TestFindByID_Draft(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findByIDProductQuery)).
+		WithArgs(int64(1)).
+		WillReturnRows(mock.NewRows(productRows))
+
+	_, err := NewProductRepository().FindByID(context.Background(), db, 1)
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Contains(t, findByIDProductQuery, "status = 'published'")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindAnyByID_Draft(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	product := InitialMockDBProduct()
+	product.Status = domain.ProductDraft
+	publishAt := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	product.PublishAt = &publishAt
+
+	mock.ExpectQuery(QueryReplace(findAnyByIDProductQuery)).
+		WithArgs(product.ID).
+		WillReturnRows(mock.NewRows(productRows).
+			AddRow(product.ID, product.Title, product.Description, product.Price.String(), product.Currency, product.Image, product.CreatedAt, product.CategoryID, product.Status, product.PublishAt, product.UnpublishAt))
+
+	result, err := NewProductRepository().FindAnyByID(context.Background(), db, product.ID)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, product, result)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindAnyByID_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findAnyByIDProductQuery)).
+		WithArgs(int64(1)).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := NewProductRepository().FindAnyByID(context.Background(), db, 1)
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
 func TestFindByCategory_WithResults(t *testing.T) {
 	db, mock := InitialCommonMocks()
 	defer db.Close()
@@ -451,15 +524,15 @@ func TestFindByCategory_WithResults(t *testing.T) {
 	mock.ExpectQuery(query).
 		WithArgs(categoryID).
 		WillReturnRows(mock.NewRows(productRows).
-			AddRow(1, "Product 1", "Description 1", 10.00, "image1.jpg", time.Now(), categoryID).
-			AddRow(2, "Product 2", "Description 2", 20.00, "image2.jpg", time.Now(), categoryID))
+			AddRow(1, "Product 1", "Description 1", "10.00", "BRL", "image1.jpg", time.Now(), categoryID, domain.ProductPublished, nil, nil).
+			AddRow(2, "Product 2", "Description 2", "20.00", "BRL", "image2.jpg", time.Now(), categoryID, domain.ProductPublished, nil, nil))
 
 	repo := NewProductRepository()
 
 	tx, err := db.Begin()
 	assert.NoError(t, err, "Error should not be returned")
 
-	result, err := repo.FindByCategory(context.Background(), tx, categoryID)
+	result, err := repo.FindByCategory(context.Background(), tx, categoryID, "")
 	assert.NoError(t, err, "Error should not be returned")
 	assert.Len(t, result, 2, "Number of returned products should be 2")
 
@@ -484,7 +557,7 @@ func TestFindByCategory_QueryError(t *testing.T) {
 	tx, err := db.Begin()
 	assert.NoError(t, err, "Error should not be returned")
 
-	_, err = repo.FindByCategory(context.Background(), tx, categoryID)
+	_, err = repo.FindByCategory(context.Background(), tx, categoryID, "")
 	assert.Error(t, err, "Error should be returned")
 
 	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
@@ -502,7 +575,7 @@ func TestFindByCategory_NoRowsError(t *testing.T) {
 
 	repo := NewProductRepository()
 
-	_, err := repo.FindByCategory(context.Background(), db, categoryID)
+	_, err := repo.FindByCategory(context.Background(), db, categoryID, "")
 	assert.Error(t, err, "Error should be returned")
 
 	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
@@ -522,7 +595,7 @@ func TestFindByCategory_ScanError(t *testing.T) {
 
 	repo := NewProductRepository()
 
-	_, err := repo.FindByCategory(context.Background(), db, categoryID)
+	_, err := repo.FindByCategory(context.Background(), db, categoryID, "")
 	assert.Error(t, err, "Error should be returned")
 
 	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
@@ -542,7 +615,7 @@ func TestFindByCategory_isNotFound(t *testing.T) {
 
 	repo := NewProductRepository()
 
-	_, err := repo.FindByCategory(context.Background(), db, categoryID)
+	_, err := repo.FindByCategory(context.Background(), db, categoryID, "")
 	assert.Error(t, err, "Error should be returned")
 
 	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
@@ -558,7 +631,7 @@ func TestUpdateProduct_WithoutError(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.ExpectExec(query).
-		WithArgs(product.Title, product.Description, product.Price, product.Image, product.CategoryID, product.ID).
+		WithArgs(product.Title, product.Description, product.Price, product.Currency, product.Image, product.CategoryID, product.PublishAt, product.UnpublishAt, product.ID).
 		WillReturnResult(sqlmock.NewResult(product.ID, 1))
 
 	repo := NewProductRepository()
@@ -582,7 +655,7 @@ func TestUpdateProduct_WithError(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.ExpectExec(query).
-		WithArgs(product.Title, product.Description, product.Price, product.Image, product.CategoryID, product.ID).
+		WithArgs(product.Title, product.Description, product.Price, product.Currency, product.Image, product.CategoryID, product.PublishAt, product.UnpublishAt, product.ID).
 		WillReturnError(sql.ErrConnDone)
 
 	repo := NewProductRepository()
@@ -606,7 +679,7 @@ func TestUpdateProduct_WithErrorInResult(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.ExpectExec(query).
-		WithArgs(product.Title, product.Description, product.Price, product.Image, product.CategoryID, product.ID).
+		WithArgs(product.Title, product.Description, product.Price, product.Currency, product.Image, product.CategoryID, product.PublishAt, product.UnpublishAt, product.ID).
 		WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
 
 	repo := NewProductRepository()
@@ -628,7 +701,7 @@ func TestUpdateProduct_WithZeroRowsAffected(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.ExpectExec(query).
-		WithArgs(product.Title, product.Description, product.Price, product.Image, product.CategoryID, product.ID).
+		WithArgs(product.Title, product.Description, product.Price, product.Currency, product.Image, product.CategoryID, product.PublishAt, product.UnpublishAt, product.ID).
 		WillReturnResult(sqlmock.NewResult(product.ID, 0))
 
 	repo := NewProductRepository()
@@ -743,3 +816,207 @@ func QueryReplace(query string) string {
 	}
 	return query
 }
+
+func TestFindAllProducts_WithStatus(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	product := InitialMockDBProduct()
+	status := domain.ProductPublished
+
+	mock.ExpectQuery(regexp.QuoteMeta(countProductsQuery + " AND " + productWindowCondition + " AND status = ?")).
+		WithArgs(status).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(findAllProductsQuery+" WHERE 1=1 AND "+productWindowCondition+" AND status = ? ORDER BY created_at DESC LIMIT ? OFFSET ?")).
+		WithArgs(status, limit, int64(0)).
+		WillReturnRows(mock.NewRows(productRows).
+			AddRow(product.ID, product.Title, product.Description, product.Price.String(), product.Currency, product.Image, product.CreatedAt, product.CategoryID, product.Status, product.PublishAt, product.UnpublishAt))
+
+	params := dto.SearchParams{
+		Limit:  &limit,
+		Offset: &offset,
+		Status: &status,
+		Public: true,
+	}
+
+	result, total, err := NewProductRepository().FindAll(context.Background(), db, params)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []domain.Product{product}, result)
+	assert.Equal(t, int64(1), total)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindAllProducts_AdminOutsidePublicationWindow(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	product := InitialMockDBProduct()
+	product.Status = domain.ProductDraft
+	publishAt := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	product.PublishAt = &publishAt
+
+	mock.ExpectQuery("^" + regexp.QuoteMeta(countProductsQuery) + "$").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("^"+regexp.QuoteMeta(findAllProductsQuery+" WHERE 1=1 ORDER BY created_at DESC LIMIT ? OFFSET ?")+"$").
+		WithArgs(limit, int64(0)).
+		WillReturnRows(mock.NewRows(productRows).
+			AddRow(product.ID, product.Title, product.Description, product.Price.String(), product.Currency, product.Image, product.CreatedAt, product.CategoryID, product.Status, product.PublishAt, product.UnpublishAt))
+
+	params := dto.SearchParams{
+		Limit:  &limit,
+		Offset: &offset,
+	}
+
+	result, total, err := NewProductRepository().FindAll(context.Background(), db, params)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []domain.Product{product}, result)
+	assert.Equal(t, int64(1), total)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindByCategory_WithStatus(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	product := InitialMockDBProduct()
+
+	mock.ExpectQuery(QueryReplace(findByCategoryQuery+" AND status = ?")).
+		WithArgs(product.CategoryID, domain.ProductPublished).
+		WillReturnRows(mock.NewRows(productRows).
+			AddRow(product.ID, product.Title, product.Description, product.Price.String(), product.Currency, product.Image, product.CreatedAt, product.CategoryID, product.Status, product.PublishAt, product.UnpublishAt))
+
+	result, err := NewProductRepository().FindByCategory(context.Background(), db, product.CategoryID, domain.ProductPublished)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []domain.Product{product}, result)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestUpdateProductStatus_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(updateProductStatusQuery)).
+		WithArgs(domain.ProductArchived, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewProductRepository().UpdateStatus(context.Background(), tx, 1, domain.ProductArchived)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestUpdateProductStatus_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(updateProductStatusQuery)).
+		WithArgs(domain.ProductArchived, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewProductRepository().UpdateStatus(context.Background(), tx, 1, domain.ProductArchived)
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestLockProduct_OutsidePublicationWindow(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	product := InitialMockDBProduct()
+	publishAt := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	product.PublishAt = &publishAt
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(QueryReplace(lockProductQuery)).
+		WithArgs(product.ID).
+		WillReturnRows(mock.NewRows(productRows).
+			AddRow(product.ID, product.Title, product.Description, product.Price.String(), product.Currency, product.Image, product.CreatedAt, product.CategoryID, product.Status, product.PublishAt, product.UnpublishAt))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	locked, err := NewProductRepository().Lock(context.Background(), tx, product.ID)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, product, locked)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestLockProduct_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(QueryReplace(lockProductQuery)).
+		WithArgs(int64(1)).
+		WillReturnError(sql.ErrNoRows)
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = NewProductRepository().Lock(context.Background(), tx, 1)
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestSetProductImage_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(setProductImageQuery)).
+		WithArgs("https://cdn.example.com/products/1/front.jpg", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewProductRepository().SetImage(context.Background(), tx, 1, "https://cdn.example.com/products/1/front.jpg")
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindAllProducts_WithAttributes(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	product := InitialMockDBProduct()
+	status := domain.ProductPublished
+
+	screenSizeCondition := fmt.Sprintf(productAttributeCondition, "(?, ?), (?, ?)")
+	smartCondition := fmt.Sprintf(productAttributeCondition, "(?, ?)")
+	mock.ExpectQuery(regexp.QuoteMeta(countProductsQuery+" AND "+productWindowCondition+" AND status = ? AND "+screenSizeCondition+" AND "+smartCondition)).
+		WithArgs(status, int64(2), "55", int64(7), "55.0", int64(3), "true").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(findAllProductsQuery+" WHERE 1=1 AND "+productWindowCondition+" AND status = ? AND "+screenSizeCondition+" AND "+smartCondition+" ORDER BY created_at DESC LIMIT ? OFFSET ?")).
+		WithArgs(status, int64(2), "55", int64(7), "55.0", int64(3), "true", limit, int64(0)).
+		WillReturnRows(mock.NewRows(productRows).
+			AddRow(product.ID, product.Title, product.Description, product.Price.String(), product.Currency, product.Image, product.CreatedAt, product.CategoryID, product.Status, product.PublishAt, product.UnpublishAt))
+
+	params := dto.SearchParams{
+		Limit:  &limit,
+		Offset: &offset,
+		Status: &status,
+		Public: true,
+		AttributeValues: map[string]map[int64]string{
+			"smart":       {3: "true"},
+			"screen_size": {7: "55.0", 2: "55"},
+		},
+	}
+
+	result, total, err := NewProductRepository().FindAll(context.Background(), db, params)
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []domain.Product{product}, result)
+	assert.Equal(t, int64(1), total)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, promotion domain.Promotion) (int64, error)
+	FindAll(ctx context.Context, tx helperdb.Tx) ([]domain.Promotion, error)
+	FindActive(ctx context.Context, tx helperdb.Tx) ([]domain.Promotion, error)
+	NextChangeAt(ctx context.Context, tx helperdb.Tx) (*time.Time, error)
+	FindByID(ctx context.Context, tx helperdb.Tx, id int64) (domain.Promotion, error)
+	Update(ctx context.Context, tx *sql.Tx, promotion domain.Promotion) error
+	Delete(ctx context.Context, tx *sql.Tx, id int64) (int64, error)
+}
+
+type promotionRepository struct {
+}
+
+func NewPromotionRepository() PromotionRepository {
+	return &promotionRepository{}
+}
+
+const (
+	createPromotionQuery           = "INSERT INTO promotions (name, type, value, currency, min_price, max_price, priority, stackable, starts_at, ends_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())"
+	findAllPromotionQuery          = "SELECT id, name, type, value, currency, min_price, max_price, priority, stackable, starts_at, ends_at, created_at FROM promotions ORDER BY priority DESC, id"
+	findActivePromotionQuery       = "SELECT id, name, type, value, currency, min_price, max_price, priority, stackable, starts_at, ends_at, created_at FROM promotions WHERE starts_at <= NOW() AND ends_at > NOW() ORDER BY priority DESC, id"
+	nextChangePromotionQuery       = "SELECT MIN(edge) FROM (SELECT starts_at AS edge FROM promotions WHERE starts_at > NOW() UNION ALL SELECT ends_at FROM promotions WHERE ends_at > NOW()) AS edges"
+	findByIDPromotionQuery         = "SELECT id, name, type, value, currency, min_price, max_price, priority, stackable, starts_at, ends_at, created_at FROM promotions WHERE id = ?"
+	updatePromotionQuery           = "UPDATE promotions SET name = ?, type = ?, value = ?, currency = ?, min_price = ?, max_price = ?, priority = ?, stackable = ?, starts_at = ?, ends_at = ? WHERE id = ?"
+	deletePromotionQuery           = "DELETE FROM promotions WHERE id = ?"
+	deletePromotionProductsQuery   = "DELETE FROM promotion_products WHERE promotion_id = ?"
+	deletePromotionCategoriesQuery = "DELETE FROM promotion_categories WHERE promotion_id = ?"
+	createPromotionProductQuery    = "INSERT INTO promotion_products (promotion_id, product_id) VALUES (?, ?)"
+	createPromotionCategoryQuery   = "INSERT INTO promotion_categories (promotion_id, category_id) VALUES (?, ?)"
+	findPromotionProductsQuery     = "SELECT promotion_id, product_id FROM promotion_products WHERE promotion_id IN (%s) ORDER BY product_id"
+	findPromotionCategoriesQuery   = "SELECT promotion_id, category_id FROM promotion_categories WHERE promotion_id IN (%s) ORDER BY category_id"
+)
+
+func (p *promotionRepository) Create(ctx context.Context, tx *sql.Tx, promotion domain.Promotion) (int64, error) {
+	res, err := tx.ExecContext(
+		ctx,
+		createPromotionQuery,
+		promotion.Name,
+		promotion.Type,
+		promotion.Value,
+		nullString(promotion.Currency),
+		promotion.MinPrice,
+		promotion.MaxPrice,
+		promotion.Priority,
+		promotion.Stackable,
+		promotion.StartsAt,
+		promotion.EndsAt,
+	)
+	if err != nil {
+		return 0, domain.NewInternalError("fail to create promotion", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, domain.NewInternalError("fail to get last insert id", err)
+	}
+	promotion.ID = id
+	if err = p.createTargets(ctx, tx, promotion); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (p *promotionRepository) FindAll(ctx context.Context, tx helperdb.Tx) ([]domain.Promotion, error) {
+	return p.find(ctx, tx, findAllPromotionQuery)
+}
+
+// FindActive returns the promotions running now, by descending priority.
+func (p *promotionRepository) FindActive(ctx context.Context, tx helperdb.Tx) ([]domain.Promotion, error) {
+	return p.find(ctx, tx, findActivePromotionQuery)
+}
+
+// NextChangeAt returns the next time a promotion starts or ends, or nil when
+// none is due to.
+func (p *promotionRepository) NextChangeAt(ctx context.Context, tx helperdb.Tx) (*time.Time, error) {
+	var next sql.NullTime
+	if err := tx.QueryRowContext(ctx, nextChangePromotionQuery).Scan(&next); err != nil {
+		return nil, domain.NewInternalError("fail to get next promotion change", err)
+	}
+	if !next.Valid {
+		return nil, nil
+	}
+	return &next.Time, nil
+}
+
+func (p *promotionRepository) FindByID(ctx context.Context, tx helperdb.Tx, id int64) (domain.Promotion, error) {
+	promotion, err := scanPromotion(tx.QueryRowContext(ctx, findByIDPromotionQuery, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Promotion{}, domain.NewNotFoundError(fmt.Sprintf("promotion with ID %d not found", id), err)
+		}
+		return domain.Promotion{}, domain.NewInternalError("fail to get promotion", err)
+	}
+	promotions := []domain.Promotion{promotion}
+	if err = p.findTargets(ctx, tx, promotions); err != nil {
+		return domain.Promotion{}, err
+	}
+	return promotions[0], nil
+}
+
+// Update replaces the promotion and its targets.
+func (p *promotionRepository) Update(ctx context.Context, tx *sql.Tx, promotion domain.Promotion) error {
+	_, err := tx.ExecContext(
+		ctx,
+		updatePromotionQuery,
+		promotion.Name,
+		promotion.Type,
+		promotion.Value,
+		nullString(promotion.Currency),
+		promotion.MinPrice,
+		promotion.MaxPrice,
+		promotion.Priority,
+		promotion.Stackable,
+		promotion.StartsAt,
+		promotion.EndsAt,
+		promotion.ID,
+	)
+	if err != nil {
+		return domain.NewInternalError("fail to update promotion", err)
+	}
+	if _, err = tx.ExecContext(ctx, deletePromotionProductsQuery, promotion.ID); err != nil {
+		return domain.NewInternalError("fail to update promotion targets", err)
+	}
+	if _, err = tx.ExecContext(ctx, deletePromotionCategoriesQuery, promotion.ID); err != nil {
+		return domain.NewInternalError("fail to update promotion targets", err)
+	}
+	return p.createTargets(ctx, tx, promotion)
+}
+
+func (p *promotionRepository) Delete(ctx context.Context, tx *sql.Tx, id int64) (int64, error) {
+	res, err := tx.ExecContext(ctx, deletePromotionQuery, id)
+	if err != nil {
+		return 0, domain.NewInternalError("fail to delete promotion", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, domain.NewInternalError("fail to get rows affected", err)
+	}
+	if rowsAffected == 0 {
+		return 0, domain.NewNotFoundError(fmt.Sprintf("promotion with ID %d not found", id), nil)
+	}
+	return rowsAffected, nil
+}
+
+// find runs a promotions query and then fills their targets, once the rows
+// are closed so both queries can share the connection of a transaction.
+func (p *promotionRepository) find(ctx context.Context, tx helperdb.Tx, query string) ([]domain.Promotion, error) {
+	promotions, err := p.findPromotions(ctx, tx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.findTargets(ctx, tx, promotions); err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (p *promotionRepository) findPromotions(ctx context.Context, tx helperdb.Tx, query string) ([]domain.Promotion, error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get promotions", err)
+	}
+	defer rows.Close()
+
+	promotions := []domain.Promotion{}
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, domain.NewInternalError("fail to scan promotion", err)
+		}
+		promotions = append(promotions, promotion)
+	}
+	return promotions, nil
+}
+
+func (p *promotionRepository) createTargets(ctx context.Context, tx *sql.Tx, promotion domain.Promotion) error {
+	for _, productID := range promotion.ProductIDs {
+		if _, err := tx.ExecContext(ctx, createPromotionProductQuery, promotion.ID, productID); err != nil {
+			if mysql.IsForeignKeyViolation(err) {
+				return domain.NewBadRequest(fmt.Sprintf("product with ID %d not found", productID), err)
+			}
+			return domain.NewInternalError("fail to create promotion targets", err)
+		}
+	}
+	for _, categoryID := range promotion.CategoryIDs {
+		if _, err := tx.ExecContext(ctx, createPromotionCategoryQuery, promotion.ID, categoryID); err != nil {
+			if mysql.IsForeignKeyViolation(err) {
+				return domain.NewBadRequest(fmt.Sprintf("category with id %d not found", categoryID), err)
+			}
+			return domain.NewInternalError("fail to create promotion targets", err)
+		}
+	}
+	return nil
+}
+
+// findTargets fills the product and category IDs of promotions.
+func (p *promotionRepository) findTargets(ctx context.Context, tx helperdb.Tx, promotions []domain.Promotion) error {
+	if len(promotions) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*domain.Promotion, len(promotions))
+	args := make([]interface{}, 0, len(promotions))
+	for i := range promotions {
+		byID[promotions[i].ID] = &promotions[i]
+		args = append(args, promotions[i].ID)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(promotions)), ", ")
+
+	targets := []struct {
+		query string
+		add   func(promotion *domain.Promotion, id int64)
+	}{
+		{findPromotionProductsQuery, func(promotion *domain.Promotion, id int64) {
+			promotion.ProductIDs = append(promotion.ProductIDs, id)
+		}},
+		{findPromotionCategoriesQuery, func(promotion *domain.Promotion, id int64) {
+			promotion.CategoryIDs = append(promotion.CategoryIDs, id)
+		}},
+	}
+	for _, target := range targets {
+		if err := p.findTarget(ctx, tx, fmt.Sprintf(target.query, placeholders), args, byID, target.add); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *promotionRepository) findTarget(ctx context.Context, tx helperdb.Tx, query string, args []interface{}, byID map[int64]*domain.Promotion, add func(*domain.Promotion, int64)) error {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return domain.NewInternalError("fail to get promotion targets", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var promotionID, targetID int64
+		if err = rows.Scan(&promotionID, &targetID); err != nil {
+			return domain.NewInternalError("fail to scan promotion target", err)
+		}
+		if promotion, ok := byID[promotionID]; ok {
+			add(promotion, targetID)
+		}
+	}
+	return nil
+}
+
+func scanPromotion(row scanner) (domain.Promotion, error) {
+	var promotion domain.Promotion
+	var currency sql.NullString
+	err := row.Scan(
+		&promotion.ID,
+		&promotion.Name,
+		&promotion.Type,
+		&promotion.Value,
+		&currency,
+		&promotion.MinPrice,
+		&promotion.MaxPrice,
+		&promotion.Priority,
+		&promotion.Stackable,
+		&promotion.StartsAt,
+		&promotion.EndsAt,
+		&promotion.CreatedAt,
+	)
+	promotion.Currency = currency.String
+	return promotion, err
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var promotionRows = []string{
+	"id",
+	"name",
+	"type",
+	"value",
+	"currency",
+	"min_price",
+	"max_price",
+	"priority",
+	"stackable",
+	"starts_at",
+	"ends_at",
+	"created_at",
+}
+
+func InitialMockDBPromotion() domain.Promotion {
+	minPrice := money.MustParseDecimal("100.0000")
+	return domain.Promotion{
+		ID:          1,
+		Name:        "Black Friday",
+		Type:        domain.PromotionPercentage,
+		Value:       money.MustParseDecimal("10.0000"),
+		Currency:    "BRL",
+		ProductIDs:  []int64{7},
+		CategoryIDs: []int64{1, 2},
+		MinPrice:    &minPrice,
+		Priority:    10,
+		Stackable:   true,
+		StartsAt:    time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC),
+		EndsAt:      time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC),
+		CreatedAt:   time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func promotionRow(rows *sqlmock.Rows, promotion domain.Promotion) *sqlmock.Rows {
+	return rows.AddRow(promotion.ID, promotion.Name, promotion.Type, promotion.Value.String(), promotion.Currency,
+		promotion.MinPrice.String(), nil, promotion.Priority, promotion.Stackable, promotion.StartsAt, promotion.EndsAt, promotion.CreatedAt)
+}
+
+func expectPromotionTargets(mock sqlmock.Sqlmock, promotion domain.Promotion) {
+	products := sqlmock.NewRows([]string{"promotion_id", "product_id"})
+	for _, id := range promotion.ProductIDs {
+		products.AddRow(promotion.ID, id)
+	}
+	mock.ExpectQuery(QueryReplace(fmt.Sprintf(findPromotionProductsQuery, "?"))).
+		WithArgs(promotion.ID).
+		WillReturnRows(products)
+
+	categories := sqlmock.NewRows([]string{"promotion_id", "category_id"})
+	for _, id := range promotion.CategoryIDs {
+		categories.AddRow(promotion.ID, id)
+	}
+	mock.ExpectQuery(QueryReplace(fmt.Sprintf(findPromotionCategoriesQuery, "?"))).
+		WithArgs(promotion.ID).
+		WillReturnRows(categories)
+}
+
+func TestCreatePromotion_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	promotion := InitialMockDBPromotion()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createPromotionQuery)).
+		WithArgs(promotion.Name, promotion.Type, promotion.Value, promotion.Currency, promotion.MinPrice, nil,
+			promotion.Priority, promotion.Stackable, promotion.StartsAt, promotion.EndsAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(QueryReplace(createPromotionProductQuery)).
+		WithArgs(int64(1), int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(QueryReplace(createPromotionCategoryQuery)).
+		WithArgs(int64(1), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(QueryReplace(createPromotionCategoryQuery)).
+		WithArgs(int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	id, err := NewPromotionRepository().Create(context.Background(), tx, promotion)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, int64(1), id)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestCreatePromotion_WithMissingProduct(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	promotion := InitialMockDBPromotion()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createPromotionQuery)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(QueryReplace(createPromotionProductQuery)).
+		WithArgs(int64(1), int64(7)).
+		WillReturnError(&mysqlDriver.MySQLError{Number: 1452})
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = NewPromotionRepository().Create(context.Background(), tx, promotion)
+
+	var badRequest *domain.BadRequest
+	if assert.ErrorAs(t, err, &badRequest) {
+		assert.Equal(t, "product with ID 7 not found", badRequest.Message)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestCreatePromotion_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createPromotionQuery)).
+		WillReturnError(sql.ErrConnDone)
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = NewPromotionRepository().Create(context.Background(), tx, InitialMockDBPromotion())
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindActivePromotions_WithResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	promotion := InitialMockDBPromotion()
+
+	mock.ExpectQuery(QueryReplace(findActivePromotionQuery)).
+		WillReturnRows(promotionRow(sqlmock.NewRows(promotionRows), promotion))
+	expectPromotionTargets(mock, promotion)
+
+	promotions, err := NewPromotionRepository().FindActive(context.Background(), db)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []domain.Promotion{promotion}, promotions)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestNextPromotionChangeAt(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	next := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(QueryReplace(nextChangePromotionQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"MIN(edge)"}).AddRow(next))
+
+	changeAt, err := NewPromotionRepository().NextChangeAt(context.Background(), db)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, &next, changeAt)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestNextPromotionChangeAt_WithoutChanges(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(nextChangePromotionQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"MIN(edge)"}).AddRow(nil))
+
+	changeAt, err := NewPromotionRepository().NextChangeAt(context.Background(), db)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Nil(t, changeAt)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindAllPromotions_WithoutResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findAllPromotionQuery)).
+		WillReturnRows(sqlmock.NewRows(promotionRows))
+
+	promotions, err := NewPromotionRepository().FindAll(context.Background(), db)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Empty(t, promotions)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindAllPromotions_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findAllPromotionQuery)).
+		WillReturnError(sql.ErrConnDone)
+
+	_, err := NewPromotionRepository().FindAll(context.Background(), db)
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindPromotionByID_WithResult(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	promotion := InitialMockDBPromotion()
+
+	mock.ExpectQuery(QueryReplace(findByIDPromotionQuery)).
+		WithArgs(promotion.ID).
+		WillReturnRows(promotionRow(sqlmock.NewRows(promotionRows), promotion))
+	expectPromotionTargets(mock, promotion)
+
+	found, err := NewPromotionRepository().FindByID(context.Background(), db, promotion.ID)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, promotion, found)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindPromotionByID_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findByIDPromotionQuery)).
+		WithArgs(int64(1)).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := NewPromotionRepository().FindByID(context.Background(), db, 1)
+
+	var notFound *domain.NotFoundError
+	if assert.ErrorAs(t, err, &notFound) {
+		assert.Equal(t, "promotion with ID 1 not found", notFound.Message)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestUpdatePromotion_ReplacesTargets(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	promotion := InitialMockDBPromotion()
+	promotion.CategoryIDs = nil
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(updatePromotionQuery)).
+		WithArgs(promotion.Name, promotion.Type, promotion.Value, promotion.Currency, promotion.MinPrice, nil,
+			promotion.Priority, promotion.Stackable, promotion.StartsAt, promotion.EndsAt, promotion.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(QueryReplace(deletePromotionProductsQuery)).
+		WithArgs(promotion.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(QueryReplace(deletePromotionCategoriesQuery)).
+		WithArgs(promotion.ID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(QueryReplace(createPromotionProductQuery)).
+		WithArgs(promotion.ID, int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewPromotionRepository().Update(context.Background(), tx, promotion)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestDeletePromotion_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(deletePromotionQuery)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = NewPromotionRepository().Delete(context.Background(), tx, 1)
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
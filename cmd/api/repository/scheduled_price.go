@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+)
+
+type ScheduledPriceRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, change domain.ScheduledPriceChange) (int64, error)
+	FindByID(ctx context.Context, tx helperdb.Tx, id int64) (domain.ScheduledPriceChange, error)
+	FindByProduct(ctx context.Context, tx helperdb.Tx, productID int64, status string) ([]domain.ScheduledPriceChange, error)
+	FindDue(ctx context.Context, tx helperdb.Tx, limit int) ([]int64, error)
+	Lock(ctx context.Context, tx *sql.Tx, id int64) (domain.ScheduledPriceChange, error)
+	MarkApplied(ctx context.Context, tx *sql.Tx, id int64) error
+	RecordFailure(ctx context.Context, tx *sql.Tx, id int64, cause string, retryAt *time.Time) error
+	Cancel(ctx context.Context, tx *sql.Tx, id int64) (int64, error)
+}
+
+type scheduledPriceRepository struct {
+}
+
+func NewScheduledPriceRepository() ScheduledPriceRepository {
+	return &scheduledPriceRepository{}
+}
+
+const (
+	scheduledPriceColumns = "id, product_id, new_price, new_currency, effective_at, reason, created_by, status, attempts, last_error, next_attempt_at, applied_at, created_at, updated_at"
+
+	createScheduledPriceQuery        = "INSERT INTO scheduled_price_changes (product_id, new_price, new_currency, effective_at, reason, created_by, status, attempts, last_error, next_attempt_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, 'pending', 0, '', ?, NOW(), NOW())"
+	findByIDScheduledPriceQuery      = "SELECT " + scheduledPriceColumns + " FROM scheduled_price_changes WHERE id = ?"
+	findByProductScheduledPriceQuery = "SELECT " + scheduledPriceColumns + " FROM scheduled_price_changes WHERE product_id = ?"
+	findDueScheduledPriceQuery       = "SELECT id FROM scheduled_price_changes WHERE status = 'pending' AND next_attempt_at <= NOW() ORDER BY effective_at, id LIMIT ?"
+	lockScheduledPriceQuery          = "SELECT " + scheduledPriceColumns + " FROM scheduled_price_changes WHERE id = ? FOR UPDATE"
+	markAppliedScheduledPriceQuery   = "UPDATE scheduled_price_changes SET status = 'applied', attempts = attempts + 1, applied_at = NOW(), updated_at = NOW() WHERE id = ?"
+	retryScheduledPriceQuery         = "UPDATE scheduled_price_changes SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?, updated_at = NOW() WHERE id = ?"
+	failScheduledPriceQuery          = "UPDATE scheduled_price_changes SET status = 'failed', attempts = attempts + 1, last_error = ?, updated_at = NOW() WHERE id = ?"
+	cancelScheduledPriceQuery        = "UPDATE scheduled_price_changes SET status = 'cancelled', updated_at = NOW() WHERE id = ? AND status = 'pending'"
+)
+
+func (s *scheduledPriceRepository) Create(ctx context.Context, tx *sql.Tx, change domain.ScheduledPriceChange) (int64, error) {
+	res, err := tx.ExecContext(
+		ctx,
+		createScheduledPriceQuery,
+		change.ProductID,
+		change.NewPrice,
+		change.NewCurrency,
+		change.EffectiveAt,
+		change.Reason,
+		change.CreatedBy,
+		change.EffectiveAt,
+	)
+	if err != nil {
+		return 0, domain.NewInternalError("fail to create scheduled price change", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, domain.NewInternalError("fail to get last insert id", err)
+	}
+	return id, nil
+}
+
+func (s *scheduledPriceRepository) FindByID(ctx context.Context, tx helperdb.Tx, id int64) (domain.ScheduledPriceChange, error) {
+	change, err := scanScheduledPrice(tx.QueryRowContext(ctx, findByIDScheduledPriceQuery, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ScheduledPriceChange{}, domain.NewNotFoundError(fmt.Sprintf("scheduled price change with ID %d not found", id), err)
+		}
+		return domain.ScheduledPriceChange{}, domain.NewInternalError("fail to get scheduled price change", err)
+	}
+	return change, nil
+}
+
+// FindByProduct returns the scheduled price changes of a product, in the
+// order they take effect. An empty status returns all of them.
+func (s *scheduledPriceRepository) FindByProduct(ctx context.Context, tx helperdb.Tx, productID int64, status string) ([]domain.ScheduledPriceChange, error) {
+	var query bytes.Buffer
+	query.WriteString(findByProductScheduledPriceQuery)
+	queryParams := []interface{}{productID}
+	if status != "" {
+		query.WriteString(" AND status = ?")
+		queryParams = append(queryParams, status)
+	}
+	query.WriteString(" ORDER BY effective_at, id")
+
+	rows, err := tx.QueryContext(ctx, query.String(), queryParams...)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get scheduled price changes", err)
+	}
+	defer rows.Close()
+
+	changes := []domain.ScheduledPriceChange{}
+	for rows.Next() {
+		change, err := scanScheduledPrice(rows)
+		if err != nil {
+			return nil, domain.NewInternalError("fail to scan scheduled price change", err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// FindDue returns the IDs of up to limit pending changes whose effective
+// time, or next retry, has come.
+func (s *scheduledPriceRepository) FindDue(ctx context.Context, tx helperdb.Tx, limit int) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, findDueScheduledPriceQuery, limit)
+	if err != nil {
+		return nil, domain.NewInternalError("fail to get due scheduled price changes", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			return nil, domain.NewInternalError("fail to scan scheduled price change", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Lock reads a change and locks it until tx ends, so it is applied once even
+// with several instances running the scheduler.
+func (s *scheduledPriceRepository) Lock(ctx context.Context, tx *sql.Tx, id int64) (domain.ScheduledPriceChange, error) {
+	change, err := scanScheduledPrice(tx.QueryRowContext(ctx, lockScheduledPriceQuery, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ScheduledPriceChange{}, domain.NewNotFoundError(fmt.Sprintf("scheduled price change with ID %d not found", id), err)
+		}
+		return domain.ScheduledPriceChange{}, domain.NewInternalError("fail to lock scheduled price change", err)
+	}
+	return change, nil
+}
+
+func (s *scheduledPriceRepository) MarkApplied(ctx context.Context, tx *sql.Tx, id int64) error {
+	if _, err := tx.ExecContext(ctx, markAppliedScheduledPriceQuery, id); err != nil {
+		return domain.NewInternalError("fail to update scheduled price change", err)
+	}
+	return nil
+}
+
+// RecordFailure counts a failed attempt. The change is retried at retryAt or,
+// when it is nil, marked as failed.
+func (s *scheduledPriceRepository) RecordFailure(ctx context.Context, tx *sql.Tx, id int64, cause string, retryAt *time.Time) error {
+	var err error
+	if retryAt != nil {
+		_, err = tx.ExecContext(ctx, retryScheduledPriceQuery, cause, *retryAt, id)
+	} else {
+		_, err = tx.ExecContext(ctx, failScheduledPriceQuery, cause, id)
+	}
+	if err != nil {
+		return domain.NewInternalError("fail to update scheduled price change", err)
+	}
+	return nil
+}
+
+// Cancel cancels a pending change and returns 0 when it is no longer pending.
+func (s *scheduledPriceRepository) Cancel(ctx context.Context, tx *sql.Tx, id int64) (int64, error) {
+	res, err := tx.ExecContext(ctx, cancelScheduledPriceQuery, id)
+	if err != nil {
+		return 0, domain.NewInternalError("fail to cancel scheduled price change", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, domain.NewInternalError("fail to get rows affected", err)
+	}
+	return rowsAffected, nil
+}
+
+func scanScheduledPrice(row scanner) (domain.ScheduledPriceChange, error) {
+	var change domain.ScheduledPriceChange
+	err := row.Scan(
+		&change.ID,
+		&change.ProductID,
+		&change.NewPrice,
+		&change.NewCurrency,
+		&change.EffectiveAt,
+		&change.Reason,
+		&change.CreatedBy,
+		&change.Status,
+		&change.Attempts,
+		&change.LastError,
+		&change.NextAttemptAt,
+		&change.AppliedAt,
+		&change.CreatedAt,
+		&change.UpdatedAt,
+	)
+	return change, err
+}
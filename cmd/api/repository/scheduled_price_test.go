@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/stretchr/testify/assert"
+)
+
+var scheduledPriceRows = []string{
+	"id",
+	"product_id",
+	"new_price",
+	"new_currency",
+	"effective_at",
+	"reason",
+	"created_by",
+	"status",
+	"attempts",
+	"last_error",
+	"next_attempt_at",
+	"applied_at",
+	"created_at",
+	"updated_at",
+}
+
+func InitialMockDBScheduledPriceChange() domain.ScheduledPriceChange {
+	effectiveAt := time.Date(2020, 11, 27, 0, 0, 0, 0, time.UTC)
+	return domain.ScheduledPriceChange{
+		ID:            1,
+		ProductID:     1,
+		NewPrice:      money.MustParseDecimal("10.50"),
+		NewCurrency:   "BRL",
+		EffectiveAt:   effectiveAt,
+		Reason:        "black friday",
+		CreatedBy:     "integration-1",
+		Status:        domain.ScheduledPricePending,
+		NextAttemptAt: effectiveAt,
+		CreatedAt:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func scheduledPriceRow(rows *sqlmock.Rows, change domain.ScheduledPriceChange) *sqlmock.Rows {
+	return rows.AddRow(
+		change.ID,
+		change.ProductID,
+		change.NewPrice.String(),
+		change.NewCurrency,
+		change.EffectiveAt,
+		change.Reason,
+		change.CreatedBy,
+		change.Status,
+		change.Attempts,
+		change.LastError,
+		change.NextAttemptAt,
+		change.AppliedAt,
+		change.CreatedAt,
+		change.UpdatedAt,
+	)
+}
+
+func TestCreateScheduledPriceChange_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	change := InitialMockDBScheduledPriceChange()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createScheduledPriceQuery)).
+		WithArgs(change.ProductID, change.NewPrice, change.NewCurrency, change.EffectiveAt, change.Reason, change.CreatedBy, change.EffectiveAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	id, err := NewScheduledPriceRepository().Create(context.Background(), tx, change)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, int64(1), id)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestCreateScheduledPriceChange_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	change := InitialMockDBScheduledPriceChange()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(createScheduledPriceQuery)).
+		WithArgs(change.ProductID, change.NewPrice, change.NewCurrency, change.EffectiveAt, change.Reason, change.CreatedBy, change.EffectiveAt).
+		WillReturnError(sql.ErrConnDone)
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	_, err = NewScheduledPriceRepository().Create(context.Background(), tx, change)
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindScheduledPriceChangeByID_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	change := InitialMockDBScheduledPriceChange()
+
+	mock.ExpectQuery(QueryReplace(findByIDScheduledPriceQuery)).
+		WithArgs(change.ID).
+		WillReturnRows(scheduledPriceRow(sqlmock.NewRows(scheduledPriceRows), change))
+
+	found, err := NewScheduledPriceRepository().FindByID(context.Background(), db, change.ID)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, change, found)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindScheduledPriceChangeByID_NotFound(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findByIDScheduledPriceQuery)).
+		WithArgs(int64(1)).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := NewScheduledPriceRepository().FindByID(context.Background(), db, 1)
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindScheduledPriceChangesByProduct_WithResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	change := InitialMockDBScheduledPriceChange()
+
+	mock.ExpectQuery(QueryReplace(findByProductScheduledPriceQuery + " ORDER BY effective_at, id")).
+		WithArgs(change.ProductID).
+		WillReturnRows(scheduledPriceRow(sqlmock.NewRows(scheduledPriceRows), change))
+
+	changes, err := NewScheduledPriceRepository().FindByProduct(context.Background(), db, change.ProductID, "")
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []domain.ScheduledPriceChange{change}, changes)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindScheduledPriceChangesByProduct_WithStatus(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findByProductScheduledPriceQuery+" AND status = ? ORDER BY effective_at, id")).
+		WithArgs(int64(1), domain.ScheduledPriceFailed).
+		WillReturnRows(sqlmock.NewRows(scheduledPriceRows))
+
+	changes, err := NewScheduledPriceRepository().FindByProduct(context.Background(), db, 1, domain.ScheduledPriceFailed)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Empty(t, changes)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindDueScheduledPriceChanges_WithResults(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findDueScheduledPriceQuery)).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)).AddRow(int64(3)))
+
+	ids, err := NewScheduledPriceRepository().FindDue(context.Background(), db, 100)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []int64{1, 3}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestFindDueScheduledPriceChanges_WithError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectQuery(QueryReplace(findDueScheduledPriceQuery)).
+		WithArgs(100).
+		WillReturnError(sql.ErrConnDone)
+
+	_, err := NewScheduledPriceRepository().FindDue(context.Background(), db, 100)
+
+	var internalErr *domain.InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestLockScheduledPriceChange_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	change := InitialMockDBScheduledPriceChange()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(QueryReplace(lockScheduledPriceQuery)).
+		WithArgs(change.ID).
+		WillReturnRows(scheduledPriceRow(sqlmock.NewRows(scheduledPriceRows), change))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	locked, err := NewScheduledPriceRepository().Lock(context.Background(), tx, change.ID)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, change, locked)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestMarkScheduledPriceChangeApplied_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(markAppliedScheduledPriceQuery)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewScheduledPriceRepository().MarkApplied(context.Background(), tx, 1)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestRecordScheduledPriceFailure_WithRetry(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	retryAt := time.Date(2020, 11, 27, 0, 5, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(retryScheduledPriceQuery)).
+		WithArgs("fail to update product", retryAt, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewScheduledPriceRepository().RecordFailure(context.Background(), tx, 1, "fail to update product", &retryAt)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestRecordScheduledPriceFailure_WithoutRetry(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(failScheduledPriceQuery)).
+		WithArgs("product with ID 1 not found", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	err = NewScheduledPriceRepository().RecordFailure(context.Background(), tx, 1, "product with ID 1 not found", nil)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestCancelScheduledPriceChange_WithoutError(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(cancelScheduledPriceQuery)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	cancelled, err := NewScheduledPriceRepository().Cancel(context.Background(), tx, 1)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, int64(1), cancelled)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
+
+func TestCancelScheduledPriceChange_NotPending(t *testing.T) {
+	db, mock := InitialCommonMocks()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(QueryReplace(cancelScheduledPriceQuery)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err, "Error should not be returned")
+
+	cancelled, err := NewScheduledPriceRepository().Cancel(context.Background(), tx, 1)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, int64(0), cancelled)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Error should not be returned")
+}
@@ -0,0 +1,174 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/logging"
+	"github.com/mercadolibre/fury_go-core/pkg/log"
+)
+
+// Server is the HTTP server run by Serve.
+type Server interface {
+	ListenAndServe() error
+	Shutdown(ctx context.Context) error
+}
+
+// Hook is a start or stop step of the runtime lifecycle.
+type Hook func(ctx context.Context) error
+
+type lifecycle struct {
+	mu         sync.Mutex
+	startHooks []Hook
+	stopHooks  []Hook
+	stopping   bool
+	onStopping []func()
+
+	workers       sync.WaitGroup
+	workersCtx    context.Context
+	cancelWorkers context.CancelFunc
+}
+
+func newLifecycle() *lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &lifecycle{
+		workersCtx:    ctx,
+		cancelWorkers: cancel,
+	}
+}
+
+// OnStart registers a hook executed by Start, in registration order.
+func (r *Runtime) OnStart(hook Hook) {
+	r.lifecycle.mu.Lock()
+	defer r.lifecycle.mu.Unlock()
+	r.lifecycle.startHooks = append(r.lifecycle.startHooks, hook)
+}
+
+// OnStop registers a hook executed by Stop after the background workers are
+// done, in reverse registration order.
+func (r *Runtime) OnStop(hook Hook) {
+	r.lifecycle.mu.Lock()
+	defer r.lifecycle.mu.Unlock()
+	r.lifecycle.stopHooks = append(r.lifecycle.stopHooks, hook)
+}
+
+// OnStopping registers a callback executed as soon as the shutdown begins,
+// before in-flight requests are drained.
+func (r *Runtime) OnStopping(callback func()) {
+	r.lifecycle.mu.Lock()
+	defer r.lifecycle.mu.Unlock()
+	r.lifecycle.onStopping = append(r.lifecycle.onStopping, callback)
+}
+
+// Go runs worker in the background. Its context is cancelled when the runtime
+// stops, and Stop waits for it to return.
+func (r *Runtime) Go(name string, worker func(ctx context.Context)) {
+	r.lifecycle.workers.Add(1)
+	go func() {
+		defer r.lifecycle.workers.Done()
+		worker(r.lifecycle.workersCtx)
+		logging.Info(context.Background(), "background worker finished", log.String("worker", name))
+	}()
+}
+
+func (r *Runtime) Start(ctx context.Context) error {
+	r.lifecycle.mu.Lock()
+	hooks := append([]Hook(nil), r.lifecycle.startHooks...)
+	r.lifecycle.mu.Unlock()
+
+	for _, hook := range hooks {
+		if err := hook(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BeginShutdown marks the runtime as stopping so readiness starts failing.
+// It is safe to call more than once.
+func (r *Runtime) BeginShutdown() {
+	r.lifecycle.mu.Lock()
+	if r.lifecycle.stopping {
+		r.lifecycle.mu.Unlock()
+		return
+	}
+	r.lifecycle.stopping = true
+	callbacks := append([]func(){}, r.lifecycle.onStopping...)
+	r.lifecycle.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+// Stop cancels the background workers, waits for them until ctx is done and
+// then runs the stop hooks.
+func (r *Runtime) Stop(ctx context.Context) error {
+	r.BeginShutdown()
+	r.lifecycle.cancelWorkers()
+
+	done := make(chan struct{})
+	go func() {
+		r.lifecycle.workers.Wait()
+		close(done)
+	}()
+
+	var errs []error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		errs = append(errs, errors.New("background workers did not finish before the shutdown deadline"))
+	}
+
+	r.lifecycle.mu.Lock()
+	hooks := append([]Hook(nil), r.lifecycle.stopHooks...)
+	r.lifecycle.mu.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Serve runs server until ctx is done and then shuts down within
+// shutdown.timeoutseconds: readiness fails first, the server keeps serving for
+// the pre-drain delay so the load balancer stops routing, in-flight requests
+// are drained and Stop gets whatever is left of the budget.
+func (r *Runtime) Serve(ctx context.Context, server Server) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	shutdown := r.Environment.Shutdown
+	select {
+	case err := <-serveErr:
+		stopCtx, cancel := context.WithTimeout(context.Background(), shutdown.Timeout())
+		defer cancel()
+		return errors.Join(err, r.Stop(stopCtx))
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdown.Timeout())
+	defer cancel()
+
+	r.BeginShutdown()
+	preDrain := time.NewTimer(shutdown.PreDrainDelay())
+	defer preDrain.Stop()
+	select {
+	case <-preDrain.C:
+	case <-shutdownCtx.Done():
+	}
+
+	errs := []error{server.Shutdown(shutdownCtx)}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		errs = append(errs, err)
+	}
+	errs = append(errs, r.Stop(shutdownCtx))
+	return errors.Join(errs...)
+}
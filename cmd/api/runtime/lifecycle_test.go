@@ -0,0 +1,115 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStop_WaitsForWorkersAndRunsHooksInReverseOrder(t *testing.T) {
+	run := &Runtime{lifecycle: newLifecycle()}
+	var calls []string
+
+	run.OnStopping(func() { calls = append(calls, "stopping") })
+	run.OnStop(func(ctx context.Context) error {
+		calls = append(calls, "close db")
+		return nil
+	})
+	run.OnStop(func(ctx context.Context) error {
+		calls = append(calls, "flush cache")
+		return nil
+	})
+	run.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		calls = append(calls, "worker done")
+	})
+
+	err := run.Stop(context.Background())
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.Equal(t, []string{"stopping", "worker done", "flush cache", "close db"}, calls)
+}
+
+func TestStop_WithWorkerPastDeadline(t *testing.T) {
+	run := &Runtime{lifecycle: newLifecycle()}
+	release := make(chan struct{})
+	defer close(release)
+	closed := false
+
+	run.OnStop(func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+	run.Go("stuck", func(ctx context.Context) {
+		<-release
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := run.Stop(ctx)
+
+	assert.Error(t, err, "Error should be returned")
+	assert.True(t, closed)
+}
+
+func TestStart_StopsAtFirstError(t *testing.T) {
+	run := &Runtime{lifecycle: newLifecycle()}
+	hookErr := errors.New("fail")
+	called := false
+
+	run.OnStart(func(ctx context.Context) error { return hookErr })
+	run.OnStart(func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	err := run.Start(context.Background())
+
+	assert.ErrorIs(t, err, hookErr)
+	assert.False(t, called)
+}
+
+type serverMock struct {
+	closed     chan struct{}
+	shutdownAt time.Time
+}
+
+func (s *serverMock) ListenAndServe() error {
+	<-s.closed
+	return http.ErrServerClosed
+}
+
+func (s *serverMock) Shutdown(_ context.Context) error {
+	s.shutdownAt = time.Now()
+	close(s.closed)
+	return nil
+}
+
+func TestServe_DrainsAfterPreDrainDelayWithinBudget(t *testing.T) {
+	run := &Runtime{lifecycle: newLifecycle(), Environment: config.Environment{
+		Shutdown: config.ShutdownConfig{TimeoutSeconds: 2, PreDrainSeconds: 1},
+	}}
+	server := &serverMock{closed: make(chan struct{})}
+	var stoppingAt time.Time
+	var stopDeadline time.Time
+	run.OnStopping(func() { stoppingAt = time.Now() })
+	run.OnStop(func(ctx context.Context) error {
+		stopDeadline, _ = ctx.Deadline()
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	err := run.Serve(ctx, server)
+
+	assert.NoError(t, err, "Error should not be returned")
+	assert.False(t, stoppingAt.IsZero())
+	assert.GreaterOrEqual(t, server.shutdownAt.Sub(stoppingAt), time.Second)
+	assert.WithinDuration(t, start.Add(2*time.Second), stopDeadline, 100*time.Millisecond)
+}
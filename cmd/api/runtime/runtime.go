@@ -2,24 +2,45 @@ package runtime
 
 import (
 	"context"
+	"time"
 
 	"github.com/mercadolibre/fury_go-core/pkg/log"
 
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/controller"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/auth"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/cache"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/logging"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/ratelimit"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/storage"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/service"
 )
 
 type Runtime struct {
-	Environment        config.Environment
-	ProductController  controller.ProductController
-	CategoryController controller.CategoryController
+	Environment                 config.Environment
+	ProductController           controller.ProductController
+	CategoryController          controller.CategoryController
+	HealthController            controller.HealthController
+	APIKeyController            controller.APIKeyController
+	PriceListController         controller.PriceListController
+	PromotionController         controller.PromotionController
+	ScheduledPriceController    controller.ScheduledPriceController
+	ProductImageController      controller.ProductImageController
+	UploadController            controller.UploadController
+	CategoryAttributeController controller.CategoryAttributeController
+	AuthService                 service.AuthService
+	RateLimitStore              ratelimit.Store
+	IdempotencyService          service.IdempotencyService
+
+	lifecycle *lifecycle
 }
 
 func InstanceRuntime() *Runtime {
 	env := config.InitConfig()
+	dto.SetPricing(dto.Pricing{Format: env.Pricing.Format(), DefaultCurrency: env.Pricing.Currency()})
 
 	//database
 	mySQLClient, err := mysql.NewMySQL(env)
@@ -30,18 +51,178 @@ func InstanceRuntime() *Runtime {
 	//repositories
 	productRepository := repository.NewProductRepository()
 	categoryRepository := repository.NewCategoryRepository()
+	migrationRepository := repository.NewMigrationRepository()
+	apiKeyRepository := repository.NewAPIKeyRepository()
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepository()
+	productPriceRepository := repository.NewProductPriceRepository()
+	exchangeRateRepository := repository.NewExchangeRateRepository()
+	priceHistoryRepository := repository.NewPriceHistoryRepository()
+	promotionRepository := repository.NewPromotionRepository()
+	scheduledPriceRepository := repository.NewScheduledPriceRepository()
+	productImageRepository := repository.NewProductImageRepository()
+	categoryAttributeRepository := repository.NewCategoryAttributeRepository()
+	productAttributeRepository := repository.NewProductAttributeRepository()
+
+	//auth
+	jwtVerifier, err := auth.NewJWTVerifier(auth.JWTConfig{
+		HMACSecret: env.Auth.HMACSecret,
+		JWKSPath:   env.Auth.JWKSPath,
+		Issuer:     env.Auth.Issuer,
+		Audience:   env.Auth.Audience,
+	})
+	if err != nil {
+		log.Panic(context.Background(), err.Error())
+	}
+
+	//storage
+	imageStorage, err := storage.NewLocal(env.Uploads.Path, env.Uploads.BaseURL)
+	if err != nil {
+		log.Panic(context.Background(), err.Error())
+	}
 
 	//services
-	productService := service.NewProductService(productRepository, categoryRepository, mySQLClient, env)
+	var productService service.ProductService = service.NewProductService(productRepository, categoryRepository, priceHistoryRepository, promotionRepository, productImageRepository, categoryAttributeRepository, productAttributeRepository, mySQLClient, env)
 	categoryService := service.NewCategoryService(categoryRepository, mySQLClient, env)
+	healthService := service.NewHealthService(migrationRepository, mySQLClient, env.Health)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepository, mySQLClient)
+	authService := service.NewAuthService(apiKeyRepository, jwtVerifier, mySQLClient)
+	idempotencyService := service.NewIdempotencyService(idempotencyKeyRepository, mySQLClient, env.Idempotency)
+	priceListService := service.NewPriceListService(productRepository, productPriceRepository, exchangeRateRepository, priceHistoryRepository, promotionRepository, mySQLClient)
+	exchangeRateService := service.NewExchangeRateService(exchangeRateRepository, mySQLClient)
+	promotionService := service.NewPromotionService(promotionRepository, mySQLClient)
+	scheduledPriceService := service.NewScheduledPriceService(scheduledPriceRepository, productRepository, priceHistoryRepository, mySQLClient, env.ScheduledPrices)
+	productImageService := service.NewProductImageService(productImageRepository, productRepository, mySQLClient)
+	uploadService := service.NewUploadService(imageStorage, env.Uploads)
+	thumbnailService := service.NewThumbnailService(productImageRepository, productRepository, imageStorage, mySQLClient, env.Thumbnails)
+	categoryAttributeService := service.NewCategoryAttributeService(categoryAttributeRepository, categoryRepository, mySQLClient)
+
+	//cache
+	if env.Cache.Enabled {
+		lru := cache.NewLRU(env.Cache.MaxEntries)
+		productService = service.NewCachedProductService(productService, lru, env.Cache)
+		categoryService = service.NewCachedCategoryService(categoryService, lru, env.Cache)
+		promotionService = service.NewCachedPromotionService(promotionService, lru)
+		scheduledPriceService = service.NewCachedScheduledPriceService(scheduledPriceService, lru)
+		productImageService = service.NewCachedProductImageService(productImageService, lru)
+		thumbnailService = service.NewCachedThumbnailService(thumbnailService, lru)
+		categoryAttributeService = service.NewCachedCategoryAttributeService(categoryAttributeService, lru)
+	}
 
 	//controllers
-	productController := controller.NewProductController(productService, env)
+	productController := controller.NewProductController(productService, priceListService, env)
 	categoryController := controller.NewCategoryController(categoryService)
+	healthController := controller.NewHealthController(healthService)
+	apiKeyController := controller.NewAPIKeyController(apiKeyService)
+	priceListController := controller.NewPriceListController(priceListService, exchangeRateService)
+	promotionController := controller.NewPromotionController(promotionService)
+	scheduledPriceController := controller.NewScheduledPriceController(scheduledPriceService)
+	productImageController := controller.NewProductImageController(productImageService)
+	uploadController := controller.NewUploadController(uploadService, env.Uploads)
+	categoryAttributeController := controller.NewCategoryAttributeController(categoryAttributeService)
+
+	run := &Runtime{
+		Environment:                 env,
+		ProductController:           productController,
+		CategoryController:          categoryController,
+		HealthController:            healthController,
+		APIKeyController:            apiKeyController,
+		PriceListController:         priceListController,
+		PromotionController:         promotionController,
+		ScheduledPriceController:    scheduledPriceController,
+		ProductImageController:      productImageController,
+		UploadController:            uploadController,
+		CategoryAttributeController: categoryAttributeController,
+		AuthService:                 authService,
+		RateLimitStore:              ratelimit.NewMemoryStore(),
+		IdempotencyService:          idempotencyService,
+		lifecycle:                   newLifecycle(),
+	}
+
+	run.OnStart(func(ctx context.Context) error {
+		if path := env.ExchangeRates.Path; path != "" {
+			loaded, err := exchangeRateService.LoadFile(ctx, path)
+			if err != nil {
+				return err
+			}
+			logging.Info(ctx, "exchange rates loaded", log.String("path", path), log.Int("rates", loaded))
+		}
+		run.Go("idempotency-purge", purgeIdempotencyKeys(idempotencyService, env.Idempotency.PurgeInterval()))
+		run.Go("scheduled-prices", applyScheduledPrices(scheduledPriceService, env.ScheduledPrices.Interval()))
+		run.Go("thumbnails", generateThumbnails(thumbnailService, env.Thumbnails.Interval()))
+		return nil
+	})
+	run.OnStopping(healthService.SetShuttingDown)
+	run.OnStop(func(ctx context.Context) error {
+		return mySQLClient.Close()
+	})
+
+	return run
+}
+
+// purgeIdempotencyKeys deletes expired idempotency keys every interval.
+func purgeIdempotencyKeys(idempotencyService service.IdempotencyService, interval time.Duration) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				purged, err := idempotencyService.PurgeExpired(ctx)
+				if err != nil {
+					logging.Warn(ctx, "fail to purge expired idempotency keys", log.Err(err))
+					continue
+				}
+				if purged > 0 {
+					logging.Info(ctx, "expired idempotency keys purged", log.Int64("purged", purged))
+				}
+			}
+		}
+	}
+}
+
+// applyScheduledPrices applies the due scheduled price changes every interval.
+func applyScheduledPrices(scheduledPriceService service.ScheduledPriceService, interval time.Duration) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				applied, err := scheduledPriceService.ApplyDue(ctx)
+				if err != nil {
+					logging.Warn(ctx, "fail to apply scheduled price changes", log.Err(err))
+				}
+				if applied > 0 {
+					logging.Info(ctx, "scheduled price changes applied", log.Int("applied", applied))
+				}
+			}
+		}
+	}
+}
 
-	return &Runtime{
-		Environment:        env,
-		ProductController:  productController,
-		CategoryController: categoryController,
+// generateThumbnails generates the renditions of the pending product images
+// every interval.
+func generateThumbnails(thumbnailService service.ThumbnailService, interval time.Duration) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				generated, err := thumbnailService.GenerateDue(ctx)
+				if err != nil {
+					logging.Warn(ctx, "fail to generate image thumbnails", log.Err(err))
+				}
+				if generated > 0 {
+					logging.Info(ctx, "image thumbnails generated", log.Int("images", generated))
+				}
+			}
+		}
 	}
 }
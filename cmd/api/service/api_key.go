@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/auth"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+)
+
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, apiKeyDTO dto.APIKeyDTO) (dto.APIKeyCreatedResponseDTO, error)
+	GetAPIKeys(ctx context.Context) ([]dto.APIKeyResponseDTO, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+}
+
+type apiKeyService struct {
+	apiKeyRepository repository.APIKeyRepository
+	db               mysql.DB
+}
+
+func NewAPIKeyService(apiKeyRepository repository.APIKeyRepository, db mysql.DB) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepository: apiKeyRepository,
+		db:               db,
+	}
+}
+
+func (a *apiKeyService) CreateAPIKey(ctx context.Context, apiKeyDTO dto.APIKeyDTO) (dto.APIKeyCreatedResponseDTO, error) {
+	key, prefix, err := auth.GenerateAPIKey()
+	if err != nil {
+		return dto.APIKeyCreatedResponseDTO{}, domain.NewInternalError("fail to generate api key", err)
+	}
+
+	var apiKey domain.APIKey
+	txErr := a.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		id, err := a.apiKeyRepository.Create(ctx, tx, domain.APIKey{
+			Name:   apiKeyDTO.Name,
+			Prefix: prefix,
+			Hash:   auth.HashAPIKey(key),
+			Scopes: apiKeyDTO.Scopes,
+		})
+		if err != nil {
+			return err
+		}
+		apiKey, err = a.apiKeyRepository.FindByID(ctx, tx, id)
+		return err
+	})
+	if txErr != nil {
+		return dto.APIKeyCreatedResponseDTO{}, txErr
+	}
+
+	return dto.APIKeyCreatedResponseDTO{
+		APIKeyResponseDTO: toAPIKeyResponseDTO(apiKey),
+		Key:               key,
+	}, nil
+}
+
+func (a *apiKeyService) GetAPIKeys(ctx context.Context) ([]dto.APIKeyResponseDTO, error) {
+	var apiKeysDTO []dto.APIKeyResponseDTO
+	txErr := a.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		apiKeys, err := a.apiKeyRepository.FindAll(ctx, tx)
+		if err != nil {
+			return err
+		}
+		apiKeysDTO = make([]dto.APIKeyResponseDTO, 0, len(apiKeys))
+		for _, apiKey := range apiKeys {
+			apiKeysDTO = append(apiKeysDTO, toAPIKeyResponseDTO(apiKey))
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return apiKeysDTO, nil
+}
+
+func (a *apiKeyService) RevokeAPIKey(ctx context.Context, id int64) error {
+	return a.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := a.apiKeyRepository.Revoke(ctx, tx, id)
+		return err
+	})
+}
+
+func toAPIKeyResponseDTO(apiKey domain.APIKey) dto.APIKeyResponseDTO {
+	return dto.APIKeyResponseDTO{
+		ID:        apiKey.ID,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Scopes:    apiKey.Scopes,
+		CreatedAt: apiKey.CreatedAt,
+		RevokedAt: apiKey.RevokedAt,
+	}
+}
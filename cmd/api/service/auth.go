@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/auth"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+)
+
+var ErrMissingCredentials = errors.New("missing credentials")
+
+type AuthService interface {
+	Authenticate(ctx context.Context, credentials auth.Credentials) (auth.Principal, error)
+}
+
+type authService struct {
+	apiKeyRepository repository.APIKeyRepository
+	jwtVerifier      *auth.JWTVerifier
+	db               mysql.DB
+}
+
+func NewAuthService(apiKeyRepository repository.APIKeyRepository, jwtVerifier *auth.JWTVerifier, db mysql.DB) AuthService {
+	return &authService{
+		apiKeyRepository: apiKeyRepository,
+		jwtVerifier:      jwtVerifier,
+		db:               db,
+	}
+}
+
+func (a *authService) Authenticate(ctx context.Context, credentials auth.Credentials) (auth.Principal, error) {
+	switch {
+	case credentials.BearerToken != "":
+		if !a.jwtVerifier.Enabled() {
+			return auth.Principal{}, auth.ErrInvalidToken
+		}
+		return a.jwtVerifier.Verify(credentials.BearerToken)
+	case credentials.APIKey != "":
+		return a.authenticateAPIKey(ctx, credentials.APIKey)
+	default:
+		return auth.Principal{}, ErrMissingCredentials
+	}
+}
+
+func (a *authService) authenticateAPIKey(ctx context.Context, key string) (auth.Principal, error) {
+	var apiKey domain.APIKey
+	var err error
+	txErr := a.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		apiKey, err = a.apiKeyRepository.FindActiveByHash(ctx, tx, auth.HashAPIKey(key))
+		return err
+	})
+	if txErr != nil {
+		return auth.Principal{}, txErr
+	}
+	return auth.Principal{Subject: apiKey.Prefix, Method: auth.MethodAPIKey, Scopes: apiKey.Scopes}, nil
+}
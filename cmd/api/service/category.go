@@ -53,6 +53,7 @@ func (c *categoryService) CreateCategory(ctx context.Context, category domain.Ca
 	if txErr != nil {
 		return dto.CategoryResponseDTO{}, txErr
 	}
+	categoriesCreatedCounter.Inc()
 	return dto.CategoryResponseDTO{
 		ID:   categoryDomain.ID,
 		Name: categoryDomain.Name,
@@ -133,5 +134,6 @@ func (c *categoryService) DeleteCategory(ctx context.Context, id int64) (int64,
 	if txErr != nil {
 		return 0, txErr
 	}
+	categoriesDeletedCounter.Inc()
 	return id, nil
 }
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+)
+
+// CategoryAttributeService manages the attributes the products of a category
+// may have. Changing an attribute does not change the values products already
+// have; they are checked again when the product is updated.
+type CategoryAttributeService interface {
+	GetAttributes(ctx context.Context, categoryID int64) ([]dto.AttributeDefinitionDTO, error)
+	CreateAttribute(ctx context.Context, categoryID int64, attribute dto.AttributeDefinitionDTO) (dto.AttributeDefinitionDTO, error)
+	UpdateAttribute(ctx context.Context, categoryID int64, id int64, attribute dto.AttributeDefinitionDTO) (dto.AttributeDefinitionDTO, error)
+	// DeleteAttribute removes an attribute along with the values products
+	// have for it.
+	DeleteAttribute(ctx context.Context, categoryID int64, id int64) error
+}
+
+type categoryAttributeService struct {
+	categoryAttributeRepository repository.CategoryAttributeRepository
+	categoryRepository          repository.CategoryRepository
+	db                          mysql.DB
+}
+
+func NewCategoryAttributeService(categoryAttributeRepository repository.CategoryAttributeRepository, categoryRepository repository.CategoryRepository, db mysql.DB) CategoryAttributeService {
+	return &categoryAttributeService{
+		categoryAttributeRepository: categoryAttributeRepository,
+		categoryRepository:          categoryRepository,
+		db:                          db,
+	}
+}
+
+func (c *categoryAttributeService) GetAttributes(ctx context.Context, categoryID int64) ([]dto.AttributeDefinitionDTO, error) {
+	var attributesDTO []dto.AttributeDefinitionDTO
+	txErr := c.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		if _, err := c.categoryRepository.FindByID(ctx, tx, categoryID); err != nil {
+			return err
+		}
+		attributes, err := c.categoryAttributeRepository.FindByCategory(ctx, tx, categoryID)
+		if err != nil {
+			return err
+		}
+		attributesDTO = toAttributeDefinitionDTOs(attributes)
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return attributesDTO, nil
+}
+
+func (c *categoryAttributeService) CreateAttribute(ctx context.Context, categoryID int64, attributeDTO dto.AttributeDefinitionDTO) (dto.AttributeDefinitionDTO, error) {
+	attribute := domain.AttributeDefinition{
+		CategoryID:    categoryID,
+		Name:          attributeDTO.Name,
+		Type:          attributeDTO.Type,
+		Required:      attributeDTO.Required,
+		AllowedValues: attributeDTO.AllowedValues,
+	}
+	if err := attribute.NormalizeAllowedValues(); err != nil {
+		return dto.AttributeDefinitionDTO{}, err
+	}
+
+	txErr := c.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := c.categoryRepository.FindByID(ctx, tx, categoryID); err != nil {
+			return err
+		}
+		var err error
+		attribute.ID, err = c.categoryAttributeRepository.Create(ctx, tx, attribute)
+		return err
+	})
+	if txErr != nil {
+		return dto.AttributeDefinitionDTO{}, txErr
+	}
+	return toAttributeDefinitionDTO(attribute), nil
+}
+
+func (c *categoryAttributeService) UpdateAttribute(ctx context.Context, categoryID int64, id int64, attributeDTO dto.AttributeDefinitionDTO) (dto.AttributeDefinitionDTO, error) {
+	var attribute domain.AttributeDefinition
+	txErr := c.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		attribute, err = c.categoryAttributeRepository.Lock(ctx, tx, categoryID, id)
+		if err != nil {
+			return err
+		}
+		if attributeDTO.Name != attribute.Name || attributeDTO.Type != attribute.Type {
+			return domain.NewBadRequest(fmt.Sprintf("name and type of attribute %s cannot change", attribute.Name), nil)
+		}
+		attribute.Required = attributeDTO.Required
+		attribute.AllowedValues = attributeDTO.AllowedValues
+		if err = attribute.NormalizeAllowedValues(); err != nil {
+			return err
+		}
+		return c.categoryAttributeRepository.Update(ctx, tx, attribute)
+	})
+	if txErr != nil {
+		return dto.AttributeDefinitionDTO{}, txErr
+	}
+	return toAttributeDefinitionDTO(attribute), nil
+}
+
+func (c *categoryAttributeService) DeleteAttribute(ctx context.Context, categoryID int64, id int64) error {
+	return c.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		return c.categoryAttributeRepository.Delete(ctx, tx, categoryID, id)
+	})
+}
+
+func toAttributeDefinitionDTO(attribute domain.AttributeDefinition) dto.AttributeDefinitionDTO {
+	return dto.AttributeDefinitionDTO{
+		ID:            attribute.ID,
+		Name:          attribute.Name,
+		Type:          attribute.Type,
+		Required:      attribute.Required,
+		AllowedValues: attribute.AllowedValues,
+	}
+}
+
+func toAttributeDefinitionDTOs(attributes []domain.AttributeDefinition) []dto.AttributeDefinitionDTO {
+	attributesDTO := make([]dto.AttributeDefinitionDTO, 0, len(attributes))
+	for _, attribute := range attributes {
+		attributesDTO = append(attributesDTO, toAttributeDefinitionDTO(attribute))
+	}
+	return attributesDTO
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/cache"
+)
+
+type cachedCategoryAttributeService struct {
+	CategoryAttributeService
+	products cache.Namespace
+}
+
+// NewCachedCategoryAttributeService decorates next so deleting an attribute
+// invalidates the cached products, which may hold values for it.
+func NewCachedCategoryAttributeService(next CategoryAttributeService, c cache.Cache) CategoryAttributeService {
+	return &cachedCategoryAttributeService{
+		CategoryAttributeService: next,
+		products:                 cache.NewNamespace(c, productsNamespace),
+	}
+}
+
+func (c *cachedCategoryAttributeService) DeleteAttribute(ctx context.Context, categoryID int64, id int64) error {
+	err := c.CategoryAttributeService.DeleteAttribute(ctx, categoryID, id)
+	if err == nil {
+		c.products.Invalidate(ctx)
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/cache"
+)
+
+type cachedCategoryService struct {
+	CategoryService
+	cache      cache.Cache
+	categories cache.Namespace
+	products   cache.Namespace
+	group      *cache.Group
+	config     config.CacheConfig
+}
+
+// NewCachedCategoryService decorates next with a read-through cache on
+// GetCategories. Changing a category also invalidates the cached products,
+// since they embed the category name and are deleted along with it.
+func NewCachedCategoryService(next CategoryService, c cache.Cache, config config.CacheConfig) CategoryService {
+	return &cachedCategoryService{
+		CategoryService: next,
+		cache:           c,
+		categories:      cache.NewNamespace(c, categoriesNamespace),
+		products:        cache.NewNamespace(c, productsNamespace),
+		group:           &cache.Group{},
+		config:          config,
+	}
+}
+
+func (c *cachedCategoryService) GetCategories(ctx context.Context, params dto.SearchParams) (dto.CategoryListResponseDTO, error) {
+	return cache.GetOrLoad(ctx, c.cache, c.group, c.categories, searchParamsKey(params), c.config.CategoryTTL(), func(ctx context.Context) (dto.CategoryListResponseDTO, error) {
+		return c.CategoryService.GetCategories(ctx, params)
+	})
+}
+
+func (c *cachedCategoryService) CreateCategory(ctx context.Context, category domain.Category) (dto.CategoryResponseDTO, error) {
+	categoryDTO, err := c.CategoryService.CreateCategory(ctx, category)
+	if err == nil {
+		c.categories.Invalidate(ctx)
+	}
+	return categoryDTO, err
+}
+
+func (c *cachedCategoryService) UpdateCategory(ctx context.Context, category dto.CategoryDTO, id int64) (dto.CategoryResponseDTO, error) {
+	categoryDTO, err := c.CategoryService.UpdateCategory(ctx, category, id)
+	if err == nil {
+		c.categories.Invalidate(ctx)
+		c.products.Invalidate(ctx)
+	}
+	return categoryDTO, err
+}
+
+func (c *cachedCategoryService) DeleteCategory(ctx context.Context, id int64) (int64, error) {
+	deletedID, err := c.CategoryService.DeleteCategory(ctx, id)
+	if err == nil {
+		c.categories.Invalidate(ctx)
+		c.products.Invalidate(ctx)
+	}
+	return deletedID, err
+}
+
+func searchParamsKey(params dto.SearchParams) string {
+	parts := []string{
+		formatParam(params.Limit),
+		formatParam(params.Offset),
+		formatParam(params.Min),
+		formatParam(params.Max),
+		formatParam(params.Sort),
+		formatParam(params.Title),
+		formatParam(params.Name),
+	}
+	return strings.Join(parts, "|")
+}
+
+func formatParam[T any](value *T) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(*value)
+}
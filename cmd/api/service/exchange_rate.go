@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+)
+
+type ExchangeRateService interface {
+	GetRates(ctx context.Context) ([]dto.ExchangeRateDTO, error)
+	LoadFile(ctx context.Context, path string) (int, error)
+}
+
+type exchangeRateService struct {
+	exchangeRateRepository repository.ExchangeRateRepository
+	db                     mysql.DB
+}
+
+func NewExchangeRateService(exchangeRateRepository repository.ExchangeRateRepository, db mysql.DB) ExchangeRateService {
+	return &exchangeRateService{
+		exchangeRateRepository: exchangeRateRepository,
+		db:                     db,
+	}
+}
+
+func (e *exchangeRateService) GetRates(ctx context.Context) ([]dto.ExchangeRateDTO, error) {
+	var ratesDTO []dto.ExchangeRateDTO
+	txErr := e.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		rates, err := e.exchangeRateRepository.FindAll(ctx, tx)
+		if err != nil {
+			return err
+		}
+		ratesDTO = make([]dto.ExchangeRateDTO, 0, len(rates))
+		for _, rate := range rates {
+			ratesDTO = append(ratesDTO, dto.ExchangeRateDTO{
+				Base:      rate.Base,
+				Quote:     rate.Quote,
+				Rate:      rate.Rate,
+				UpdatedAt: rate.UpdatedAt,
+			})
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return ratesDTO, nil
+}
+
+// LoadFile saves the rates of a CSV file with base,quote,rate records, e.g.
+// "BRL,ARS,180.25", in one transaction, replacing the rates of the same
+// pairs. Lines starting with # and a base,quote,rate header are skipped.
+func (e *exchangeRateService) LoadFile(ctx context.Context, path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, domain.NewInternalError("fail to open exchange rates file", err)
+	}
+	defer file.Close()
+
+	rates, err := parseExchangeRates(file)
+	if err != nil {
+		return 0, domain.NewInternalError(fmt.Sprintf("invalid exchange rates file %s", path), err)
+	}
+
+	txErr := e.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		for _, rate := range rates {
+			if err := e.exchangeRateRepository.Save(ctx, tx, rate); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		return 0, txErr
+	}
+	return len(rates), nil
+}
+
+func parseExchangeRates(r io.Reader) ([]domain.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	var rates []domain.ExchangeRate
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rates, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(record[0], "base") {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		base, ok := money.LookupCurrency(record[0])
+		if !ok {
+			return nil, fmt.Errorf("line %d: unsupported currency %q", line, record[0])
+		}
+		quote, ok := money.LookupCurrency(record[1])
+		if !ok {
+			return nil, fmt.Errorf("line %d: unsupported currency %q", line, record[1])
+		}
+		rate, err := money.ParseDecimal(record[2])
+		if err != nil || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("line %d: invalid rate %q", line, record[2])
+		}
+		rates = append(rates, domain.ExchangeRate{Base: base.Code, Quote: quote.Code, Rate: rate})
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/logging"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+	"github.com/mercadolibre/fury_go-core/pkg/log"
+)
+
+const (
+	componentMySQL      = "mysql"
+	componentMigrations = "migrations"
+	componentServer     = "server"
+
+	defaultPingTimeoutMilliSeconds = 1000
+	migrationFileExtension         = ".sql"
+
+	// The readiness response is public, so failed checks report these
+	// messages and the underlying errors are only logged.
+	mysqlUnreachableMessage  = "database is unreachable"
+	migrationsUnknownMessage = "fail to check migrations"
+	pendingMigrationsMessage = "there are pending migrations"
+	shuttingDownMessage      = "shutting down"
+)
+
+type HealthService interface {
+	Liveness(ctx context.Context) dto.HealthResponse
+	Readiness(ctx context.Context) dto.HealthResponse
+	SetShuttingDown()
+}
+
+type healthService struct {
+	migrationRepository repository.MigrationRepository
+	db                  mysql.DB
+	config              config.HealthConfig
+	shuttingDown        atomic.Bool
+}
+
+func NewHealthService(migrationRepository repository.MigrationRepository, db mysql.DB, config config.HealthConfig) HealthService {
+	return &healthService{
+		migrationRepository: migrationRepository,
+		db:                  db,
+		config:              config,
+	}
+}
+
+func (h *healthService) Liveness(_ context.Context) dto.HealthResponse {
+	return dto.HealthResponse{Status: dto.HealthStatusUp}
+}
+
+// SetShuttingDown makes readiness fail so the load balancer stops routing
+// traffic while in-flight requests are drained.
+func (h *healthService) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+func (h *healthService) Readiness(ctx context.Context) dto.HealthResponse {
+	if h.shuttingDown.Load() {
+		return dto.HealthResponse{
+			Status: dto.HealthStatusDown,
+			Components: map[string]dto.HealthComponent{
+				componentServer: {Status: dto.HealthStatusDown, Error: shuttingDownMessage},
+			},
+		}
+	}
+
+	// Both checks share the ping timeout so readiness answers in bounded time
+	// even when the database hangs.
+	ctx, cancel := context.WithTimeout(ctx, h.pingTimeout())
+	defer cancel()
+
+	response := dto.HealthResponse{
+		Status: dto.HealthStatusUp,
+		Components: map[string]dto.HealthComponent{
+			componentMySQL: h.checkMySQL(ctx),
+		},
+	}
+
+	if h.config.MigrationsPath != "" {
+		response.Components[componentMigrations] = h.checkMigrations(ctx)
+	}
+
+	for _, component := range response.Components {
+		if component.Status != dto.HealthStatusUp {
+			response.Status = dto.HealthStatusDown
+		}
+	}
+	return response
+}
+
+func (h *healthService) pingTimeout() time.Duration {
+	timeout := h.config.PingTimeoutMilliSeconds * time.Millisecond
+	if timeout <= 0 {
+		timeout = defaultPingTimeoutMilliSeconds * time.Millisecond
+	}
+	return timeout
+}
+
+func (h *healthService) checkMySQL(ctx context.Context) dto.HealthComponent {
+	stats := h.db.Stats()
+	component := dto.HealthComponent{
+		Status: dto.HealthStatusUp,
+		Details: dto.PoolStatsDTO{
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+			WaitDuration:       stats.WaitDuration.String(),
+			MaxIdleClosed:      stats.MaxIdleClosed,
+			MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+		},
+	}
+
+	if err := h.db.PingContext(ctx); err != nil {
+		logging.Error(ctx, "readiness check failed", log.String("component", componentMySQL), log.Err(err))
+		component.Status = dto.HealthStatusDown
+		component.Error = mysqlUnreachableMessage
+	}
+	return component
+}
+
+func (h *healthService) checkMigrations(ctx context.Context) dto.HealthComponent {
+	expected, err := migrationVersions(h.config.MigrationsPath)
+	if err != nil {
+		logging.Error(ctx, "readiness check failed", log.String("component", componentMigrations), log.Err(err))
+		return dto.HealthComponent{Status: dto.HealthStatusDown, Error: migrationsUnknownMessage}
+	}
+
+	var applied map[string]bool
+	err = h.db.WithoutRetry(ctx, func(tx helperdb.Tx) error {
+		applied, err = h.migrationRepository.FindAppliedVersions(ctx, tx)
+		return err
+	})
+	if err != nil {
+		logging.Error(ctx, "readiness check failed", log.String("component", componentMigrations), log.Err(err))
+		return dto.HealthComponent{Status: dto.HealthStatusDown, Error: migrationsUnknownMessage}
+	}
+
+	var pending []string
+	for _, version := range expected {
+		if !applied[version] {
+			pending = append(pending, version)
+		}
+	}
+
+	if len(pending) > 0 {
+		return dto.HealthComponent{
+			Status:  dto.HealthStatusDown,
+			Error:   pendingMigrationsMessage,
+			Details: dto.MigrationsDTO{Pending: pending},
+		}
+	}
+	return dto.HealthComponent{Status: dto.HealthStatusUp}
+}
+
+// migrationVersions returns the sorted versions of the migration files, taken
+// from the timestamp that prefixes every file name.
+func migrationVersions(path string) ([]string, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), migrationFileExtension) {
+			continue
+		}
+		versions = append(versions, strings.SplitN(entry.Name(), "_", 2)[0])
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
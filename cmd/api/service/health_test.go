@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/stretchr/testify/assert"
+)
+
+type dbMock struct {
+	pingErr error
+}
+
+func (d *dbMock) WithoutTransaction(_ context.Context, exec func(helperdb.Tx) error) error {
+	return exec(nil)
+}
+
+func (d *dbMock) WithoutRetry(_ context.Context, exec func(helperdb.Tx) error) error {
+	return exec(nil)
+}
+
+func (d *dbMock) WithTransaction(_ context.Context, txFunc func(*sql.Tx) error) error {
+	return txFunc(nil)
+}
+
+func (d *dbMock) PingContext(_ context.Context) error {
+	return d.pingErr
+}
+
+func (d *dbMock) Stats() sql.DBStats {
+	return sql.DBStats{}
+}
+
+func (d *dbMock) Close() error {
+	return nil
+}
+
+type migrationRepositoryMock struct {
+	applied map[string]bool
+	err     error
+	hang    bool
+}
+
+func (m *migrationRepositoryMock) FindAppliedVersions(ctx context.Context, _ helperdb.Tx) (map[string]bool, error) {
+	if m.hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return m.applied, m.err
+}
+
+func TestReadiness(t *testing.T) {
+	migrationsPath := t.TempDir()
+	for _, name := range []string{"20200101000000000_create_products.sql", "20200102000000000_create_categories.sql"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(migrationsPath, name), nil, 0o600), "Error should not be returned")
+	}
+	applied := map[string]bool{"20200101000000000": true, "20200102000000000": true}
+
+	tests := []struct {
+		name              string
+		pingErr           error
+		applied           map[string]bool
+		migrationsErr     error
+		shuttingDown      bool
+		status            string
+		components        map[string]string
+		pendingMigrations []string
+	}{
+		{
+			name:       "ready",
+			applied:    applied,
+			status:     dto.HealthStatusUp,
+			components: map[string]string{componentMySQL: "", componentMigrations: ""},
+		},
+		{
+			name:          "database down",
+			pingErr:       errors.New("dial tcp 10.0.0.1:3306: connect: connection refused"),
+			migrationsErr: errors.New("dial tcp 10.0.0.1:3306: connect: connection refused"),
+			status:        dto.HealthStatusDown,
+			components:    map[string]string{componentMySQL: mysqlUnreachableMessage, componentMigrations: migrationsUnknownMessage},
+		},
+		{
+			name:              "pending migration",
+			applied:           map[string]bool{"20200101000000000": true},
+			status:            dto.HealthStatusDown,
+			components:        map[string]string{componentMySQL: "", componentMigrations: pendingMigrationsMessage},
+			pendingMigrations: []string{"20200102000000000"},
+		},
+		{
+			name:         "shutting down",
+			applied:      applied,
+			shuttingDown: true,
+			status:       dto.HealthStatusDown,
+			components:   map[string]string{componentServer: shuttingDownMessage},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			healthService := NewHealthService(
+				&migrationRepositoryMock{applied: tt.applied, err: tt.migrationsErr},
+				&dbMock{pingErr: tt.pingErr},
+				config.HealthConfig{MigrationsPath: migrationsPath},
+			)
+			if tt.shuttingDown {
+				healthService.SetShuttingDown()
+			}
+
+			health := healthService.Readiness(context.Background())
+
+			assert.Equal(t, tt.status, health.Status)
+			assert.Len(t, health.Components, len(tt.components))
+			for name, errorText := range tt.components {
+				assert.Equal(t, errorText, health.Components[name].Error, name)
+			}
+			if tt.pendingMigrations != nil {
+				assert.Equal(t, dto.MigrationsDTO{Pending: tt.pendingMigrations}, health.Components[componentMigrations].Details)
+			}
+		})
+	}
+}
+
+func TestReadiness_MigrationsBoundedByPingTimeout(t *testing.T) {
+	healthService := NewHealthService(
+		&migrationRepositoryMock{hang: true},
+		&dbMock{},
+		config.HealthConfig{MigrationsPath: t.TempDir(), PingTimeoutMilliSeconds: 10},
+	)
+
+	start := time.Now()
+	health := healthService.Readiness(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, dto.HealthStatusDown, health.Status)
+	assert.Equal(t, migrationsUnknownMessage, health.Components[componentMigrations].Error)
+}
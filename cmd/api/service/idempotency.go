@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+)
+
+type IdempotencyService interface {
+	// Begin reserves key for the configured lease. When the key is already in
+	// use it returns the stored key and true, or a *domain.ConflictError if
+	// another request is reserving it at the same time. Complete keeps the
+	// response for the configured expiration.
+	Begin(ctx context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error)
+	Complete(ctx context.Context, key domain.IdempotencyKey) error
+	Release(ctx context.Context, key domain.IdempotencyKey) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyService struct {
+	idempotencyKeyRepository repository.IdempotencyKeyRepository
+	db                       mysql.DB
+	idempotencyConfig        config.IdempotencyConfig
+}
+
+func NewIdempotencyService(idempotencyKeyRepository repository.IdempotencyKeyRepository, db mysql.DB, idempotencyConfig config.IdempotencyConfig) IdempotencyService {
+	return &idempotencyService{
+		idempotencyKeyRepository: idempotencyKeyRepository,
+		db:                       db,
+		idempotencyConfig:        idempotencyConfig,
+	}
+}
+
+func (i *idempotencyService) Begin(ctx context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error) {
+	var stored domain.IdempotencyKey
+	var found bool
+	txErr := i.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		stored, err = i.idempotencyKeyRepository.FindByKey(ctx, tx, key.Scope, key.Key)
+		if err == nil {
+			found = true
+			return nil
+		}
+		var notFoundErr *domain.NotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return err
+		}
+
+		found = false
+		if err := i.idempotencyKeyRepository.DeleteExpired(ctx, tx, key.Scope, key.Key); err != nil {
+			return err
+		}
+		return i.idempotencyKeyRepository.Create(ctx, tx, key, i.idempotencyConfig.Lease())
+	})
+	if txErr != nil {
+		return domain.IdempotencyKey{}, false, txErr
+	}
+	return stored, found, nil
+}
+
+func (i *idempotencyService) Complete(ctx context.Context, key domain.IdempotencyKey) error {
+	return i.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		return i.idempotencyKeyRepository.SaveResponse(ctx, tx, key, i.idempotencyConfig.Expiration())
+	})
+}
+
+func (i *idempotencyService) Release(ctx context.Context, key domain.IdempotencyKey) error {
+	return i.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		return i.idempotencyKeyRepository.Delete(ctx, tx, key.Scope, key.Key)
+	})
+}
+
+func (i *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	var purged int64
+	txErr := i.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		purged, err = i.idempotencyKeyRepository.PurgeExpired(ctx, tx, i.idempotencyConfig.PurgeLimit())
+		return err
+	})
+	return purged, txErr
+}
@@ -0,0 +1,14 @@
+package service
+
+import "github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/metrics"
+
+var (
+	productsCreatedCounter   = metrics.NewCounterVec("products_created_total", "Products created.")
+	productsDeletedCounter   = metrics.NewCounterVec("products_deleted_total", "Products deleted.")
+	categoriesCreatedCounter = metrics.NewCounterVec("categories_created_total", "Categories created.")
+	categoriesDeletedCounter = metrics.NewCounterVec("categories_deleted_total", "Categories deleted.")
+	imagesUploadedCounter    = metrics.NewCounterVec("images_uploaded_total", "Images uploaded.")
+
+	scheduledPricesCounter = metrics.NewCounterVec("scheduled_price_changes_total", "Scheduled price change attempts, by result.", "result")
+	thumbnailsCounter      = metrics.NewCounterVec("image_thumbnails_total", "Image thumbnail generation attempts, by result.", "result")
+)
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+)
+
+// PriceListService manages the prices of products per market currency and
+// prices products in the currency a client asks for.
+type PriceListService interface {
+	GetPrices(ctx context.Context, productID int64) ([]dto.ProductPriceDTO, error)
+	SetPrice(ctx context.Context, productID int64, price dto.ProductPriceDTO) (dto.ProductPriceDTO, error)
+	DeletePrice(ctx context.Context, productID int64, currency string, reason string) error
+	InCurrency(ctx context.Context, products []dto.ProductDTO, currency string) ([]dto.ProductDTO, error)
+	GetPriceHistory(ctx context.Context, productID int64, filter domain.PriceHistoryFilter) ([]dto.PriceChangeDTO, error)
+}
+
+type priceListService struct {
+	productRepository      repository.ProductRepository
+	productPriceRepository repository.ProductPriceRepository
+	exchangeRateRepository repository.ExchangeRateRepository
+	priceHistoryRepository repository.PriceHistoryRepository
+	promotionRepository    repository.PromotionRepository
+	db                     mysql.DB
+}
+
+func NewPriceListService(productRepository repository.ProductRepository, productPriceRepository repository.ProductPriceRepository, exchangeRateRepository repository.ExchangeRateRepository, priceHistoryRepository repository.PriceHistoryRepository, promotionRepository repository.PromotionRepository, db mysql.DB) PriceListService {
+	return &priceListService{
+		productRepository:      productRepository,
+		productPriceRepository: productPriceRepository,
+		exchangeRateRepository: exchangeRateRepository,
+		priceHistoryRepository: priceHistoryRepository,
+		promotionRepository:    promotionRepository,
+		db:                     db,
+	}
+}
+
+func (p *priceListService) GetPrices(ctx context.Context, productID int64) ([]dto.ProductPriceDTO, error) {
+	var pricesDTO []dto.ProductPriceDTO
+	txErr := p.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		if _, err := p.productRepository.FindByID(ctx, tx, productID); err != nil {
+			return err
+		}
+		prices, err := p.productPriceRepository.FindByProduct(ctx, tx, productID)
+		if err != nil {
+			return err
+		}
+		pricesDTO = make([]dto.ProductPriceDTO, 0, len(prices))
+		for _, price := range prices {
+			pricesDTO = append(pricesDTO, toProductPriceDTO(price))
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return pricesDTO, nil
+}
+
+// SetPrice creates or replaces a list price and records the change in the
+// price history, in the same transaction.
+func (p *priceListService) SetPrice(ctx context.Context, productID int64, price dto.ProductPriceDTO) (dto.ProductPriceDTO, error) {
+	var priceDTO dto.ProductPriceDTO
+	txErr := p.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := p.productRepository.Lock(ctx, tx, productID); err != nil {
+			return err
+		}
+		previous, err := p.productPriceRepository.FindByProducts(ctx, tx, []int64{productID}, price.Currency)
+		if err != nil {
+			return err
+		}
+		err = p.productPriceRepository.Save(ctx, tx, domain.ProductPrice{
+			ProductID: productID,
+			Currency:  price.Currency,
+			Price:     price.Price,
+		})
+		if err != nil {
+			return err
+		}
+
+		change := domain.PriceChange{
+			ProductID:   productID,
+			OldCurrency: price.Currency,
+			NewPrice:    &price.Price,
+			NewCurrency: price.Currency,
+			PriceList:   true,
+			ChangedBy:   changedBy(ctx),
+			Reason:      price.PriceChangeReason,
+		}
+		if old, found := previous[productID]; found {
+			change.OldPrice = &old.Price
+		}
+		if change.OldPrice == nil || !change.OldPrice.Equal(price.Price) {
+			if _, err = p.priceHistoryRepository.Create(ctx, tx, change); err != nil {
+				return err
+			}
+		}
+
+		prices, err := p.productPriceRepository.FindByProducts(ctx, tx, []int64{productID}, price.Currency)
+		if err != nil {
+			return err
+		}
+		priceDTO = toProductPriceDTO(prices[productID])
+		return nil
+	})
+	if txErr != nil {
+		return dto.ProductPriceDTO{}, txErr
+	}
+	return priceDTO, nil
+}
+
+// DeletePrice removes a list price and records the removal in the price
+// history, in the same transaction.
+func (p *priceListService) DeletePrice(ctx context.Context, productID int64, currency string, reason string) error {
+	return p.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := p.productRepository.Lock(ctx, tx, productID); err != nil {
+			return err
+		}
+		previous, err := p.productPriceRepository.FindByProducts(ctx, tx, []int64{productID}, currency)
+		if err != nil {
+			return err
+		}
+		if _, err = p.productPriceRepository.Delete(ctx, tx, productID, currency); err != nil {
+			return err
+		}
+		old := previous[productID]
+		_, err = p.priceHistoryRepository.Create(ctx, tx, domain.PriceChange{
+			ProductID:   productID,
+			OldPrice:    &old.Price,
+			OldCurrency: currency,
+			NewCurrency: currency,
+			PriceList:   true,
+			ChangedBy:   changedBy(ctx),
+			Reason:      reason,
+		})
+		return err
+	})
+}
+
+// InCurrency prices products in currency: with their price in its price list
+// when they have one, or converting their price with the exchange rates
+// otherwise, flagged as derived.
+func (p *priceListService) InCurrency(ctx context.Context, products []dto.ProductDTO, currency string) ([]dto.ProductDTO, error) {
+	target, ok := money.LookupCurrency(currency)
+	if !ok {
+		return nil, domain.NewBadRequest(fmt.Sprintf("currency %s is not supported", currency), nil)
+	}
+
+	ids := make([]int64, 0, len(products))
+	for _, product := range products {
+		if product.Currency != target.Code {
+			ids = append(ids, product.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return products, nil
+	}
+
+	priced := make([]dto.ProductDTO, 0, len(products))
+	txErr := p.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		listPrices, err := p.productPriceRepository.FindByProducts(ctx, tx, ids, target.Code)
+		if err != nil {
+			return err
+		}
+		converters := map[string]converter{}
+		var promotions []domain.Promotion
+		for _, product := range products {
+			switch listPrice, found := listPrices[product.ID]; {
+			case product.Currency == target.Code:
+			case found:
+				// The lowest price only covers the base price.
+				product.Price = listPrice.Price
+				product.Currency = target.Code
+				product.PriceDerived = false
+				product.LowestPrice30Days = nil
+				if product.FinalPrice != nil {
+					if promotions == nil {
+						promotions, err = p.promotionRepository.FindActive(ctx, tx)
+						if err != nil {
+							return err
+						}
+					}
+					product, err = p.withListPromotions(ctx, tx, product, promotions)
+					if err != nil {
+						return err
+					}
+				}
+			default:
+				convert, cached := converters[product.Currency]
+				if !cached {
+					convert, err = p.converter(ctx, tx, product.Currency, target)
+					if err != nil {
+						return err
+					}
+					converters[product.Currency] = convert
+				}
+				product.Price = convert(product.Price)
+				product.LowestPrice30Days = convertOptional(convert, product.LowestPrice30Days)
+				product.OriginalPrice = convertOptional(convert, product.OriginalPrice)
+				product.FinalPrice = convertOptional(convert, product.FinalPrice)
+				product.Currency = target.Code
+				product.PriceDerived = true
+			}
+			priced = append(priced, product)
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return priced, nil
+}
+
+// withListPromotions sets the original and final price of product, already
+// in its list price, from the promotions that apply to that price.
+func (p *priceListService) withListPromotions(ctx context.Context, tx helperdb.Tx, product dto.ProductDTO, promotions []domain.Promotion) (dto.ProductDTO, error) {
+	productDomain, err := p.productRepository.FindAnyByID(ctx, tx, product.ID)
+	if err != nil {
+		return dto.ProductDTO{}, err
+	}
+	productDomain.Price = product.Price
+	productDomain.Currency = product.Currency
+	return withPromotions(product, productDomain, promotions), nil
+}
+
+// GetPriceHistory lists the price changes of a product, latest first.
+func (p *priceListService) GetPriceHistory(ctx context.Context, productID int64, filter domain.PriceHistoryFilter) ([]dto.PriceChangeDTO, error) {
+	var changesDTO []dto.PriceChangeDTO
+	txErr := p.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		if _, err := p.productRepository.FindByID(ctx, tx, productID); err != nil {
+			return err
+		}
+		changes, err := p.priceHistoryRepository.FindByProduct(ctx, tx, productID, filter)
+		if err != nil {
+			return err
+		}
+		changesDTO = make([]dto.PriceChangeDTO, 0, len(changes))
+		for _, change := range changes {
+			changesDTO = append(changesDTO, dto.PriceChangeDTO{
+				ID:          change.ID,
+				ProductID:   change.ProductID,
+				OldPrice:    change.OldPrice,
+				OldCurrency: change.OldCurrency,
+				NewPrice:    change.NewPrice,
+				NewCurrency: change.NewCurrency,
+				PriceList:   change.PriceList,
+				ChangedBy:   change.ChangedBy,
+				Reason:      change.Reason,
+				ChangedAt:   change.ChangedAt,
+			})
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return changesDTO, nil
+}
+
+type converter func(amount money.Decimal) money.Decimal
+
+func convertOptional(convert converter, amount *money.Decimal) *money.Decimal {
+	if amount == nil {
+		return nil
+	}
+	converted := convert(*amount)
+	return &converted
+}
+
+// converter converts amounts from one currency to another with the direct
+// rate, or dividing by the inverse one when only that is known.
+func (p *priceListService) converter(ctx context.Context, tx helperdb.Tx, from string, to money.Currency) (converter, error) {
+	rate, err := p.exchangeRateRepository.Find(ctx, tx, from, to.Code)
+	if err == nil {
+		return func(amount money.Decimal) money.Decimal {
+			return amount.Mul(rate.Rate).Round(to.Digits)
+		}, nil
+	}
+	if !isNotFound(err) {
+		return nil, err
+	}
+
+	inverse, err := p.exchangeRateRepository.Find(ctx, tx, to.Code, from)
+	if err == nil && !inverse.Rate.IsZero() {
+		return func(amount money.Decimal) money.Decimal {
+			return amount.Quo(inverse.Rate, to.Digits)
+		}, nil
+	}
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	return nil, domain.NewBadRequest(fmt.Sprintf("no exchange rate from %s to %s", from, to.Code), err)
+}
+
+func isNotFound(err error) bool {
+	var notFound *domain.NotFoundError
+	return errors.As(err, &notFound)
+}
+
+func toProductPriceDTO(price domain.ProductPrice) dto.ProductPriceDTO {
+	return dto.ProductPriceDTO{
+		ProductID: price.ProductID,
+		Currency:  price.Currency,
+		Price:     price.Price,
+		UpdatedAt: price.UpdatedAt,
+	}
+}
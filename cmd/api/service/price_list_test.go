@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// productPriceRepositoryMock keeps the list prices of a single currency by
+// product id.
+type productPriceRepositoryMock struct {
+	repository.ProductPriceRepository
+	prices map[int64]domain.ProductPrice
+}
+
+func (p *productPriceRepositoryMock) Save(_ context.Context, _ *sql.Tx, price domain.ProductPrice) error {
+	p.prices[price.ProductID] = price
+	return nil
+}
+
+func (p *productPriceRepositoryMock) FindByProducts(_ context.Context, _ helperdb.Tx, productIDs []int64, _ string) (map[int64]domain.ProductPrice, error) {
+	found := map[int64]domain.ProductPrice{}
+	for _, id := range productIDs {
+		if price, ok := p.prices[id]; ok {
+			found[id] = price
+		}
+	}
+	return found, nil
+}
+
+func (p *productPriceRepositoryMock) Delete(_ context.Context, _ *sql.Tx, productID int64, _ string) (int64, error) {
+	if _, ok := p.prices[productID]; !ok {
+		return 0, domain.NewNotFoundError("price not found", nil)
+	}
+	delete(p.prices, productID)
+	return 1, nil
+}
+
+type priceHistoryRepositoryMock struct {
+	repository.PriceHistoryRepository
+	created []domain.PriceChange
+}
+
+func (p *priceHistoryRepositoryMock) Create(_ context.Context, _ *sql.Tx, change domain.PriceChange) (int64, error) {
+	p.created = append(p.created, change)
+	return int64(len(p.created)), nil
+}
+
+type promotionRepositoryMock struct {
+	repository.PromotionRepository
+	active []domain.Promotion
+}
+
+func (p *promotionRepositoryMock) FindActive(_ context.Context, _ helperdb.Tx) ([]domain.Promotion, error) {
+	return p.active, nil
+}
+
+func newPublishedProductRepository() *productRepositoryMock {
+	return &productRepositoryMock{products: map[int64]domain.Product{
+		1: {ID: 1, Title: "TV", CategoryID: 3, Status: domain.ProductPublished, Price: money.MustParseDecimal("100"), Currency: "BRL"},
+	}}
+}
+
+func TestSetPrice_RecordsListPriceChanges(t *testing.T) {
+	productPriceRepository := &productPriceRepositoryMock{prices: map[int64]domain.ProductPrice{}}
+	priceHistoryRepository := &priceHistoryRepositoryMock{}
+	priceListService := NewPriceListService(newPublishedProductRepository(), productPriceRepository, nil, priceHistoryRepository, nil, &dbMock{})
+	ctx := context.Background()
+
+	for _, price := range []string{"20.00", "20.00", "18.50"} {
+		_, err := priceListService.SetPrice(ctx, 1, dto.ProductPriceDTO{Currency: "USD", Price: money.MustParseDecimal(price), PriceChangeReason: "launch"})
+		assert.NoError(t, err, "Error should not be returned")
+	}
+
+	if assert.Len(t, priceHistoryRepository.created, 2) {
+		created := priceHistoryRepository.created[0]
+		assert.Nil(t, created.OldPrice)
+		assert.Equal(t, "20.00", created.NewPrice.String())
+		assert.Equal(t, "USD", created.NewCurrency)
+		assert.True(t, created.PriceList)
+		assert.Equal(t, "launch", created.Reason)
+
+		changed := priceHistoryRepository.created[1]
+		assert.Equal(t, "20.00", changed.OldPrice.String())
+		assert.Equal(t, "18.50", changed.NewPrice.String())
+	}
+}
+
+func TestDeletePrice_RecordsRemoval(t *testing.T) {
+	productPriceRepository := &productPriceRepositoryMock{prices: map[int64]domain.ProductPrice{
+		1: {ProductID: 1, Currency: "USD", Price: money.MustParseDecimal("20.00")},
+	}}
+	priceHistoryRepository := &priceHistoryRepositoryMock{}
+	priceListService := NewPriceListService(newPublishedProductRepository(), productPriceRepository, nil, priceHistoryRepository, nil, &dbMock{})
+
+	err := priceListService.DeletePrice(context.Background(), 1, "USD", "market closed")
+
+	assert.NoError(t, err, "Error should not be returned")
+	if assert.Len(t, priceHistoryRepository.created, 1) {
+		removed := priceHistoryRepository.created[0]
+		assert.Equal(t, "20.00", removed.OldPrice.String())
+		assert.Nil(t, removed.NewPrice)
+		assert.True(t, removed.PriceList)
+		assert.Equal(t, "market closed", removed.Reason)
+	}
+
+	err = priceListService.DeletePrice(context.Background(), 1, "USD", "")
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Len(t, priceHistoryRepository.created, 1)
+}
+
+func TestInCurrency_AppliesPromotionsToListPrice(t *testing.T) {
+	productPriceRepository := &productPriceRepositoryMock{prices: map[int64]domain.ProductPrice{
+		1: {ProductID: 1, Currency: "USD", Price: money.MustParseDecimal("20.00")},
+	}}
+	promotionRepository := &promotionRepositoryMock{active: []domain.Promotion{
+		{ID: 1, Type: domain.PromotionPercentage, Value: money.MustParseDecimal("10"), CategoryIDs: []int64{3}, Priority: 2, Stackable: true},
+		{ID: 2, Type: domain.PromotionFixed, Value: money.MustParseDecimal("5"), Currency: "BRL", Priority: 1, Stackable: true},
+		{ID: 3, Type: domain.PromotionFixed, Value: money.MustParseDecimal("1"), Currency: "USD", Stackable: true},
+	}}
+	priceListService := NewPriceListService(newPublishedProductRepository(), productPriceRepository, nil, nil, promotionRepository, &dbMock{})
+	originalPrice, finalPrice := money.MustParseDecimal("100"), money.MustParseDecimal("85.00")
+	product := dto.ProductDTO{ID: 1, Price: originalPrice, Currency: "BRL", OriginalPrice: &originalPrice, FinalPrice: &finalPrice, AppliedPromotionIDs: []int64{1, 2}}
+
+	priced, err := priceListService.InCurrency(context.Background(), []dto.ProductDTO{product}, "USD")
+
+	assert.NoError(t, err, "Error should not be returned")
+	if assert.Len(t, priced, 1) {
+		assert.Equal(t, "USD", priced[0].Currency)
+		assert.Equal(t, "20.00", priced[0].OriginalPrice.String())
+		assert.Equal(t, "17.00", priced[0].FinalPrice.String())
+		assert.Equal(t, []int64{1, 3}, priced[0].AppliedPromotionIDs)
+		assert.False(t, priced[0].PriceDerived)
+	}
+}
@@ -3,37 +3,63 @@ package service
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/auth"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
 	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
 )
 
 type ProductService interface {
 	GetProducts(ctx context.Context, params dto.SearchParams) (dto.ProductResponse, error)
+	// FindById returns a product visible to everyone: published and inside
+	// its publication window.
 	FindById(ctx context.Context, id int64) (dto.ProductDTO, error)
+	// FindAnyById returns a product whatever its status and publication
+	// window, so editors can preview it.
+	FindAnyById(ctx context.Context, id int64) (dto.ProductDTO, error)
 	CreateProduct(ctx context.Context, productDTO dto.ProductDTO) (dto.ProductDTO, error)
 	GetProductsByCategory(ctx context.Context, category string) ([]dto.ProductDTO, error)
 	UpdateProduct(ctx context.Context, product dto.ProductUpdateDTO, id int64) (dto.ProductDTO, error)
+	// UpdateStatus moves a product to another lifecycle status, following
+	// the allowed transitions.
+	UpdateStatus(ctx context.Context, id int64, status string) (dto.ProductDTO, error)
 	DeleteProduct(ctx context.Context, id int64) error
 }
 
 type productService struct {
-	productRepository  repository.ProductRepository
-	categoryRepository repository.CategoryRepository
-	db                 mysql.DB
-	env                config.Environment
+	productRepository           repository.ProductRepository
+	categoryRepository          repository.CategoryRepository
+	priceHistoryRepository      repository.PriceHistoryRepository
+	promotionRepository         repository.PromotionRepository
+	productImageRepository      repository.ProductImageRepository
+	categoryAttributeRepository repository.CategoryAttributeRepository
+	productAttributeRepository  repository.ProductAttributeRepository
+	db                          mysql.DB
+	env                         config.Environment
 }
 
-func NewProductService(productRepository repository.ProductRepository, categoryRepository repository.CategoryRepository, db mysql.DB, env config.Environment) *productService {
+// lowestPriceDays is the window of the lowest price shown with each product.
+const lowestPriceDays = 30
+
+func NewProductService(productRepository repository.ProductRepository, categoryRepository repository.CategoryRepository, priceHistoryRepository repository.PriceHistoryRepository, promotionRepository repository.PromotionRepository, productImageRepository repository.ProductImageRepository, categoryAttributeRepository repository.CategoryAttributeRepository, productAttributeRepository repository.ProductAttributeRepository, db mysql.DB, env config.Environment) *productService {
 	return &productService{
-		productRepository:  productRepository,
-		categoryRepository: categoryRepository,
-		db:                 db,
-		env:                env,
+		productRepository:           productRepository,
+		categoryRepository:          categoryRepository,
+		priceHistoryRepository:      priceHistoryRepository,
+		promotionRepository:         promotionRepository,
+		productImageRepository:      productImageRepository,
+		categoryAttributeRepository: categoryAttributeRepository,
+		productAttributeRepository:  productAttributeRepository,
+		db:                          db,
+		env:                         env,
 	}
 }
 
@@ -41,10 +67,28 @@ func (p *productService) GetProducts(ctx context.Context, param dto.SearchParams
 	var productsResponse dto.ProductResponse
 
 	txErr := p.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		if len(param.Attributes) > 0 {
+			definitions, err := p.categoryAttributeRepository.FindByNames(ctx, tx, attributeNames(param.Attributes))
+			if err != nil {
+				return err
+			}
+			param.AttributeValues, err = domain.NormalizeAttributeFilters(definitions, param.Attributes)
+			if err != nil {
+				return err
+			}
+		}
 		products, total, err := p.productRepository.FindAll(ctx, tx, param)
 		if err != nil {
 			return err
 		}
+		promotions, err := p.promotionRepository.FindActive(ctx, tx)
+		if err != nil {
+			return err
+		}
+		attributes, err := p.productAttributeRepository.FindByProducts(ctx, tx, productIDs(products))
+		if err != nil {
+			return err
+		}
 
 		var productsDTO []dto.ProductDTO
 		for _, product := range products {
@@ -52,14 +96,19 @@ func (p *productService) GetProducts(ctx context.Context, param dto.SearchParams
 			if err != nil {
 				return err
 			}
-			productsDTO = append(productsDTO, dto.ProductDTO{
+			productsDTO = append(productsDTO, withPromotions(dto.ProductDTO{
 				ID:          product.ID,
 				Title:       product.Title,
 				Description: product.Description,
 				Price:       product.Price,
+				Currency:    product.Currency,
 				Image:       product.Image,
 				Category:    categoryName.Name,
-			})
+				Status:      product.Status,
+				PublishAt:   product.PublishAt,
+				UnpublishAt: product.UnpublishAt,
+				Attributes:  attributes[product.ID],
+			}, product, promotions))
 		}
 
 		productsResponse = dto.ProductResponse{
@@ -81,54 +130,133 @@ func (p *productService) GetProducts(ctx context.Context, param dto.SearchParams
 }
 
 func (p *productService) FindById(ctx context.Context, id int64) (dto.ProductDTO, error) {
+	return p.findById(ctx, id, p.productRepository.FindByID)
+}
+
+func (p *productService) FindAnyById(ctx context.Context, id int64) (dto.ProductDTO, error) {
+	return p.findById(ctx, id, p.productRepository.FindAnyByID)
+}
+
+func (p *productService) findById(ctx context.Context, id int64, find func(context.Context, helperdb.Tx, int64) (domain.Product, error)) (dto.ProductDTO, error) {
 	var categoryDomain domain.Category
 	var productDomain domain.Product
+	var lowestPrice money.Decimal
+	var promotions []domain.Promotion
+	var promotionsChangeAt *time.Time
+	var images []domain.ProductImage
+	var attributes map[string]string
 	var err error
 
 	txErr := p.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
-		productDomain, err = p.productRepository.FindByID(ctx, tx, id)
+		productDomain, err = find(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		images, err = p.productImageRepository.FindByProduct(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		attributes, err = p.productAttributeRepository.FindByProduct(ctx, tx, id)
 		if err != nil {
 			return err
 		}
 		categoryDomain, err = p.categoryRepository.FindByID(ctx, tx, productDomain.CategoryID)
+		if err != nil {
+			return err
+		}
+		lowestPrice, err = p.priceHistoryRepository.LowestPriceSince(ctx, tx, productDomain, lowestPriceDays)
+		if err != nil {
+			return err
+		}
+		promotions, err = p.promotionRepository.FindActive(ctx, tx)
+		if err != nil {
+			return err
+		}
+		promotionsChangeAt, err = p.promotionRepository.NextChangeAt(ctx, tx)
 		return err
 	})
 	if txErr != nil {
 		return dto.ProductDTO{}, txErr
 	}
-	return dto.ProductDTO{
+	return withPromotions(dto.ProductDTO{
 		ID:          productDomain.ID,
 		Title:       productDomain.Title,
 		Description: productDomain.Description,
 		Price:       productDomain.Price,
+		Currency:    productDomain.Currency,
 		Image:       productDomain.Image,
 		Category:    categoryDomain.Name,
-	}, nil
+		Status:      productDomain.Status,
+		PublishAt:   productDomain.PublishAt,
+		UnpublishAt: productDomain.UnpublishAt,
+		Images:      toProductImageDTOs(images),
+		Attributes:  attributes,
+
+		LowestPrice30Days:  &lowestPrice,
+		PromotionsChangeAt: promotionsChangeAt,
+	}, productDomain, promotions), nil
 
 }
 
 func (p *productService) CreateProduct(ctx context.Context, productDTO dto.ProductDTO) (dto.ProductDTO, error) {
-	var id int64
+	var productDomain domain.Product
+	var promotions []domain.Promotion
 	txErr := p.db.WithTransaction(ctx, func(tx *sql.Tx) error {
 		category, err := p.categoryRepository.FindByName(ctx, tx, productDTO.Category)
 		if err != nil {
 			return err
 		}
-		productDomain := domain.Product{
+		definitions, err := p.categoryAttributeRepository.FindByCategory(ctx, tx, category.ID)
+		if err != nil {
+			return err
+		}
+		productDTO.Attributes, err = domain.ValidateAttributes(category, definitions, productDTO.Attributes)
+		if err != nil {
+			return err
+		}
+		productDomain = domain.Product{
 			Title:       productDTO.Title,
 			Description: productDTO.Description,
 			Price:       productDTO.Price,
+			Currency:    dto.PriceCurrency(productDTO.Currency),
 			Image:       productDTO.Image,
 			CategoryID:  category.ID,
+			Status:      domain.ProductDraft,
+			PublishAt:   productDTO.PublishAt,
+			UnpublishAt: productDTO.UnpublishAt,
 		}
-		id, err = p.productRepository.Create(ctx, tx, productDomain)
+		productDomain.ID, err = p.productRepository.Create(ctx, tx, productDomain)
+		if err != nil {
+			return err
+		}
+		if productDomain.Image != "" {
+			_, err = p.productImageRepository.Create(ctx, tx, domain.ProductImage{
+				ProductID: productDomain.ID,
+				URL:       productDomain.Image,
+				Primary:   true,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(productDTO.Attributes) > 0 {
+			err = p.productAttributeRepository.SetValues(ctx, tx, productDomain.ID, definitions, productDTO.Attributes)
+			if err != nil {
+				return err
+			}
+		}
+		promotions, err = p.promotionRepository.FindActive(ctx, tx)
 		return err
 	})
 	if txErr != nil {
 		return dto.ProductDTO{}, txErr
 	}
-	productDTO.ID = id
-	return productDTO, nil
+	productsCreatedCounter.Inc()
+	productDTO.ID = productDomain.ID
+	productDTO.Currency = productDomain.Currency
+	productDTO.Status = productDomain.Status
+	productDTO.PriceDerived = false
+	return withPromotions(productDTO, productDomain, promotions), nil
 }
 
 func (p *productService) GetProductsByCategory(ctx context.Context, category string) ([]dto.ProductDTO, error) {
@@ -138,19 +266,33 @@ func (p *productService) GetProductsByCategory(ctx context.Context, category str
 		if err != nil {
 			return err
 		}
-		products, err := p.productRepository.FindByCategory(ctx, tx, categoryDomain.ID)
+		products, err := p.productRepository.FindByCategory(ctx, tx, categoryDomain.ID, domain.ProductPublished)
+		if err != nil {
+			return err
+		}
+		promotions, err := p.promotionRepository.FindActive(ctx, tx)
+		if err != nil {
+			return err
+		}
+		attributes, err := p.productAttributeRepository.FindByProducts(ctx, tx, productIDs(products))
 		if err != nil {
 			return err
 		}
+		productsDTO = nil
 		for _, productDomain := range products {
-			productsDTO = append(productsDTO, dto.ProductDTO{
+			productsDTO = append(productsDTO, withPromotions(dto.ProductDTO{
 				ID:          productDomain.ID,
 				Title:       productDomain.Title,
 				Description: productDomain.Description,
 				Price:       productDomain.Price,
+				Currency:    productDomain.Currency,
 				Image:       productDomain.Image,
 				Category:    categoryDomain.Name,
-			})
+				Status:      productDomain.Status,
+				PublishAt:   productDomain.PublishAt,
+				UnpublishAt: productDomain.UnpublishAt,
+				Attributes:  attributes[productDomain.ID],
+			}, productDomain, promotions))
 		}
 		return nil
 	})
@@ -163,7 +305,7 @@ func (p *productService) GetProductsByCategory(ctx context.Context, category str
 func (p *productService) UpdateProduct(ctx context.Context, product dto.ProductUpdateDTO, id int64) (dto.ProductDTO, error) {
 	var productDTO dto.ProductDTO
 	txErr := p.db.WithTransaction(ctx, func(tx *sql.Tx) error {
-		productDomain, err := p.productRepository.FindByID(ctx, tx, id)
+		productDomain, err := p.productRepository.Lock(ctx, tx, id)
 		if err != nil {
 			return err
 		}
@@ -171,29 +313,131 @@ func (p *productService) UpdateProduct(ctx context.Context, product dto.ProductU
 		if err != nil {
 			return err
 		}
+		definitions, err := p.categoryAttributeRepository.FindByCategory(ctx, tx, productDomain.CategoryID)
+		if err != nil {
+			return err
+		}
+		attributes, err := domain.ValidateAttributes(categoryName, definitions, product.Attributes)
+		if err != nil {
+			return err
+		}
+
+		// Prices sent without a currency keep the one of the product.
+		currency := product.Currency
+		if currency == "" {
+			currency = productDomain.Currency
+		}
+		if c, ok := money.LookupCurrency(currency); ok && !c.Fits(product.Price) {
+			return domain.NewBadRequest(fmt.Sprintf("price must have at most %d decimal places", c.Digits), nil)
+		}
 
 		productUpdate := domain.Product{
 			ID:          productDomain.ID,
 			Title:       product.Title,
 			Description: product.Description,
 			Price:       product.Price,
+			Currency:    currency,
 			Image:       product.Image,
 			CategoryID:  productDomain.CategoryID,
+			Status:      productDomain.Status,
+			PublishAt:   product.PublishAt,
+			UnpublishAt: product.UnpublishAt,
 		}
 
 		_, err = p.productRepository.Update(ctx, tx, productUpdate)
 		if err != nil {
 			return err
 		}
+		if productUpdate.Image != productDomain.Image {
+			if err = p.setPrimaryImage(ctx, tx, id, productUpdate.Image); err != nil {
+				return err
+			}
+		}
+		if err = p.productAttributeRepository.SetValues(ctx, tx, id, definitions, attributes); err != nil {
+			return err
+		}
 
-		productDTO = dto.ProductDTO{
+		if !productUpdate.Price.Equal(productDomain.Price) || productUpdate.Currency != productDomain.Currency {
+			_, err = p.priceHistoryRepository.Create(ctx, tx, domain.PriceChange{
+				ProductID:   productDomain.ID,
+				OldPrice:    &productDomain.Price,
+				OldCurrency: productDomain.Currency,
+				NewPrice:    &productUpdate.Price,
+				NewCurrency: productUpdate.Currency,
+				ChangedBy:   changedBy(ctx),
+				Reason:      product.PriceChangeReason,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		promotions, err := p.promotionRepository.FindActive(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		productDTO = withPromotions(dto.ProductDTO{
 			Title:       productUpdate.Title,
 			Description: productUpdate.Description,
 			Price:       productUpdate.Price,
+			Currency:    productUpdate.Currency,
 			Image:       productUpdate.Image,
 			Category:    categoryName.Name,
+			Status:      productUpdate.Status,
+			PublishAt:   productUpdate.PublishAt,
+			UnpublishAt: productUpdate.UnpublishAt,
+			Attributes:  attributes,
+		}, productUpdate, promotions)
+
+		return nil
+	})
+	if txErr != nil {
+		return dto.ProductDTO{}, txErr
+	}
+	return productDTO, nil
+}
+
+func (p *productService) UpdateStatus(ctx context.Context, id int64, status string) (dto.ProductDTO, error) {
+	var productDTO dto.ProductDTO
+	txErr := p.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		productDomain, err := p.productRepository.Lock(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if !productDomain.CanTransitionTo(status) {
+			return domain.NewConflictError(fmt.Sprintf("product with ID %d is %s and cannot be %s", id, productDomain.Status, status), nil)
 		}
+		if err = p.productRepository.UpdateStatus(ctx, tx, id, status); err != nil {
+			return err
+		}
+		productDomain.Status = status
 
+		categoryDomain, err := p.categoryRepository.FindByID(ctx, tx, productDomain.CategoryID)
+		if err != nil {
+			return err
+		}
+		promotions, err := p.promotionRepository.FindActive(ctx, tx)
+		if err != nil {
+			return err
+		}
+		attributes, err := p.productAttributeRepository.FindByProduct(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		productDTO = withPromotions(dto.ProductDTO{
+			ID:          productDomain.ID,
+			Title:       productDomain.Title,
+			Description: productDomain.Description,
+			Price:       productDomain.Price,
+			Currency:    productDomain.Currency,
+			Image:       productDomain.Image,
+			Category:    categoryDomain.Name,
+			Status:      productDomain.Status,
+			PublishAt:   productDomain.PublishAt,
+			UnpublishAt: productDomain.UnpublishAt,
+			Attributes:  attributes,
+		}, productDomain, promotions)
 		return nil
 	})
 	if txErr != nil {
@@ -210,5 +454,72 @@ func (p *productService) DeleteProduct(ctx context.Context, id int64) error {
 	if txErr != nil {
 		return txErr
 	}
+	productsDeletedCounter.Inc()
 	return nil
 }
+
+// setPrimaryImage keeps the images of a product in line with an image set
+// through the product: it replaces the URL of the primary image, or adds one
+// when the product has no images yet.
+func (p *productService) setPrimaryImage(ctx context.Context, tx *sql.Tx, productID int64, url string) error {
+	images, err := p.productImageRepository.FindByProduct(ctx, tx, productID)
+	if err != nil {
+		return err
+	}
+	for _, image := range images {
+		if !image.Primary {
+			continue
+		}
+		if !image.SetURL(url) {
+			return nil
+		}
+		if err = p.productImageRepository.Update(ctx, tx, image); err != nil {
+			return err
+		}
+		return p.productImageRepository.SetRenditions(ctx, tx, image.ID, nil)
+	}
+	_, err = p.productImageRepository.Create(ctx, tx, domain.ProductImage{
+		ProductID: productID,
+		URL:       url,
+		Position:  len(images),
+		Primary:   true,
+	})
+	return err
+}
+
+func attributeNames(attributes map[string]string) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func productIDs(products []domain.Product) []int64 {
+	ids := make([]int64, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+	return ids
+}
+
+// withPromotions sets the original and final price of productDTO from the
+// promotions that apply to product.
+func withPromotions(productDTO dto.ProductDTO, product domain.Product, promotions []domain.Promotion) dto.ProductDTO {
+	originalPrice := product.Price
+	finalPrice, applied := domain.ApplyPromotions(product, promotions)
+	productDTO.OriginalPrice = &originalPrice
+	productDTO.FinalPrice = &finalPrice
+	productDTO.AppliedPromotionIDs = applied
+	return productDTO
+}
+
+// changedBy identifies the caller of a change in the price history.
+func changedBy(ctx context.Context) string {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return principal.Subject
+}
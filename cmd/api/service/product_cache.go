@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/cache"
+)
+
+const (
+	productsNamespace   = "products"
+	categoriesNamespace = "categories"
+)
+
+type cachedProductService struct {
+	ProductService
+	cache    cache.Cache
+	products cache.Namespace
+	group    *cache.Group
+	config   config.CacheConfig
+}
+
+// NewCachedProductService decorates next with a read-through cache on FindById.
+// Entries expire at the next edge of the publication window of the product,
+// or the next start or end of a promotion, when it comes before the
+// configured TTL.
+func NewCachedProductService(next ProductService, c cache.Cache, config config.CacheConfig) ProductService {
+	return &cachedProductService{
+		ProductService: next,
+		cache:          c,
+		products:       cache.NewNamespace(c, productsNamespace),
+		group:          &cache.Group{},
+		config:         config,
+	}
+}
+
+func (p *cachedProductService) FindById(ctx context.Context, id int64) (dto.ProductDTO, error) {
+	ttl := func(product dto.ProductDTO) time.Duration {
+		return productTTL(product, p.config.ProductTTL(), time.Now())
+	}
+	return cache.GetOrLoadWithTTL(ctx, p.cache, p.group, p.products, strconv.FormatInt(id, 10), ttl, func(ctx context.Context) (dto.ProductDTO, error) {
+		return p.ProductService.FindById(ctx, id)
+	})
+}
+
+func (p *cachedProductService) UpdateProduct(ctx context.Context, product dto.ProductUpdateDTO, id int64) (dto.ProductDTO, error) {
+	productDTO, err := p.ProductService.UpdateProduct(ctx, product, id)
+	if err == nil {
+		cache.Delete(ctx, p.cache, p.key(ctx, id))
+	}
+	return productDTO, err
+}
+
+func (p *cachedProductService) UpdateStatus(ctx context.Context, id int64, status string) (dto.ProductDTO, error) {
+	productDTO, err := p.ProductService.UpdateStatus(ctx, id, status)
+	if err == nil {
+		cache.Delete(ctx, p.cache, p.key(ctx, id))
+	}
+	return productDTO, err
+}
+
+func (p *cachedProductService) DeleteProduct(ctx context.Context, id int64) error {
+	err := p.ProductService.DeleteProduct(ctx, id)
+	if err == nil {
+		cache.Delete(ctx, p.cache, p.key(ctx, id))
+	}
+	return err
+}
+
+// productTTL caps ttl at the next publish_at or unpublish_at of product after
+// now, so a product is not served from the cache once its window closes, and
+// at the next change of promotions, so its final price is not stale.
+func productTTL(product dto.ProductDTO, ttl time.Duration, now time.Time) time.Duration {
+	for _, edge := range []*time.Time{product.PublishAt, product.UnpublishAt, product.PromotionsChangeAt} {
+		if edge != nil && edge.After(now) && edge.Sub(now) < ttl {
+			ttl = edge.Sub(now)
+		}
+	}
+	return ttl
+}
+
+func (p *cachedProductService) key(ctx context.Context, id int64) string {
+	return p.products.Key(ctx, strconv.FormatInt(id, 10))
+}
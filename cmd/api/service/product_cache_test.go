@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductTTL(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		edge := now.Add(d)
+		return &edge
+	}
+
+	tests := []struct {
+		name    string
+		product dto.ProductDTO
+		ttl     time.Duration
+	}{
+		{name: "without window", product: dto.ProductDTO{}, ttl: 5 * time.Minute},
+		{name: "unpublished before ttl", product: dto.ProductDTO{PublishAt: at(-time.Hour), UnpublishAt: at(90 * time.Second)}, ttl: 90 * time.Second},
+		{name: "unpublished after ttl", product: dto.ProductDTO{UnpublishAt: at(time.Hour)}, ttl: 5 * time.Minute},
+		{name: "published before ttl", product: dto.ProductDTO{PublishAt: at(time.Minute)}, ttl: time.Minute},
+		{name: "window already closed", product: dto.ProductDTO{UnpublishAt: at(-time.Minute)}, ttl: 5 * time.Minute},
+		{name: "promotions change before ttl", product: dto.ProductDTO{PromotionsChangeAt: at(2 * time.Minute)}, ttl: 2 * time.Minute},
+		{name: "promotions change before window", product: dto.ProductDTO{UnpublishAt: at(3 * time.Minute), PromotionsChangeAt: at(30 * time.Second)}, ttl: 30 * time.Second},
+		{name: "promotions change after ttl", product: dto.ProductDTO{PromotionsChangeAt: at(time.Hour)}, ttl: 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.ttl, productTTL(tt.product, 5*time.Minute, now))
+		})
+	}
+}
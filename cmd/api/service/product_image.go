@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+)
+
+// ProductImageService manages the images of products. Every change keeps the
+// image of the product set to its primary image.
+type ProductImageService interface {
+	GetImages(ctx context.Context, productID int64) ([]dto.ProductImageDTO, error)
+	AddImage(ctx context.Context, productID int64, image dto.ProductImageDTO) (dto.ProductImageDTO, error)
+	UpdateImage(ctx context.Context, productID int64, id int64, image dto.ProductImageDTO) (dto.ProductImageDTO, error)
+	ReorderImages(ctx context.Context, productID int64, ids []int64) ([]dto.ProductImageDTO, error)
+	DeleteImage(ctx context.Context, productID int64, id int64) error
+}
+
+type productImageService struct {
+	productImageRepository repository.ProductImageRepository
+	productRepository      repository.ProductRepository
+	db                     mysql.DB
+}
+
+func NewProductImageService(productImageRepository repository.ProductImageRepository, productRepository repository.ProductRepository, db mysql.DB) ProductImageService {
+	return &productImageService{
+		productImageRepository: productImageRepository,
+		productRepository:      productRepository,
+		db:                     db,
+	}
+}
+
+func (p *productImageService) GetImages(ctx context.Context, productID int64) ([]dto.ProductImageDTO, error) {
+	var imagesDTO []dto.ProductImageDTO
+	txErr := p.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		if _, err := p.productRepository.FindByID(ctx, tx, productID); err != nil {
+			return err
+		}
+		images, err := p.productImageRepository.FindByProduct(ctx, tx, productID)
+		if err != nil {
+			return err
+		}
+		imagesDTO = toProductImageDTOs(images)
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return imagesDTO, nil
+}
+
+func (p *productImageService) AddImage(ctx context.Context, productID int64, imageDTO dto.ProductImageDTO) (dto.ProductImageDTO, error) {
+	var image domain.ProductImage
+	txErr := p.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		images, err := p.findImages(ctx, tx, productID)
+		if err != nil {
+			return err
+		}
+		image = domain.ProductImage{
+			ProductID: productID,
+			URL:       imageDTO.URL,
+			Position:  len(images),
+			AltText:   imageDTO.AltText,
+			Width:     imageDTO.Width,
+			Height:    imageDTO.Height,
+			Primary:   imageDTO.Primary,
+
+			ThumbnailStatus: domain.ThumbnailPending,
+		}
+		image.ID, err = p.productImageRepository.Create(ctx, tx, image)
+		if err != nil {
+			return err
+		}
+		images = append(images, image)
+		if image.Primary {
+			setPrimary(images, image.ID)
+		}
+		image, err = p.save(ctx, tx, productID, images, image.ID)
+		return err
+	})
+	if txErr != nil {
+		return dto.ProductImageDTO{}, txErr
+	}
+	return toProductImageDTO(image), nil
+}
+
+func (p *productImageService) UpdateImage(ctx context.Context, productID int64, id int64, imageDTO dto.ProductImageDTO) (dto.ProductImageDTO, error) {
+	var image domain.ProductImage
+	txErr := p.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		images, err := p.findImages(ctx, tx, productID)
+		if err != nil {
+			return err
+		}
+		i := indexOfImage(images, id)
+		if i < 0 {
+			return domain.NewNotFoundError(fmt.Sprintf("image with ID %d of product with ID %d not found", id, productID), nil)
+		}
+		urlChanged := images[i].SetURL(imageDTO.URL)
+		images[i].AltText = imageDTO.AltText
+		images[i].Width = imageDTO.Width
+		images[i].Height = imageDTO.Height
+		if imageDTO.Primary {
+			setPrimary(images, id)
+		} else {
+			images[i].Primary = false
+		}
+		image, err = p.save(ctx, tx, productID, images, id)
+		if err != nil || !urlChanged {
+			return err
+		}
+		return p.productImageRepository.SetRenditions(ctx, tx, id, nil)
+	})
+	if txErr != nil {
+		return dto.ProductImageDTO{}, txErr
+	}
+	return toProductImageDTO(image), nil
+}
+
+func (p *productImageService) ReorderImages(ctx context.Context, productID int64, ids []int64) ([]dto.ProductImageDTO, error) {
+	var images []domain.ProductImage
+	txErr := p.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		images, err = p.findImages(ctx, tx, productID)
+		if err != nil {
+			return err
+		}
+		if len(ids) != len(images) {
+			return domain.NewBadRequest("image_ids must list every image of the product once", nil)
+		}
+		for position, id := range ids {
+			i := indexOfImage(images, id)
+			if i < 0 {
+				return domain.NewBadRequest("image_ids must list every image of the product once", nil)
+			}
+			images[i].Position = position
+		}
+		_, err = p.save(ctx, tx, productID, images, 0)
+		return err
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return toProductImageDTOs(images), nil
+}
+
+func (p *productImageService) DeleteImage(ctx context.Context, productID int64, id int64) error {
+	return p.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := p.productRepository.Lock(ctx, tx, productID); err != nil {
+			return err
+		}
+		if err := p.productImageRepository.Delete(ctx, tx, productID, id); err != nil {
+			return err
+		}
+		images, err := p.productImageRepository.FindByProduct(ctx, tx, productID)
+		if err != nil {
+			return err
+		}
+		_, err = p.save(ctx, tx, productID, images, 0)
+		return err
+	})
+}
+
+// findImages locks a product, so concurrent changes to its images are
+// serialized, and returns its images.
+func (p *productImageService) findImages(ctx context.Context, tx *sql.Tx, productID int64) ([]domain.ProductImage, error) {
+	if _, err := p.productRepository.Lock(ctx, tx, productID); err != nil {
+		return nil, err
+	}
+	return p.productImageRepository.FindByProduct(ctx, tx, productID)
+}
+
+// save arranges and stores the images of a product, sets the image of the
+// product to the primary one and returns the image with ID id.
+func (p *productImageService) save(ctx context.Context, tx *sql.Tx, productID int64, images []domain.ProductImage, id int64) (domain.ProductImage, error) {
+	var saved domain.ProductImage
+	for _, image := range domain.ArrangeImages(images) {
+		if err := p.productImageRepository.Update(ctx, tx, image); err != nil {
+			return domain.ProductImage{}, err
+		}
+		if image.ID == id {
+			saved = image
+		}
+	}
+	if err := p.productRepository.SetImage(ctx, tx, productID, domain.PrimaryImage(images)); err != nil {
+		return domain.ProductImage{}, err
+	}
+	return saved, nil
+}
+
+// setPrimary makes the image with ID id the only primary one.
+func setPrimary(images []domain.ProductImage, id int64) {
+	for i := range images {
+		images[i].Primary = images[i].ID == id
+	}
+}
+
+func indexOfImage(images []domain.ProductImage, id int64) int {
+	for i, image := range images {
+		if image.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func toProductImageDTO(image domain.ProductImage) dto.ProductImageDTO {
+	return dto.ProductImageDTO{
+		ID:        image.ID,
+		URL:       image.URL,
+		Position:  image.Position,
+		AltText:   image.AltText,
+		Width:     image.Width,
+		Height:    image.Height,
+		Primary:   image.Primary,
+		CreatedAt: image.CreatedAt,
+
+		ThumbnailStatus: image.ThumbnailStatus,
+		Renditions:      toRenditionDTOs(image.Renditions),
+	}
+}
+
+func toRenditionDTOs(renditions []domain.Rendition) []dto.RenditionDTO {
+	if len(renditions) == 0 {
+		return nil
+	}
+	renditionsDTO := make([]dto.RenditionDTO, 0, len(renditions))
+	for _, rendition := range renditions {
+		renditionsDTO = append(renditionsDTO, dto.RenditionDTO{
+			Size:   rendition.Size,
+			URL:    rendition.URL,
+			Width:  rendition.Width,
+			Height: rendition.Height,
+		})
+	}
+	return renditionsDTO
+}
+
+func toProductImageDTOs(images []domain.ProductImage) []dto.ProductImageDTO {
+	imagesDTO := make([]dto.ProductImageDTO, 0, len(images))
+	for _, image := range images {
+		imagesDTO = append(imagesDTO, toProductImageDTO(image))
+	}
+	return imagesDTO
+}
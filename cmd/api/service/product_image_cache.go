@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/cache"
+)
+
+type cachedProductImageService struct {
+	ProductImageService
+	cache    cache.Cache
+	products cache.Namespace
+}
+
+// NewCachedProductImageService decorates next so changes to the images of a
+// product invalidate the cached product.
+func NewCachedProductImageService(next ProductImageService, c cache.Cache) ProductImageService {
+	return &cachedProductImageService{
+		ProductImageService: next,
+		cache:               c,
+		products:            cache.NewNamespace(c, productsNamespace),
+	}
+}
+
+func (c *cachedProductImageService) AddImage(ctx context.Context, productID int64, image dto.ProductImageDTO) (dto.ProductImageDTO, error) {
+	imageDTO, err := c.ProductImageService.AddImage(ctx, productID, image)
+	if err == nil {
+		c.invalidate(ctx, productID)
+	}
+	return imageDTO, err
+}
+
+func (c *cachedProductImageService) UpdateImage(ctx context.Context, productID int64, id int64, image dto.ProductImageDTO) (dto.ProductImageDTO, error) {
+	imageDTO, err := c.ProductImageService.UpdateImage(ctx, productID, id, image)
+	if err == nil {
+		c.invalidate(ctx, productID)
+	}
+	return imageDTO, err
+}
+
+func (c *cachedProductImageService) ReorderImages(ctx context.Context, productID int64, ids []int64) ([]dto.ProductImageDTO, error) {
+	imagesDTO, err := c.ProductImageService.ReorderImages(ctx, productID, ids)
+	if err == nil {
+		c.invalidate(ctx, productID)
+	}
+	return imagesDTO, err
+}
+
+func (c *cachedProductImageService) DeleteImage(ctx context.Context, productID int64, id int64) error {
+	err := c.ProductImageService.DeleteImage(ctx, productID, id)
+	if err == nil {
+		c.invalidate(ctx, productID)
+	}
+	return err
+}
+
+func (c *cachedProductImageService) invalidate(ctx context.Context, productID int64) {
+	cache.Delete(ctx, c.cache, c.products.Key(ctx, strconv.FormatInt(productID, 10)))
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// productRepositoryMock keeps products by id and reads them like the
+// repository does: FindByID only sees published products.
+type productRepositoryMock struct {
+	repository.ProductRepository
+	products map[int64]domain.Product
+	searched []dto.SearchParams
+}
+
+func (p *productRepositoryMock) FindAll(_ context.Context, _ helperdb.Tx, params dto.SearchParams) ([]domain.Product, int64, error) {
+	p.searched = append(p.searched, params)
+	return nil, 0, nil
+}
+
+func (p *productRepositoryMock) FindByID(_ context.Context, _ helperdb.Tx, id int64) (domain.Product, error) {
+	product, ok := p.products[id]
+	if !ok || product.Status != domain.ProductPublished {
+		return domain.Product{}, domain.NewNotFoundError(fmt.Sprintf("product with ID %d not found", id), nil)
+	}
+	return product, nil
+}
+
+func (p *productRepositoryMock) FindAnyByID(_ context.Context, _ helperdb.Tx, id int64) (domain.Product, error) {
+	product, ok := p.products[id]
+	if !ok {
+		return domain.Product{}, domain.NewNotFoundError(fmt.Sprintf("product with ID %d not found", id), nil)
+	}
+	return product, nil
+}
+
+func (p *productRepositoryMock) Lock(ctx context.Context, tx *sql.Tx, id int64) (domain.Product, error) {
+	return p.FindAnyByID(ctx, tx, id)
+}
+
+type categoryAttributeRepositoryMock struct {
+	repository.CategoryAttributeRepository
+	definitions []domain.AttributeDefinition
+}
+
+func (c *categoryAttributeRepositoryMock) FindByNames(_ context.Context, _ helperdb.Tx, names []string) ([]domain.AttributeDefinition, error) {
+	var found []domain.AttributeDefinition
+	for _, definition := range c.definitions {
+		for _, name := range names {
+			if definition.Name == name {
+				found = append(found, definition)
+			}
+		}
+	}
+	return found, nil
+}
+
+type productAttributeRepositoryMock struct {
+	repository.ProductAttributeRepository
+}
+
+func (p *productAttributeRepositoryMock) FindByProducts(_ context.Context, _ helperdb.Tx, _ []int64) (map[int64]map[string]string, error) {
+	return map[int64]map[string]string{}, nil
+}
+
+func newDraftProductRepository() *productRepositoryMock {
+	return &productRepositoryMock{products: map[int64]domain.Product{
+		7: {ID: 7, Title: "Draft TV", Status: domain.ProductDraft},
+	}}
+}
+
+func TestFindById_Draft(t *testing.T) {
+	productService := NewProductService(newDraftProductRepository(), nil, nil, nil, nil, nil, nil, &dbMock{}, config.Environment{})
+
+	_, err := productService.FindById(context.Background(), 7)
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.EqualError(t, err, "product with ID 7 not found")
+}
+
+func TestGetImages_Draft(t *testing.T) {
+	productImageService := NewProductImageService(nil, newDraftProductRepository(), &dbMock{})
+
+	_, err := productImageService.GetImages(context.Background(), 7)
+
+	var notFound *domain.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+}
+
+func TestGetProducts_NormalizesAttributeFilters(t *testing.T) {
+	productRepository := newDraftProductRepository()
+	categoryAttributeRepository := &categoryAttributeRepositoryMock{definitions: []domain.AttributeDefinition{
+		{ID: 1, CategoryID: 1, Name: "screen_size", Type: domain.AttributeNumber},
+		{ID: 2, CategoryID: 1, Name: "smart", Type: domain.AttributeBoolean},
+		{ID: 3, CategoryID: 2, Name: "smart", Type: domain.AttributeBoolean},
+	}}
+	productService := NewProductService(productRepository, nil, nil, &promotionRepositoryMock{}, nil, categoryAttributeRepository, &productAttributeRepositoryMock{}, &dbMock{}, config.Environment{})
+	limit, offset := int64(10), int64(1)
+
+	_, err := productService.GetProducts(context.Background(), dto.SearchParams{
+		Limit:      &limit,
+		Offset:     &offset,
+		Attributes: map[string]string{"screen_size": "55.0", "smart": "True"},
+	})
+
+	assert.NoError(t, err, "Error should not be returned")
+	if assert.Len(t, productRepository.searched, 1) {
+		assert.Equal(t, map[string]map[int64]string{
+			"screen_size": {1: "55"},
+			"smart":       {2: "true", 3: "true"},
+		}, productRepository.searched[0].AttributeValues)
+	}
+}
+
+func TestGetProducts_UndefinedAttributeFilter(t *testing.T) {
+	productRepository := newDraftProductRepository()
+	productService := NewProductService(productRepository, nil, nil, nil, nil, &categoryAttributeRepositoryMock{}, nil, &dbMock{}, config.Environment{})
+	limit, offset := int64(10), int64(1)
+
+	_, err := productService.GetProducts(context.Background(), dto.SearchParams{
+		Limit:      &limit,
+		Offset:     &offset,
+		Attributes: map[string]string{"weight": "12"},
+	})
+
+	var badRequest *domain.BadRequest
+	assert.ErrorAs(t, err, &badRequest)
+	assert.EqualError(t, err, "attribute weight is not defined")
+	assert.Empty(t, productRepository.searched)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+)
+
+type PromotionService interface {
+	GetPromotions(ctx context.Context) ([]dto.PromotionDTO, error)
+	FindByID(ctx context.Context, id int64) (dto.PromotionDTO, error)
+	CreatePromotion(ctx context.Context, promotion dto.PromotionDTO) (dto.PromotionDTO, error)
+	UpdatePromotion(ctx context.Context, promotion dto.PromotionDTO, id int64) (dto.PromotionDTO, error)
+	DeletePromotion(ctx context.Context, id int64) error
+}
+
+type promotionService struct {
+	promotionRepository repository.PromotionRepository
+	db                  mysql.DB
+}
+
+func NewPromotionService(promotionRepository repository.PromotionRepository, db mysql.DB) PromotionService {
+	return &promotionService{
+		promotionRepository: promotionRepository,
+		db:                  db,
+	}
+}
+
+func (p *promotionService) GetPromotions(ctx context.Context) ([]dto.PromotionDTO, error) {
+	var promotionsDTO []dto.PromotionDTO
+	txErr := p.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		promotions, err := p.promotionRepository.FindAll(ctx, tx)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		promotionsDTO = make([]dto.PromotionDTO, 0, len(promotions))
+		for _, promotion := range promotions {
+			promotionsDTO = append(promotionsDTO, toPromotionDTO(promotion, now))
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return promotionsDTO, nil
+}
+
+func (p *promotionService) FindByID(ctx context.Context, id int64) (dto.PromotionDTO, error) {
+	var promotion domain.Promotion
+	var err error
+	txErr := p.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		promotion, err = p.promotionRepository.FindByID(ctx, tx, id)
+		return err
+	})
+	if txErr != nil {
+		return dto.PromotionDTO{}, txErr
+	}
+	return toPromotionDTO(promotion, time.Now()), nil
+}
+
+func (p *promotionService) CreatePromotion(ctx context.Context, promotionDTO dto.PromotionDTO) (dto.PromotionDTO, error) {
+	var promotion domain.Promotion
+	txErr := p.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		id, err := p.promotionRepository.Create(ctx, tx, toPromotion(promotionDTO))
+		if err != nil {
+			return err
+		}
+		promotion, err = p.promotionRepository.FindByID(ctx, tx, id)
+		return err
+	})
+	if txErr != nil {
+		return dto.PromotionDTO{}, txErr
+	}
+	return toPromotionDTO(promotion, time.Now()), nil
+}
+
+func (p *promotionService) UpdatePromotion(ctx context.Context, promotionDTO dto.PromotionDTO, id int64) (dto.PromotionDTO, error) {
+	var promotion domain.Promotion
+	txErr := p.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := p.promotionRepository.FindByID(ctx, tx, id); err != nil {
+			return err
+		}
+		update := toPromotion(promotionDTO)
+		update.ID = id
+		if err := p.promotionRepository.Update(ctx, tx, update); err != nil {
+			return err
+		}
+		var err error
+		promotion, err = p.promotionRepository.FindByID(ctx, tx, id)
+		return err
+	})
+	if txErr != nil {
+		return dto.PromotionDTO{}, txErr
+	}
+	return toPromotionDTO(promotion, time.Now()), nil
+}
+
+func (p *promotionService) DeletePromotion(ctx context.Context, id int64) error {
+	return p.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := p.promotionRepository.Delete(ctx, tx, id)
+		return err
+	})
+}
+
+func toPromotion(promotionDTO dto.PromotionDTO) domain.Promotion {
+	return domain.Promotion{
+		Name:        promotionDTO.Name,
+		Type:        promotionDTO.Type,
+		Value:       promotionDTO.Value,
+		Currency:    promotionDTO.Currency,
+		ProductIDs:  promotionDTO.ProductIDs,
+		CategoryIDs: promotionDTO.CategoryIDs,
+		MinPrice:    promotionDTO.MinPrice,
+		MaxPrice:    promotionDTO.MaxPrice,
+		Priority:    promotionDTO.Priority,
+		Stackable:   promotionDTO.Stackable,
+		StartsAt:    promotionDTO.StartsAt,
+		EndsAt:      promotionDTO.EndsAt,
+	}
+}
+
+func toPromotionDTO(promotion domain.Promotion, now time.Time) dto.PromotionDTO {
+	return dto.PromotionDTO{
+		ID:          promotion.ID,
+		Name:        promotion.Name,
+		Type:        promotion.Type,
+		Value:       promotion.Value,
+		Currency:    promotion.Currency,
+		ProductIDs:  promotion.ProductIDs,
+		CategoryIDs: promotion.CategoryIDs,
+		MinPrice:    promotion.MinPrice,
+		MaxPrice:    promotion.MaxPrice,
+		Priority:    promotion.Priority,
+		Stackable:   promotion.Stackable,
+		StartsAt:    promotion.StartsAt,
+		EndsAt:      promotion.EndsAt,
+		Active:      promotion.ActiveAt(now),
+		CreatedAt:   promotion.CreatedAt,
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/cache"
+)
+
+type cachedPromotionService struct {
+	PromotionService
+	products cache.Namespace
+}
+
+// NewCachedPromotionService decorates next so changing a promotion
+// invalidates the cached products, whose final prices depend on it.
+func NewCachedPromotionService(next PromotionService, c cache.Cache) PromotionService {
+	return &cachedPromotionService{
+		PromotionService: next,
+		products:         cache.NewNamespace(c, productsNamespace),
+	}
+}
+
+func (c *cachedPromotionService) CreatePromotion(ctx context.Context, promotion dto.PromotionDTO) (dto.PromotionDTO, error) {
+	promotionDTO, err := c.PromotionService.CreatePromotion(ctx, promotion)
+	if err == nil {
+		c.products.Invalidate(ctx)
+	}
+	return promotionDTO, err
+}
+
+func (c *cachedPromotionService) UpdatePromotion(ctx context.Context, promotion dto.PromotionDTO, id int64) (dto.PromotionDTO, error) {
+	promotionDTO, err := c.PromotionService.UpdatePromotion(ctx, promotion, id)
+	if err == nil {
+		c.products.Invalidate(ctx)
+	}
+	return promotionDTO, err
+}
+
+func (c *cachedPromotionService) DeletePromotion(ctx context.Context, id int64) error {
+	err := c.PromotionService.DeletePromotion(ctx, id)
+	if err == nil {
+		c.products.Invalidate(ctx)
+	}
+	return err
+}
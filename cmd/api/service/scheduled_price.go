@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+)
+
+// maxLastErrorLength is the size of the last_error column.
+const maxLastErrorLength = 1024
+
+// errNotPending is returned when a due change was applied or cancelled by
+// someone else before it could be locked.
+var errNotPending = errors.New("scheduled price change is no longer pending")
+
+// ScheduledPriceService schedules price changes and applies them once they
+// are due.
+type ScheduledPriceService interface {
+	Schedule(ctx context.Context, productID int64, change dto.ScheduledPriceChangeDTO) (dto.ScheduledPriceChangeDTO, error)
+	GetScheduled(ctx context.Context, productID int64, status string) ([]dto.ScheduledPriceChangeDTO, error)
+	Cancel(ctx context.Context, productID int64, id int64) (dto.ScheduledPriceChangeDTO, error)
+	// ApplyDue applies the pending changes whose time has come and returns
+	// how many were applied.
+	ApplyDue(ctx context.Context) (int, error)
+}
+
+type scheduledPriceService struct {
+	scheduledPriceRepository repository.ScheduledPriceRepository
+	productRepository        repository.ProductRepository
+	priceHistoryRepository   repository.PriceHistoryRepository
+	db                       mysql.DB
+	config                   config.ScheduledPricesConfig
+}
+
+func NewScheduledPriceService(scheduledPriceRepository repository.ScheduledPriceRepository, productRepository repository.ProductRepository, priceHistoryRepository repository.PriceHistoryRepository, db mysql.DB, config config.ScheduledPricesConfig) ScheduledPriceService {
+	return &scheduledPriceService{
+		scheduledPriceRepository: scheduledPriceRepository,
+		productRepository:        productRepository,
+		priceHistoryRepository:   priceHistoryRepository,
+		db:                       db,
+		config:                   config,
+	}
+}
+
+func (s *scheduledPriceService) Schedule(ctx context.Context, productID int64, changeDTO dto.ScheduledPriceChangeDTO) (dto.ScheduledPriceChangeDTO, error) {
+	if !changeDTO.EffectiveAt.After(time.Now()) {
+		return dto.ScheduledPriceChangeDTO{}, domain.NewBadRequest("effective_at must be in the future", nil)
+	}
+
+	var change domain.ScheduledPriceChange
+	txErr := s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		product, err := s.productRepository.Lock(ctx, tx, productID)
+		if err != nil {
+			return err
+		}
+		// Prices sent without a currency keep the one of the product.
+		currency := changeDTO.Currency
+		if currency == "" {
+			currency = product.Currency
+		}
+		if c, ok := money.LookupCurrency(currency); ok && !c.Fits(changeDTO.Price) {
+			return domain.NewBadRequest(fmt.Sprintf("price must have at most %d decimal places", c.Digits), nil)
+		}
+
+		id, err := s.scheduledPriceRepository.Create(ctx, tx, domain.ScheduledPriceChange{
+			ProductID:   productID,
+			NewPrice:    changeDTO.Price,
+			NewCurrency: currency,
+			EffectiveAt: changeDTO.EffectiveAt,
+			Reason:      changeDTO.Reason,
+			CreatedBy:   changedBy(ctx),
+		})
+		if err != nil {
+			return err
+		}
+		change, err = s.scheduledPriceRepository.FindByID(ctx, tx, id)
+		return err
+	})
+	if txErr != nil {
+		return dto.ScheduledPriceChangeDTO{}, txErr
+	}
+	return toScheduledPriceChangeDTO(change), nil
+}
+
+func (s *scheduledPriceService) GetScheduled(ctx context.Context, productID int64, status string) ([]dto.ScheduledPriceChangeDTO, error) {
+	var changesDTO []dto.ScheduledPriceChangeDTO
+	// The changes of drafts and of products outside their publication window
+	// are listed too.
+	txErr := s.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		if _, err := s.productRepository.FindAnyByID(ctx, tx, productID); err != nil {
+			return err
+		}
+		changes, err := s.scheduledPriceRepository.FindByProduct(ctx, tx, productID, status)
+		if err != nil {
+			return err
+		}
+		changesDTO = make([]dto.ScheduledPriceChangeDTO, 0, len(changes))
+		for _, change := range changes {
+			changesDTO = append(changesDTO, toScheduledPriceChangeDTO(change))
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return changesDTO, nil
+}
+
+func (s *scheduledPriceService) Cancel(ctx context.Context, productID int64, id int64) (dto.ScheduledPriceChangeDTO, error) {
+	var change domain.ScheduledPriceChange
+	txErr := s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		change, err = s.scheduledPriceRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if change.ProductID != productID {
+			return domain.NewNotFoundError(fmt.Sprintf("scheduled price change with ID %d not found", id), nil)
+		}
+		cancelled, err := s.scheduledPriceRepository.Cancel(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if cancelled == 0 {
+			return domain.NewConflictError(fmt.Sprintf("scheduled price change with ID %d is %s and cannot be cancelled", id, change.Status), nil)
+		}
+		change, err = s.scheduledPriceRepository.FindByID(ctx, tx, id)
+		return err
+	})
+	if txErr != nil {
+		return dto.ScheduledPriceChangeDTO{}, txErr
+	}
+	return toScheduledPriceChangeDTO(change), nil
+}
+
+func (s *scheduledPriceService) ApplyDue(ctx context.Context) (int, error) {
+	var due []int64
+	txErr := s.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		var err error
+		due, err = s.scheduledPriceRepository.FindDue(ctx, tx, s.config.Limit())
+		return err
+	})
+	if txErr != nil {
+		return 0, txErr
+	}
+
+	applied := 0
+	for _, id := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		err := s.apply(ctx, id)
+		switch {
+		case err == nil:
+			applied++
+			scheduledPricesCounter.Inc("applied")
+		case errors.Is(err, errNotPending):
+		default:
+			if recordErr := s.recordFailure(ctx, id, err); recordErr != nil {
+				return applied, recordErr
+			}
+		}
+	}
+	return applied, nil
+}
+
+// apply sets the price of the product of a due change, records it in the
+// price history and marks the change as applied, all in one transaction.
+func (s *scheduledPriceService) apply(ctx context.Context, id int64) error {
+	return s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		change, err := s.scheduledPriceRepository.Lock(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if change.Status != domain.ScheduledPricePending {
+			return errNotPending
+		}
+		product, err := s.productRepository.Lock(ctx, tx, change.ProductID)
+		if err != nil {
+			return err
+		}
+		if c, ok := money.LookupCurrency(change.NewCurrency); ok && !c.Fits(change.NewPrice) {
+			return domain.NewBadRequest(fmt.Sprintf("price must have at most %d decimal places", c.Digits), nil)
+		}
+
+		if !change.NewPrice.Equal(product.Price) || change.NewCurrency != product.Currency {
+			update := product
+			update.Price = change.NewPrice
+			update.Currency = change.NewCurrency
+			if _, err = s.productRepository.Update(ctx, tx, update); err != nil {
+				return err
+			}
+			reason := change.Reason
+			if reason == "" {
+				reason = fmt.Sprintf("scheduled price change %d", change.ID)
+			}
+			_, err = s.priceHistoryRepository.Create(ctx, tx, domain.PriceChange{
+				ProductID:   product.ID,
+				OldPrice:    &product.Price,
+				OldCurrency: product.Currency,
+				NewPrice:    &update.Price,
+				NewCurrency: update.Currency,
+				ChangedBy:   change.CreatedBy,
+				Reason:      reason,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return s.scheduledPriceRepository.MarkApplied(ctx, tx, id)
+	})
+}
+
+// recordFailure counts a failed attempt of a change and schedules a retry,
+// or marks it as failed once it ran out of attempts.
+func (s *scheduledPriceService) recordFailure(ctx context.Context, id int64, cause error) error {
+	message := cause.Error()
+	if len(message) > maxLastErrorLength {
+		message = message[:maxLastErrorLength]
+	}
+	return s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		change, err := s.scheduledPriceRepository.Lock(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if change.Status != domain.ScheduledPricePending {
+			return nil
+		}
+		var retryAt *time.Time
+		if change.Attempts+1 < s.config.Attempts() {
+			next := time.Now().Add(s.config.RetryDelay())
+			retryAt = &next
+			scheduledPricesCounter.Inc("retried")
+		} else {
+			scheduledPricesCounter.Inc("failed")
+		}
+		return s.scheduledPriceRepository.RecordFailure(ctx, tx, id, message, retryAt)
+	})
+}
+
+func toScheduledPriceChangeDTO(change domain.ScheduledPriceChange) dto.ScheduledPriceChangeDTO {
+	return dto.ScheduledPriceChangeDTO{
+		ID:          change.ID,
+		ProductID:   change.ProductID,
+		Price:       change.NewPrice,
+		Currency:    change.NewCurrency,
+		EffectiveAt: change.EffectiveAt,
+		Reason:      change.Reason,
+		CreatedBy:   change.CreatedBy,
+		Status:      change.Status,
+		Attempts:    change.Attempts,
+		LastError:   change.LastError,
+		AppliedAt:   change.AppliedAt,
+		CreatedAt:   change.CreatedAt,
+	}
+}
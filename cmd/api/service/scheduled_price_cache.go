@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/cache"
+)
+
+type cachedScheduledPriceService struct {
+	ScheduledPriceService
+	products cache.Namespace
+}
+
+// NewCachedScheduledPriceService decorates next so applying scheduled price
+// changes invalidates the cached products.
+func NewCachedScheduledPriceService(next ScheduledPriceService, c cache.Cache) ScheduledPriceService {
+	return &cachedScheduledPriceService{
+		ScheduledPriceService: next,
+		products:              cache.NewNamespace(c, productsNamespace),
+	}
+}
+
+func (c *cachedScheduledPriceService) ApplyDue(ctx context.Context) (int, error) {
+	applied, err := c.ScheduledPriceService.ApplyDue(ctx)
+	if applied > 0 {
+		c.products.Invalidate(ctx)
+	}
+	return applied, err
+}
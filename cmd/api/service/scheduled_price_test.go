@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/money"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+type scheduledPriceRepositoryMock struct {
+	repository.ScheduledPriceRepository
+	changes []domain.ScheduledPriceChange
+}
+
+func (s *scheduledPriceRepositoryMock) FindByProduct(_ context.Context, _ helperdb.Tx, _ int64, _ string) ([]domain.ScheduledPriceChange, error) {
+	return s.changes, nil
+}
+
+func TestGetScheduled_Draft(t *testing.T) {
+	scheduledPriceRepository := &scheduledPriceRepositoryMock{changes: []domain.ScheduledPriceChange{
+		{ID: 1, ProductID: 7, NewPrice: money.MustParseDecimal("9.90"), NewCurrency: "BRL", Status: domain.ScheduledPricePending},
+	}}
+	scheduledPriceService := NewScheduledPriceService(scheduledPriceRepository, newDraftProductRepository(), nil, &dbMock{}, config.ScheduledPricesConfig{})
+
+	changes, err := scheduledPriceService.GetScheduled(context.Background(), 7, "")
+
+	assert.NoError(t, err, "Error should not be returned")
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, int64(1), changes[0].ID)
+	}
+}
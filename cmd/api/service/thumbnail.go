@@ -0,0 +1,229 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"path"
+	"strings"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/helperdb"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/imaging"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/mysql"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/storage"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/repository"
+)
+
+// thumbnailJPEGQuality is the quality of the renditions of JPEG images.
+const thumbnailJPEGQuality = 85
+
+var (
+	// errImageChanged is returned when an image was changed, removed or
+	// handled by someone else while its renditions were generated.
+	errImageChanged = errors.New("product image changed while generating its thumbnails")
+	// errImageSkipped is returned for images not stored by the service.
+	errImageSkipped = errors.New("product image is not stored by the service")
+)
+
+// ThumbnailService generates the renditions of the uploaded product images.
+type ThumbnailService interface {
+	// GenerateDue generates the renditions of the pending images and returns
+	// how many images got them.
+	GenerateDue(ctx context.Context) (int, error)
+}
+
+type thumbnailService struct {
+	productImageRepository repository.ProductImageRepository
+	productRepository      repository.ProductRepository
+	storage                storage.Storage
+	db                     mysql.DB
+	config                 config.ThumbnailsConfig
+}
+
+func NewThumbnailService(productImageRepository repository.ProductImageRepository, productRepository repository.ProductRepository, storage storage.Storage, db mysql.DB, config config.ThumbnailsConfig) ThumbnailService {
+	return &thumbnailService{
+		productImageRepository: productImageRepository,
+		productRepository:      productRepository,
+		storage:                storage,
+		db:                     db,
+		config:                 config,
+	}
+}
+
+func (t *thumbnailService) GenerateDue(ctx context.Context) (int, error) {
+	var pending []int64
+	txErr := t.db.WithoutTransaction(ctx, func(tx helperdb.Tx) error {
+		var err error
+		pending, err = t.productImageRepository.FindPendingThumbnails(ctx, tx, t.config.Limit())
+		return err
+	})
+	if txErr != nil {
+		return 0, txErr
+	}
+
+	generated := 0
+	for _, id := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+		err := t.generate(ctx, id)
+		switch {
+		case err == nil:
+			generated++
+			thumbnailsCounter.Inc("generated")
+		case errors.Is(err, errImageSkipped):
+			thumbnailsCounter.Inc("skipped")
+		case errors.Is(err, errImageChanged):
+		default:
+			if recordErr := t.recordFailure(ctx, id); recordErr != nil {
+				return generated, recordErr
+			}
+		}
+	}
+	return generated, nil
+}
+
+// generate renders and stores the renditions of an image outside of any
+// transaction, and then saves them unless the image changed meanwhile.
+func (t *thumbnailService) generate(ctx context.Context, id int64) error {
+	var original domain.ProductImage
+	var key string
+	var stored bool
+	txErr := t.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		original, err = t.lockPending(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if key, stored = t.storage.Key(original.URL); stored {
+			return nil
+		}
+		original.ThumbnailStatus = domain.ThumbnailSkipped
+		return t.productImageRepository.Update(ctx, tx, original)
+	})
+	if txErr != nil {
+		return txErr
+	}
+	if !stored {
+		return errImageSkipped
+	}
+
+	renditions, err := t.render(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	return t.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		// The product is locked first, as the image service does, so both
+		// never write the images of a product at the same time.
+		if _, err := t.productRepository.Lock(ctx, tx, original.ProductID); err != nil {
+			return notFoundAsChanged(err)
+		}
+		image, err := t.lockPending(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if image.URL != original.URL {
+			return errImageChanged
+		}
+		image.ThumbnailStatus = domain.ThumbnailGenerated
+		image.ThumbnailAttempts++
+		if err = t.productImageRepository.Update(ctx, tx, image); err != nil {
+			return err
+		}
+		return t.productImageRepository.SetRenditions(ctx, tx, id, renditions)
+	})
+}
+
+// render decodes the stored file key and stores a rendition of it for every
+// size, in the format of the original. Images smaller than a size are copied
+// as they are.
+func (t *thumbnailService) render(ctx context.Context, key string) ([]domain.Rendition, error) {
+	file, err := t.storage.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	src, format, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	extension := path.Ext(key)
+	base := strings.TrimSuffix(key, extension)
+	bounds := src.Bounds()
+	renditions := make([]domain.Rendition, 0, len(domain.RenditionSizes))
+	for _, size := range domain.RenditionSizes {
+		width, height := imaging.Fit(bounds.Dx(), bounds.Dy(), size.MaxSide)
+		resized := src
+		if width != bounds.Dx() || height != bounds.Dy() {
+			resized = imaging.Resize(src, width, height)
+		}
+
+		var buf bytes.Buffer
+		if format == "png" {
+			err = png.Encode(&buf, resized)
+		} else {
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: thumbnailJPEGQuality})
+		}
+		if err != nil {
+			return nil, err
+		}
+		url, err := t.storage.Put(ctx, base+"_"+size.Name+extension, &buf)
+		if err != nil {
+			return nil, err
+		}
+		renditions = append(renditions, domain.Rendition{Size: size.Name, URL: url, Width: width, Height: height})
+	}
+	return renditions, nil
+}
+
+// recordFailure counts a failed attempt of an image, which is retried on the
+// next run or marked as failed once it ran out of attempts.
+func (t *thumbnailService) recordFailure(ctx context.Context, id int64) error {
+	err := t.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		image, err := t.lockPending(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		image.ThumbnailAttempts++
+		if image.ThumbnailAttempts < t.config.Attempts() {
+			thumbnailsCounter.Inc("retried")
+		} else {
+			image.ThumbnailStatus = domain.ThumbnailFailed
+			thumbnailsCounter.Inc("failed")
+		}
+		return t.productImageRepository.Update(ctx, tx, image)
+	})
+	if errors.Is(err, errImageChanged) {
+		return nil
+	}
+	return err
+}
+
+// lockPending locks an image and returns errImageChanged when it was removed
+// or is no longer pending.
+func (t *thumbnailService) lockPending(ctx context.Context, tx *sql.Tx, id int64) (domain.ProductImage, error) {
+	image, err := t.productImageRepository.Lock(ctx, tx, id)
+	if err != nil {
+		return domain.ProductImage{}, notFoundAsChanged(err)
+	}
+	if image.ThumbnailStatus != domain.ThumbnailPending {
+		return domain.ProductImage{}, errImageChanged
+	}
+	return image, nil
+}
+
+func notFoundAsChanged(err error) error {
+	var notFound *domain.NotFoundError
+	if errors.As(err, &notFound) {
+		return errImageChanged
+	}
+	return err
+}
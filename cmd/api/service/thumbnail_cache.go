@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/cache"
+)
+
+type cachedThumbnailService struct {
+	ThumbnailService
+	products cache.Namespace
+}
+
+// NewCachedThumbnailService decorates next so generating renditions
+// invalidates the cached products.
+func NewCachedThumbnailService(next ThumbnailService, c cache.Cache) ThumbnailService {
+	return &cachedThumbnailService{
+		ThumbnailService: next,
+		products:         cache.NewNamespace(c, productsNamespace),
+	}
+}
+
+func (c *cachedThumbnailService) GenerateDue(ctx context.Context) (int, error) {
+	generated, err := c.ThumbnailService.GenerateDue(ctx)
+	if generated > 0 {
+		c.products.Invalidate(ctx)
+	}
+	return generated, err
+}
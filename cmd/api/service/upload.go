@@ -0,0 +1,123 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/config"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/domain"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/dto"
+	"github.com/melisource/fury_go-dev-base-3-v2/cmd/api/lib/storage"
+)
+
+// uploadImageTypes maps the accepted MIME types to the extension of the
+// stored files.
+var uploadImageTypes = []struct {
+	mime      string
+	extension string
+}{
+	{"image/jpeg", ".jpg"},
+	{"image/png", ".png"},
+}
+
+// UploadService validates uploaded images and stores them.
+type UploadService interface {
+	// UploadImage stores the image read from r and returns where it is
+	// served. The type is detected from the content, whatever the name or
+	// Content-Type the client sent.
+	UploadImage(ctx context.Context, r io.Reader) (dto.UploadDTO, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+type uploadService struct {
+	storage storage.Storage
+	config  config.UploadsConfig
+}
+
+func NewUploadService(storage storage.Storage, config config.UploadsConfig) UploadService {
+	return &uploadService{
+		storage: storage,
+		config:  config,
+	}
+}
+
+func (u *uploadService) UploadImage(ctx context.Context, r io.Reader) (dto.UploadDTO, error) {
+	maxSize := u.config.MaxSize()
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return dto.UploadDTO{}, domain.NewBadRequest("fail to read request body", err)
+	}
+	if len(data) == 0 {
+		return dto.UploadDTO{}, domain.NewBadRequest("file is required", nil)
+	}
+	if int64(len(data)) > maxSize {
+		return dto.UploadDTO{}, domain.NewBadRequest(fmt.Sprintf("file must be at most %d KB", maxSize>>10), nil)
+	}
+
+	detected := mimetype.Detect(data)
+	extension := ""
+	for _, t := range uploadImageTypes {
+		if detected.Is(t.mime) {
+			extension = t.extension
+			break
+		}
+	}
+	if extension == "" {
+		return dto.UploadDTO{}, domain.NewBadRequest(fmt.Sprintf("file type %s is not supported, upload a JPEG or PNG image", detected.String()), nil)
+	}
+
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return dto.UploadDTO{}, domain.NewBadRequest("file is not a valid image", err)
+	}
+	if imageConfig.Width > u.config.Width() || imageConfig.Height > u.config.Height() {
+		return dto.UploadDTO{}, domain.NewBadRequest(fmt.Sprintf("image must be at most %dx%d pixels", u.config.Width(), u.config.Height()), nil)
+	}
+
+	key, err := newUploadKey(extension)
+	if err != nil {
+		return dto.UploadDTO{}, domain.NewInternalError("fail to store uploaded file", err)
+	}
+	url, err := u.storage.Put(ctx, key, bytes.NewReader(data))
+	if err != nil {
+		return dto.UploadDTO{}, domain.NewInternalError("fail to store uploaded file", err)
+	}
+	imagesUploadedCounter.Inc()
+	return dto.UploadDTO{
+		URL:         url,
+		ContentType: detected.String(),
+		Size:        int64(len(data)),
+		Width:       imageConfig.Width,
+		Height:      imageConfig.Height,
+	}, nil
+}
+
+func (u *uploadService) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	r, err := u.storage.Open(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+		return nil, domain.NewNotFoundError(fmt.Sprintf("upload %s not found", key), err)
+	}
+	if err != nil {
+		return nil, domain.NewInternalError("fail to read uploaded file", err)
+	}
+	return r, nil
+}
+
+// newUploadKey returns a random key, so uploads never overwrite each other
+// and their URLs cannot be guessed.
+func newUploadKey(extension string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b) + extension, nil
+}
@@ -0,0 +1,10 @@
+CREATE TABLE api_keys (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(190) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL,
+    scopes VARCHAR(255) NOT NULL DEFAULT '',
+    created_at datetime NOT NULL,
+    revoked_at datetime NULL,
+    UNIQUE KEY `key_hash_idx` (`key_hash`)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE idempotency_keys (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    scope VARCHAR(255) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status_code INT NULL,
+    response_body MEDIUMBLOB NULL,
+    created_at datetime NOT NULL,
+    expires_at datetime NOT NULL,
+    UNIQUE KEY `scope_key_idx` (`scope`, `idempotency_key`),
+    KEY `expires_at_idx` (`expires_at`)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE products
+    MODIFY price DECIMAL(19, 4) NOT NULL,
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL' AFTER price;
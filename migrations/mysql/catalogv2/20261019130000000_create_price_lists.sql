@@ -0,0 +1,16 @@
+CREATE TABLE product_prices (
+    product_id BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    price DECIMAL(19, 4) NOT NULL,
+    updated_at datetime NOT NULL,
+    PRIMARY KEY (product_id, currency),
+    CONSTRAINT product_prices_ibfk_1 FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE
+);
+
+CREATE TABLE exchange_rates (
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    rate DECIMAL(19, 8) NOT NULL,
+    updated_at datetime NOT NULL,
+    PRIMARY KEY (base_currency, quote_currency)
+);
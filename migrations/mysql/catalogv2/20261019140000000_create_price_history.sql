@@ -0,0 +1,13 @@
+CREATE TABLE price_history (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    old_price DECIMAL(19, 4) NOT NULL,
+    old_currency CHAR(3) NOT NULL,
+    new_price DECIMAL(19, 4) NOT NULL,
+    new_currency CHAR(3) NOT NULL,
+    changed_by VARCHAR(255) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    changed_at datetime NOT NULL,
+    KEY product_changed_at_idx (product_id, changed_at),
+    CONSTRAINT price_history_ibfk_1 FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE
+);
@@ -0,0 +1,31 @@
+CREATE TABLE promotions (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(16) NOT NULL,
+    value DECIMAL(19, 4) NOT NULL,
+    currency CHAR(3) NULL,
+    min_price DECIMAL(19, 4) NULL,
+    max_price DECIMAL(19, 4) NULL,
+    priority INT NOT NULL DEFAULT 0,
+    stackable TINYINT(1) NOT NULL DEFAULT 0,
+    starts_at datetime NOT NULL,
+    ends_at datetime NOT NULL,
+    created_at datetime NOT NULL,
+    KEY active_idx (starts_at, ends_at)
+);
+
+CREATE TABLE promotion_products (
+    promotion_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    PRIMARY KEY (promotion_id, product_id),
+    CONSTRAINT promotion_products_ibfk_1 FOREIGN KEY (promotion_id) REFERENCES promotions (id) ON DELETE CASCADE,
+    CONSTRAINT promotion_products_ibfk_2 FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE
+);
+
+CREATE TABLE promotion_categories (
+    promotion_id BIGINT NOT NULL,
+    category_id BIGINT NOT NULL,
+    PRIMARY KEY (promotion_id, category_id),
+    CONSTRAINT promotion_categories_ibfk_1 FOREIGN KEY (promotion_id) REFERENCES promotions (id) ON DELETE CASCADE,
+    CONSTRAINT promotion_categories_ibfk_2 FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+);
@@ -0,0 +1,19 @@
+CREATE TABLE scheduled_price_changes (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    new_price DECIMAL(19, 4) NOT NULL,
+    new_currency CHAR(3) NOT NULL,
+    effective_at datetime NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error VARCHAR(1024) NOT NULL DEFAULT '',
+    next_attempt_at datetime NOT NULL,
+    applied_at datetime NULL,
+    created_at datetime NOT NULL,
+    updated_at datetime NOT NULL,
+    KEY due_idx (status, next_attempt_at),
+    KEY product_effective_at_idx (product_id, effective_at),
+    CONSTRAINT scheduled_price_changes_ibfk_1 FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE
+);
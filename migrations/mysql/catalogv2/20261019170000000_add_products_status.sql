@@ -0,0 +1,3 @@
+ALTER TABLE products
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'published' AFTER category_id,
+    ADD KEY status_idx (status);
@@ -0,0 +1,3 @@
+ALTER TABLE products
+    ADD COLUMN publish_at datetime NULL AFTER status,
+    ADD COLUMN unpublish_at datetime NULL AFTER publish_at;
@@ -0,0 +1,16 @@
+CREATE TABLE product_images (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    url VARCHAR(1024) NOT NULL,
+    position INT NOT NULL,
+    alt_text VARCHAR(255) NOT NULL DEFAULT '',
+    width INT NOT NULL DEFAULT 0,
+    height INT NOT NULL DEFAULT 0,
+    is_primary TINYINT(1) NOT NULL DEFAULT 0,
+    created_at datetime NOT NULL,
+    KEY product_position_idx (product_id, position),
+    CONSTRAINT product_images_ibfk_1 FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE
+);
+
+INSERT INTO product_images (product_id, url, position, alt_text, width, height, is_primary, created_at)
+SELECT id, image, 0, '', 0, 0, 1, NOW() FROM products WHERE image <> '';
@@ -0,0 +1,14 @@
+ALTER TABLE product_images
+    ADD COLUMN thumbnail_status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    ADD COLUMN thumbnail_attempts INT NOT NULL DEFAULT 0,
+    ADD KEY thumbnail_status_idx (thumbnail_status, id);
+
+CREATE TABLE product_image_renditions (
+    image_id BIGINT NOT NULL,
+    size VARCHAR(16) NOT NULL,
+    url VARCHAR(1024) NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    PRIMARY KEY (image_id, size),
+    CONSTRAINT product_image_renditions_ibfk_1 FOREIGN KEY (image_id) REFERENCES product_images (id) ON DELETE CASCADE
+);
@@ -0,0 +1,29 @@
+CREATE TABLE category_attributes (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    category_id BIGINT NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    type VARCHAR(16) NOT NULL,
+    required TINYINT(1) NOT NULL DEFAULT 0,
+    created_at datetime NOT NULL,
+    UNIQUE KEY category_name_idx (category_id, name),
+    KEY name_idx (name),
+    CONSTRAINT category_attributes_ibfk_1 FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+);
+
+CREATE TABLE category_attribute_values (
+    attribute_id BIGINT NOT NULL,
+    position INT NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    PRIMARY KEY (attribute_id, position),
+    CONSTRAINT category_attribute_values_ibfk_1 FOREIGN KEY (attribute_id) REFERENCES category_attributes (id) ON DELETE CASCADE
+);
+
+CREATE TABLE product_attributes (
+    product_id BIGINT NOT NULL,
+    attribute_id BIGINT NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    PRIMARY KEY (product_id, attribute_id),
+    KEY attribute_value_idx (attribute_id, value),
+    CONSTRAINT product_attributes_ibfk_1 FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE,
+    CONSTRAINT product_attributes_ibfk_2 FOREIGN KEY (attribute_id) REFERENCES category_attributes (id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+ALTER TABLE price_history
+    MODIFY old_price DECIMAL(19, 4) NULL,
+    MODIFY new_price DECIMAL(19, 4) NULL,
+    ADD COLUMN price_list BOOLEAN NOT NULL DEFAULT FALSE AFTER new_currency;
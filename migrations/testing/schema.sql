@@ -7,11 +7,182 @@ CREATE TABLE categories (
 CREATE TABLE products (
                           id BIGINT PRIMARY KEY AUTO_INCREMENT,
                           title VARCHAR(255) NOT NULL,
-                          price DECIMAL(10, 2) NOT NULL,
+                          price DECIMAL(19, 4) NOT NULL,
+                          currency CHAR(3) NOT NULL DEFAULT 'BRL',
                           description VARCHAR(255) NOT NULL,
                           image VARCHAR(255) NOT NULL,
                           created_at datetime NOT NULL,
                           category_id BIGINT NOT NULL,
+                          status VARCHAR(16) NOT NULL DEFAULT 'published',
+                          publish_at datetime NULL,
+                          unpublish_at datetime NULL,
                           FOREIGN KEY (category_id) REFERENCES categories(id),
-                          KEY `title_idx` (`title`)
-);
\ No newline at end of file
+                          KEY `title_idx` (`title`),
+                          KEY `status_idx` (`status`)
+);
+
+CREATE TABLE api_keys (
+                          id BIGINT PRIMARY KEY AUTO_INCREMENT,
+                          name VARCHAR(190) NOT NULL,
+                          prefix VARCHAR(16) NOT NULL,
+                          key_hash CHAR(64) NOT NULL,
+                          scopes VARCHAR(255) NOT NULL DEFAULT '',
+                          created_at datetime NOT NULL,
+                          revoked_at datetime NULL,
+                          UNIQUE KEY `key_hash_idx` (`key_hash`)
+);
+
+CREATE TABLE idempotency_keys (
+                          id BIGINT PRIMARY KEY AUTO_INCREMENT,
+                          scope VARCHAR(255) NOT NULL,
+                          idempotency_key VARCHAR(255) NOT NULL,
+                          request_hash CHAR(64) NOT NULL,
+                          status_code INT NULL,
+                          response_body MEDIUMBLOB NULL,
+                          response_headers JSON NULL,
+                          created_at datetime NOT NULL,
+                          expires_at datetime NOT NULL,
+                          UNIQUE KEY `scope_key_idx` (`scope`, `idempotency_key`),
+                          KEY `expires_at_idx` (`expires_at`)
+);
+
+CREATE TABLE product_prices (
+                          product_id BIGINT NOT NULL,
+                          currency CHAR(3) NOT NULL,
+                          price DECIMAL(19, 4) NOT NULL,
+                          updated_at datetime NOT NULL,
+                          PRIMARY KEY (product_id, currency),
+                          FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+CREATE TABLE exchange_rates (
+                          base_currency CHAR(3) NOT NULL,
+                          quote_currency CHAR(3) NOT NULL,
+                          rate DECIMAL(19, 8) NOT NULL,
+                          updated_at datetime NOT NULL,
+                          PRIMARY KEY (base_currency, quote_currency)
+);
+
+CREATE TABLE price_history (
+                          id BIGINT PRIMARY KEY AUTO_INCREMENT,
+                          product_id BIGINT NOT NULL,
+                          old_price DECIMAL(19, 4) NULL,
+                          old_currency CHAR(3) NOT NULL,
+                          new_price DECIMAL(19, 4) NULL,
+                          new_currency CHAR(3) NOT NULL,
+                          price_list BOOLEAN NOT NULL DEFAULT FALSE,
+                          changed_by VARCHAR(255) NOT NULL,
+                          reason VARCHAR(255) NOT NULL,
+                          changed_at datetime NOT NULL,
+                          KEY `product_changed_at_idx` (`product_id`, `changed_at`),
+                          FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+CREATE TABLE promotions (
+                          id BIGINT PRIMARY KEY AUTO_INCREMENT,
+                          name VARCHAR(255) NOT NULL,
+                          type VARCHAR(16) NOT NULL,
+                          value DECIMAL(19, 4) NOT NULL,
+                          currency CHAR(3) NULL,
+                          min_price DECIMAL(19, 4) NULL,
+                          max_price DECIMAL(19, 4) NULL,
+                          priority INT NOT NULL DEFAULT 0,
+                          stackable TINYINT(1) NOT NULL DEFAULT 0,
+                          starts_at datetime NOT NULL,
+                          ends_at datetime NOT NULL,
+                          created_at datetime NOT NULL,
+                          KEY `active_idx` (`starts_at`, `ends_at`)
+);
+
+CREATE TABLE promotion_products (
+                          promotion_id BIGINT NOT NULL,
+                          product_id BIGINT NOT NULL,
+                          PRIMARY KEY (promotion_id, product_id),
+                          FOREIGN KEY (promotion_id) REFERENCES promotions(id) ON DELETE CASCADE,
+                          FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+CREATE TABLE promotion_categories (
+                          promotion_id BIGINT NOT NULL,
+                          category_id BIGINT NOT NULL,
+                          PRIMARY KEY (promotion_id, category_id),
+                          FOREIGN KEY (promotion_id) REFERENCES promotions(id) ON DELETE CASCADE,
+                          FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE
+);
+
+CREATE TABLE scheduled_price_changes (
+                          id BIGINT PRIMARY KEY AUTO_INCREMENT,
+                          product_id BIGINT NOT NULL,
+                          new_price DECIMAL(19, 4) NOT NULL,
+                          new_currency CHAR(3) NOT NULL,
+                          effective_at datetime NOT NULL,
+                          reason VARCHAR(255) NOT NULL,
+                          created_by VARCHAR(255) NOT NULL,
+                          status VARCHAR(16) NOT NULL,
+                          attempts INT NOT NULL DEFAULT 0,
+                          last_error VARCHAR(1024) NOT NULL DEFAULT '',
+                          next_attempt_at datetime NOT NULL,
+                          applied_at datetime NULL,
+                          created_at datetime NOT NULL,
+                          updated_at datetime NOT NULL,
+                          KEY `due_idx` (`status`, `next_attempt_at`),
+                          KEY `product_effective_at_idx` (`product_id`, `effective_at`),
+                          FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+CREATE TABLE product_images (
+                          id BIGINT PRIMARY KEY AUTO_INCREMENT,
+                          product_id BIGINT NOT NULL,
+                          url VARCHAR(1024) NOT NULL,
+                          position INT NOT NULL,
+                          alt_text VARCHAR(255) NOT NULL DEFAULT '',
+                          width INT NOT NULL DEFAULT 0,
+                          height INT NOT NULL DEFAULT 0,
+                          is_primary TINYINT(1) NOT NULL DEFAULT 0,
+                          created_at datetime NOT NULL,
+                          thumbnail_status VARCHAR(16) NOT NULL DEFAULT 'pending',
+                          thumbnail_attempts INT NOT NULL DEFAULT 0,
+                          KEY `product_position_idx` (`product_id`, `position`),
+                          KEY `thumbnail_status_idx` (`thumbnail_status`, `id`),
+                          FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+CREATE TABLE product_image_renditions (
+                          image_id BIGINT NOT NULL,
+                          size VARCHAR(16) NOT NULL,
+                          url VARCHAR(1024) NOT NULL,
+                          width INT NOT NULL,
+                          height INT NOT NULL,
+                          PRIMARY KEY (image_id, size),
+                          FOREIGN KEY (image_id) REFERENCES product_images(id) ON DELETE CASCADE
+);
+
+CREATE TABLE category_attributes (
+                          id BIGINT PRIMARY KEY AUTO_INCREMENT,
+                          category_id BIGINT NOT NULL,
+                          name VARCHAR(64) NOT NULL,
+                          type VARCHAR(16) NOT NULL,
+                          required TINYINT(1) NOT NULL DEFAULT 0,
+                          created_at datetime NOT NULL,
+                          UNIQUE KEY category_name_idx (category_id, name),
+                          KEY name_idx (name),
+                          FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE
+);
+
+CREATE TABLE category_attribute_values (
+                          attribute_id BIGINT NOT NULL,
+                          position INT NOT NULL,
+                          value VARCHAR(255) NOT NULL,
+                          PRIMARY KEY (attribute_id, position),
+                          FOREIGN KEY (attribute_id) REFERENCES category_attributes(id) ON DELETE CASCADE
+);
+
+CREATE TABLE product_attributes (
+                          product_id BIGINT NOT NULL,
+                          attribute_id BIGINT NOT NULL,
+                          value VARCHAR(255) NOT NULL,
+                          PRIMARY KEY (product_id, attribute_id),
+                          KEY attribute_value_idx (attribute_id, value),
+                          FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+                          FOREIGN KEY (attribute_id) REFERENCES category_attributes(id) ON DELETE CASCADE
+);